@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ni5arga/stock-tui/internal/config"
+)
+
+// runExport implements `stock-tui export FILE`: it writes the config and
+// the files the app saves by itself, the state, notes, paper account and
+// alert log, to one archive, or to stdout when FILE is "-".
+func runExport(args []string) int {
+	fs, configPath := archiveFlags("export")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	if _, err := config.Load(*configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+
+	var paths []string
+	var err error
+	if name := fs.Arg(0); name == "-" {
+		paths, err = config.Export(os.Stdout)
+	} else {
+		paths, err = exportFile(name)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
+		return 1
+	}
+	// The archive may be going to stdout, so the report goes to stderr
+	for _, p := range paths {
+		fmt.Fprintln(os.Stderr, "Exported", p)
+	}
+	return 0
+}
+
+// runImport implements `stock-tui import FILE`: it restores an archive
+// from export, or adds the symbols of a watchlist file to the config,
+// reading stdin when FILE is "-".
+func runImport(args []string) int {
+	fs, configPath := archiveFlags("import")
+	name := fs.String("name", "", "watchlist to import a symbol list into (default: the file name)")
+	provider := fs.String("provider", "", "data provider to check the symbols with (overrides config)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	// Loading finds the config file the app would use, to replace it
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+
+	var in io.Reader = os.Stdin
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+	raw, err := io.ReadAll(in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	// Archives are gzipped; anything else is taken for a list of symbols
+	if !bytes.HasPrefix(raw, []byte{0x1f, 0x8b}) {
+		return importWatchlist(cfg, raw, watchlistName(*name, fs.Arg(0)), *provider)
+	}
+	paths, err := config.Import(bytes.NewReader(raw))
+	for _, p := range paths {
+		fmt.Println("Imported", p)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error importing: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// exportFile writes the archive to name, removing it again on failure.
+func exportFile(name string) ([]string, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := config.Export(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(name)
+		return nil, err
+	}
+	return paths, nil
+}
+
+func archiveFlags(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	var configPath string
+	fs.StringVar(&configPath, "config", "", "path to config file")
+	fs.StringVar(&configPath, "c", "", "path to config file (shorthand)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: stock-tui %s [flags] FILE\n", name)
+		fs.PrintDefaults()
+	}
+	return fs, &configPath
+}
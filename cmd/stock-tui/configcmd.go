@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ni5arga/stock-tui/internal/app"
+	"github.com/ni5arga/stock-tui/internal/config"
+)
+
+// runConfig implements `stock-tui config COMMAND`.
+func runConfig(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "init":
+			return runInit(args[1:])
+		case "validate":
+			return runValidate(args[1:])
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Usage: stock-tui config init [flags]")
+	fmt.Fprintln(os.Stderr, "       stock-tui config validate [flags] [FILE]")
+	return 2
+}
+
+// runValidate implements `stock-tui config validate [FILE]`: it checks
+// the config file, the one the app would load when FILE is not given, and
+// prints each problem as FILE:LINE:COLUMN: message. The exit status is
+// non-zero when there are any.
+func runValidate(args []string) int {
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	var configPath string
+	fs.StringVar(&configPath, "config", "", "path to config file")
+	fs.StringVar(&configPath, "c", "", "path to config file (shorthand)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: stock-tui config validate [flags] [FILE]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+	path := configPath
+	if fs.NArg() == 1 {
+		path = fs.Arg(0)
+	}
+	path = config.Locate(path)
+	if path == "" {
+		fmt.Println("No config file found; the defaults are used")
+		return 0
+	}
+
+	problems, err := config.Validate(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, p := range problems {
+		fmt.Printf("%s:%s\n", path, p)
+	}
+	if len(problems) > 0 {
+		return 1
+	}
+
+	// Values of the right type can still be out of range or refer to
+	// things that do not exist, which only the app checks
+	cfg, err := config.Load(path)
+	if err == nil {
+		var model *app.AppModel
+		if model, err = app.New(cfg); err == nil {
+			model.Close()
+		}
+	}
+	if err != nil {
+		fmt.Printf("%s: %v\n", path, err)
+		return 1
+	}
+	fmt.Printf("%s: OK\n", path)
+	return 0
+}
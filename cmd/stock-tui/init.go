@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/config"
+)
+
+// providerChoice is a provider config init offers, with the symbols a
+// new list on it starts with.
+type providerChoice struct {
+	name, about string
+	symbols     []string
+}
+
+// providerChoices are the providers config init offers. generic and
+// plugin need more settings than a question each, so they are left to
+// the sample config.
+var providerChoices = []providerChoice{
+	{"multi", "crypto and stocks (recommended)", []string{"BTC-USD", "ETH-USD", "AAPL", "MSFT"}},
+	{"yahoo", "stocks, indices and FX", []string{"AAPL", "MSFT", "GOOGL", "^GSPC"}},
+	{"coingecko", "crypto only", []string{"BTC-USD", "ETH-USD", "SOL-USD"}},
+	{"binance", "crypto pairs such as BTCUSDT", []string{"BTCUSDT", "ETHUSDT"}},
+	{"finnhub", "US stocks, needs a free API key", []string{"AAPL", "MSFT", "GOOGL"}},
+	{"stooq", "stocks, indices and FX, daily charts only", []string{"AAPL.US", "MSFT.US", "^GSPC"}},
+	{"simulator", "made-up prices, no network", []string{"BTC-USD", "ETH-USD", "AAPL", "GOOGL"}},
+}
+
+// runInit implements `stock-tui config init`: it asks for the provider,
+// its API key where one is needed, the symbols and the refresh interval,
+// and writes a commented config file with the answers. Empty answers take
+// the default shown in brackets.
+func runInit(args []string) int {
+	fs := flag.NewFlagSet("config init", flag.ContinueOnError)
+	var configPath string
+	var force bool
+	fs.StringVar(&configPath, "config", "", "file to write (default: the user config directory)")
+	fs.StringVar(&configPath, "c", "", "file to write (shorthand)")
+	fs.BoolVar(&force, "force", false, "replace an existing file")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: stock-tui config init [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	path := configPath
+	if path == "" {
+		var err error
+		if path, err = config.DefaultPath(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+	if _, err := os.Stat(path); err == nil && !force {
+		fmt.Fprintf(os.Stderr, "%s already exists; pass --force to replace it\n", path)
+		return 1
+	}
+
+	in := bufio.NewScanner(os.Stdin)
+	out := os.Stdout
+	fmt.Fprintf(out, "Setting up %s\n\nData provider:\n", path)
+	for i, p := range providerChoices {
+		fmt.Fprintf(out, "  %d. %-10s %s\n", i+1, p.name, p.about)
+	}
+	var s config.Starter
+	s.Provider = ask(in, out, "Provider", providerChoices[0].name, func(a string) (string, error) {
+		if n, err := strconv.Atoi(a); err == nil && n >= 1 && n <= len(providerChoices) {
+			return providerChoices[n-1].name, nil
+		}
+		a = strings.ToLower(a)
+		if !slices.ContainsFunc(providerChoices, func(p providerChoice) bool { return p.name == a }) {
+			return "", errors.New("pick a number or name from the list")
+		}
+		return a, nil
+	})
+	i := slices.IndexFunc(providerChoices, func(p providerChoice) bool { return p.name == s.Provider })
+
+	if s.Provider == "finnhub" {
+		hint := "blank to use FINNHUB_API_KEY"
+		if os.Getenv("FINNHUB_API_KEY") == "" {
+			hint = "blank to set FINNHUB_API_KEY later"
+		}
+		fmt.Fprintln(out, "\nGet a free key at https://finnhub.io/register")
+		s.FinnhubKey = ask(in, out, "Finnhub API key ("+hint+")", "", func(a string) (string, error) { return a, nil })
+	}
+
+	fmt.Fprintln(out)
+	symbols := ask(in, out, "Symbols, separated by spaces or commas", strings.Join(providerChoices[i].symbols, " "),
+		func(a string) (string, error) {
+			if len(splitSymbols(a)) == 0 {
+				return "", errors.New("enter at least one symbol")
+			}
+			return a, nil
+		})
+	s.Symbols = splitSymbols(symbols)
+
+	s.RefreshInterval = ask(in, out, "Refresh interval", "5s", func(a string) (string, error) {
+		d, err := time.ParseDuration(a)
+		if err != nil || d < time.Second {
+			return "", errors.New("use a duration of 1s or more, such as 5s or 1m")
+		}
+		return a, nil
+	})
+
+	if err := config.WriteStarter(path, s, force); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(out, "\nWrote %s. Run stock-tui to start.\n", path)
+	if found := config.Find(); found != "" && found != path {
+		fmt.Fprintf(out, "Note: %s is found first when no -c is given.\n", found)
+	}
+	return 0
+}
+
+// ask prompts for an answer until check accepts it, returning def for an
+// empty answer, or at the end of input.
+func ask(in *bufio.Scanner, out io.Writer, prompt, def string, check func(string) (string, error)) string {
+	for {
+		if def != "" {
+			fmt.Fprintf(out, "%s [%s]: ", prompt, def)
+		} else {
+			fmt.Fprintf(out, "%s: ", prompt)
+		}
+		if !in.Scan() {
+			fmt.Fprintln(out)
+			return def
+		}
+		a := strings.TrimSpace(in.Text())
+		if a == "" {
+			return def
+		}
+		v, err := check(a)
+		if err == nil {
+			return v
+		}
+		fmt.Fprintf(out, "  %v\n", err)
+	}
+}
+
+// splitSymbols splits a list of symbols on spaces and commas.
+func splitSymbols(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+}
@@ -11,9 +11,34 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "quote":
+			os.Exit(runQuote(os.Args[2:]))
+		case "export":
+			os.Exit(runExport(os.Args[2:]))
+		case "import":
+			os.Exit(runImport(os.Args[2:]))
+		case "config":
+			os.Exit(runConfig(os.Args[2:]))
+		case "paths":
+			os.Exit(runPaths(os.Args[2:]))
+		}
+	}
+
 	var configPath string
+	var noCache bool
+	var readOnly bool
+	var reducedMotion bool
+	var lowBandwidth bool
+	var fresh bool
 	flag.StringVar(&configPath, "config", "", "path to config file")
 	flag.StringVar(&configPath, "c", "", "path to config file (shorthand)")
+	flag.BoolVar(&noCache, "no-cache", false, "do not read or write the history cache")
+	flag.BoolVar(&readOnly, "read-only", false, "never write the config file; keep in-app changes in a state file")
+	flag.BoolVar(&reducedMotion, "reduced-motion", false, "no flashing, blinking or scrolling; the screen changes only with new data")
+	flag.BoolVar(&lowBandwidth, "low-bandwidth", false, "poll less often, skip history prefetch and fetch coarser candles")
+	flag.BoolVar(&fresh, "fresh", false, "start from the config without restoring the last session")
 	flag.Parse()
 
 	cfg, err := config.Load(configPath)
@@ -21,6 +46,21 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
+	if noCache {
+		cfg.NoCache = true
+	}
+	if readOnly {
+		cfg.ReadOnly = true
+	}
+	if reducedMotion {
+		cfg.ReducedMotion = true
+	}
+	if lowBandwidth {
+		cfg.LowBandwidth = true
+	}
+	if fresh {
+		cfg.RestoreSession = false
+	}
 
 	model, err := app.New(cfg)
 	if err != nil {
@@ -29,9 +69,12 @@ func main() {
 	}
 	defer model.Close()
 
-	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseAllMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}
+	if err := model.SaveSession(); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not save session: %v\n", err)
+	}
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/ni5arga/stock-tui/internal/config"
+)
+
+// runPaths implements `stock-tui paths [NAME]`: it prints the config file
+// the app reads and the directories it keeps its config, state and cache
+// in. With NAME, one of config, config-dir, state or cache, it prints that
+// path alone, for scripts.
+func runPaths(args []string) int {
+	fs := flag.NewFlagSet("paths", flag.ContinueOnError)
+	var configPath string
+	fs.StringVar(&configPath, "config", "", "path to config file")
+	fs.StringVar(&configPath, "c", "", "path to config file (shorthand)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: stock-tui paths [flags] [config|config-dir|state|cache]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	// Without a config file, say where `config init` would put one
+	cfgPath := config.Locate(configPath)
+	found := cfgPath != ""
+	if !found {
+		var err error
+		if cfgPath, err = config.DefaultPath(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	} else if abs, err := filepath.Abs(cfgPath); err == nil {
+		cfgPath = abs
+	}
+	paths := []struct {
+		name, label string
+		dir         func() (string, error)
+	}{
+		{"config", "config", func() (string, error) { return cfgPath, nil }},
+		{"config-dir", "config dir", config.ConfigDir},
+		{"state", "state dir", config.StateDir},
+		{"cache", "cache dir", config.CacheDir},
+	}
+
+	if fs.NArg() == 1 {
+		for _, p := range paths {
+			if p.name != fs.Arg(0) {
+				continue
+			}
+			path, err := p.dir()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+			fmt.Println(path)
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Unknown path %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, p := range paths {
+		path, err := p.dir()
+		if err != nil {
+			path = "unavailable: " + err.Error()
+		}
+		if p.name == "config" && !found {
+			path += " (not found; the defaults are used)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", p.label, path)
+	}
+	tw.Flush()
+	return 0
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// runQuote implements `stock-tui quote [symbols...]`: it fetches quotes
+// once and prints them without starting the TUI. With no symbols the
+// configured watchlist is used.
+func runQuote(args []string) int {
+	fs := flag.NewFlagSet("quote", flag.ContinueOnError)
+	var configPath, format, provider string
+	fs.StringVar(&configPath, "config", "", "path to config file")
+	fs.StringVar(&configPath, "c", "", "path to config file (shorthand)")
+	fs.StringVar(&format, "format", "table", "output format: table, json or csv")
+	fs.StringVar(&provider, "provider", "", "data provider (overrides config)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: stock-tui quote [flags] [symbols...]")
+		fs.PrintDefaults()
+	}
+
+	// Allow flags after symbols, e.g. `quote AAPL MSFT --format json`
+	var symbols []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return 2
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		symbols = append(symbols, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+	if len(symbols) == 0 {
+		symbols = cfg.Symbols
+	}
+
+	prov, err := newProvider(cfg, provider)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	quotes, err := prov.GetQuotes(symbols)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching quotes: %v\n", err)
+		return 1
+	}
+	quotes = orderQuotes(quotes, symbols)
+
+	switch strings.ToLower(format) {
+	case "json":
+		err = writeQuotesJSON(os.Stdout, quotes)
+	case "csv":
+		err = writeQuotesCSV(os.Stdout, quotes)
+	case "table":
+		err = writeQuotesTable(os.Stdout, quotes)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format %q (use table, json or csv)\n", format)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		return 1
+	}
+
+	if len(quotes) < len(symbols) {
+		fmt.Fprintf(os.Stderr, "No quote for %d of %d symbols\n", len(symbols)-len(quotes), len(symbols))
+		return 1
+	}
+	return 0
+}
+
+// newProvider builds the configured provider chain, or the one named by
+// provider when set, with the config's groups and synthetic symbols.
+func newProvider(cfg *models.AppConfig, provider string) (data.Provider, error) {
+	opts := data.Options{ExtendedHours: cfg.ExtendedHours, Generic: cfg.Generic, Plugin: cfg.Plugin, FinnhubKey: cfg.FinnhubAPIKey}
+	var prov data.Provider
+	if provider != "" {
+		prov = data.NewChain([]string{provider}, opts)
+	} else {
+		prov = data.NewChain(cfg.ProviderChain(), opts)
+	}
+	return data.WithSynthetics(data.WithGroups(prov, cfg.Groups), cfg.Synthetics)
+}
+
+// orderQuotes returns quotes in the order the symbols were requested.
+func orderQuotes(quotes []models.Quote, symbols []string) []models.Quote {
+	bySym := make(map[string]models.Quote, len(quotes))
+	for _, q := range quotes {
+		bySym[strings.ToUpper(q.Symbol)] = q
+	}
+	out := make([]models.Quote, 0, len(quotes))
+	for _, s := range symbols {
+		if q, ok := bySym[strings.ToUpper(s)]; ok {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+func currency(q models.Quote) string {
+	if q.Currency == "" {
+		return "USD"
+	}
+	return q.Currency
+}
+
+type quoteJSON struct {
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price"`
+	ChangePct float64   `json:"change_pct"`
+	Currency  string    `json:"currency"`
+	Updated   time.Time `json:"updated"`
+}
+
+func writeQuotesJSON(w io.Writer, quotes []models.Quote) error {
+	out := make([]quoteJSON, len(quotes))
+	for i, q := range quotes {
+		out[i] = quoteJSON{q.Symbol, q.Price, q.ChangePct, currency(q), q.LastUpdated}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeQuotesCSV(w io.Writer, quotes []models.Quote) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"symbol", "price", "change_pct", "currency", "updated"})
+	for _, q := range quotes {
+		_ = cw.Write([]string{
+			q.Symbol,
+			strconv.FormatFloat(q.Price, 'f', -1, 64),
+			strconv.FormatFloat(q.ChangePct, 'f', 2, 64),
+			currency(q),
+			q.LastUpdated.Format(time.RFC3339),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeQuotesTable(w io.Writer, quotes []models.Quote) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SYMBOL\tPRICE\tCHANGE\tCCY")
+	for _, q := range quotes {
+		fmt.Fprintf(tw, "%s\t%.2f\t%+.2f%%\t%s\n", q.Symbol, q.Price, q.ChangePct, currency(q))
+	}
+	return tw.Flush()
+}
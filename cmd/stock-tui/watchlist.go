@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// importWatchlist adds the symbols of a CSV or plain-text watchlist file
+// to the watchlist called name, creating it when the config has none by
+// that name and replacing its symbols when it does. Symbols the provider
+// has no quote for are skipped and listed on stderr.
+func importWatchlist(cfg *models.AppConfig, raw []byte, name, provider string) int {
+	symbols, err := config.ReadWatchlist(bytes.NewReader(raw))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	prov, err := newProvider(cfg, provider)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	quotes, err := prov.GetQuotes(symbols)
+	if err != nil && len(quotes) == 0 {
+		fmt.Fprintf(os.Stderr, "Error checking symbols: %v\n", err)
+		return 1
+	}
+	quoted := make(map[string]bool, len(quotes))
+	for _, q := range quotes {
+		quoted[strings.ToUpper(q.Symbol)] = true
+	}
+	var kept []string
+	for _, s := range symbols {
+		if quoted[strings.ToUpper(s)] {
+			kept = append(kept, s)
+		} else {
+			fmt.Fprintf(os.Stderr, "Skipped %s: no quote from %s\n", s, prov.Name())
+		}
+	}
+	if len(kept) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: none of the %d symbols has a quote, nothing imported\n", len(symbols))
+		return 1
+	}
+
+	path, err := saveWatchlist(cfg, models.Watchlist{Name: name, Symbols: kept})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error importing: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Imported %d of %d symbols into %q in %s\n", len(kept), len(symbols), name, path)
+	return 0
+}
+
+// saveWatchlist writes wl to the config file, or in read-only mode to the
+// state file, which can only override a list the config already has.
+func saveWatchlist(cfg *models.AppConfig, wl models.Watchlist) (string, error) {
+	if cfg.ReadOnly {
+		if !slices.ContainsFunc(cfg.Lists(), func(l models.Watchlist) bool { return l.Name == wl.Name }) {
+			return "", fmt.Errorf("read_only is set and the config has no watchlist %q to import into", wl.Name)
+		}
+		st, err := config.LoadState()
+		if err != nil {
+			return "", err
+		}
+		st.SetWatchlist(wl.Name, wl.Symbols)
+		return config.SaveState(st)
+	}
+	// The first [[watchlists]] entry replaces the top-level symbols list,
+	// so that list becomes an entry of its own before another is added
+	if len(cfg.Watchlists) == 0 {
+		if _, err := config.SaveWatchlist(cfg.Lists()[0]); err != nil {
+			return "", err
+		}
+	}
+	return config.SaveWatchlist(wl)
+}
+
+// watchlistName is the list a file is imported into: name when given,
+// otherwise the file's name without its extension.
+func watchlistName(name, file string) string {
+	if name != "" {
+		return name
+	}
+	if file == "-" {
+		return "Imported"
+	}
+	return strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+}
@@ -0,0 +1,199 @@
+package alerts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Condition is the kind of threshold an alert watches.
+type Condition int
+
+const (
+	Above Condition = iota
+	Below
+	Move // absolute daily % change
+)
+
+// Alert is a single threshold on a symbol. Alerts are edge-triggered: they
+// fire when the condition becomes true and re-arm once it is false again.
+type Alert struct {
+	Symbol    string
+	Condition Condition
+	Value     float64
+
+	triggered bool
+	checked   time.Time // last evaluated, for the schedule's interval
+}
+
+func (a Alert) String() string {
+	switch a.Condition {
+	case Above:
+		return fmt.Sprintf("above %.2f", a.Value)
+	case Below:
+		return fmt.Sprintf("below %.2f", a.Value)
+	default:
+		return fmt.Sprintf("moves ±%.2f%%", a.Value)
+	}
+}
+
+func (a Alert) met(q models.Quote) bool {
+	switch a.Condition {
+	case Above:
+		return q.Price >= a.Value
+	case Below:
+		return q.Price <= a.Value
+	default:
+		return q.ChangePct >= a.Value || q.ChangePct <= -a.Value
+	}
+}
+
+// Trigger records an alert firing against a quote.
+type Trigger struct {
+	Alert     Alert
+	Price     float64
+	ChangePct float64
+	At        time.Time
+}
+
+// Message describes the trigger, using name for the symbol.
+func (t Trigger) Message(name string) string {
+	return fmt.Sprintf("%s %s (now %.2f, %+.2f%%)", name, t.Alert, t.Price, t.ChangePct)
+}
+
+// Engine holds the configured alerts and evaluates them against quotes.
+type Engine struct {
+	alerts   []*Alert
+	schedule Schedule
+}
+
+// NewEngine builds an engine from config rules. A rule may set several
+// thresholds; each becomes its own alert.
+func NewEngine(rules []models.AlertRule) *Engine {
+	e := &Engine{}
+	for _, r := range rules {
+		if r.Symbol == "" {
+			continue
+		}
+		if r.Above > 0 {
+			e.Add(Alert{Symbol: r.Symbol, Condition: Above, Value: r.Above})
+		}
+		if r.Below > 0 {
+			e.Add(Alert{Symbol: r.Symbol, Condition: Below, Value: r.Below})
+		}
+		if r.MovePct > 0 {
+			e.Add(Alert{Symbol: r.Symbol, Condition: Move, Value: r.MovePct})
+		}
+	}
+	return e
+}
+
+// SetSchedule limits when the alerts are evaluated.
+func (e *Engine) SetSchedule(s Schedule) {
+	e.schedule = s
+}
+
+func (e *Engine) Add(a Alert) {
+	e.alerts = append(e.alerts, &a)
+}
+
+// Rules returns the alerts as config rules, one per alert, for saving.
+func (e *Engine) Rules() []models.AlertRule {
+	rules := make([]models.AlertRule, len(e.alerts))
+	for i, a := range e.alerts {
+		r := models.AlertRule{Symbol: a.Symbol}
+		switch a.Condition {
+		case Above:
+			r.Above = a.Value
+		case Below:
+			r.Below = a.Value
+		default:
+			r.MovePct = a.Value
+		}
+		rules[i] = r
+	}
+	return rules
+}
+
+// Clear removes every alert for symbol.
+func (e *Engine) Clear(symbol string) {
+	kept := e.alerts[:0]
+	for _, a := range e.alerts {
+		if a.Symbol != symbol {
+			kept = append(kept, a)
+		}
+	}
+	e.alerts = kept
+}
+
+// For returns the alerts set on symbol.
+func (e *Engine) For(symbol string) []Alert {
+	var out []Alert
+	for _, a := range e.alerts {
+		if a.Symbol == symbol {
+			out = append(out, *a)
+		}
+	}
+	return out
+}
+
+// Evaluate checks every alert the schedule allows against the latest
+// quotes and returns the ones that fired on this update. An alert left
+// out keeps its state, so one whose condition came true meanwhile fires
+// on its next evaluation.
+func (e *Engine) Evaluate(quotes []models.Quote) []Trigger {
+	now := time.Now()
+	if len(e.alerts) == 0 || e.schedule.Quiet(now) {
+		return nil
+	}
+	qmap := make(map[string]models.Quote, len(quotes))
+	for _, q := range quotes {
+		qmap[q.Symbol] = q
+	}
+
+	var fired []Trigger
+	for _, a := range e.alerts {
+		q, ok := qmap[a.Symbol]
+		if !ok || q.Price == 0 || !e.schedule.due(a, now) {
+			continue
+		}
+		a.checked = now
+		met := a.met(q)
+		if met && !a.triggered {
+			fired = append(fired, Trigger{Alert: *a, Price: q.Price, ChangePct: q.ChangePct, At: q.LastUpdated})
+		}
+		a.triggered = met
+	}
+	return fired
+}
+
+// Parse reads an interactive alert spec: ">250" or "above 250", "<200" or
+// "below 200", and "5%" for a daily move of at least 5% either way.
+func Parse(symbol, spec string) (Alert, error) {
+	s := strings.ToLower(strings.TrimSpace(spec))
+	a := Alert{Symbol: symbol}
+
+	switch {
+	case strings.HasSuffix(s, "%"):
+		a.Condition = Move
+		s = strings.TrimPrefix(strings.TrimSuffix(s, "%"), "±")
+	case strings.HasPrefix(s, ">"), strings.HasPrefix(s, "above"):
+		a.Condition = Above
+		s = strings.TrimPrefix(strings.TrimPrefix(s, ">"), "above")
+	case strings.HasPrefix(s, "<"), strings.HasPrefix(s, "below"):
+		a.Condition = Below
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "<"), "below")
+	default:
+		return a, fmt.Errorf("use >price, <price or N%%")
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v <= 0 {
+		return a, fmt.Errorf("invalid threshold %q", strings.TrimSpace(s))
+	}
+	a.Value = v
+	return a, nil
+}
@@ -0,0 +1,32 @@
+package alerts
+
+import "time"
+
+// LogEntry is a fired alert as kept in the alert log.
+type LogEntry struct {
+	At        time.Time `json:"at"`
+	Symbol    string    `json:"symbol"`
+	Condition string    `json:"condition"`
+	Price     float64   `json:"price"`
+	ChangePct float64   `json:"change_pct"`
+}
+
+// Entry returns the trigger as logged, at the time of the quote that fired
+// it or, when the source gave none, now.
+func (t Trigger) Entry() LogEntry {
+	at := t.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return LogEntry{At: at, Symbol: t.Alert.Symbol, Condition: t.Alert.String(), Price: t.Price, ChangePct: t.ChangePct}
+}
+
+// AppendLog adds e to the end of log, dropping the oldest entries beyond
+// limit.
+func AppendLog(log []LogEntry, e LogEntry, limit int) []LogEntry {
+	log = append(log, e)
+	if len(log) > limit {
+		log = log[len(log)-limit:]
+	}
+	return log
+}
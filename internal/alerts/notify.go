@@ -0,0 +1,36 @@
+package alerts
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Notify sends a desktop notification using the platform's native tool:
+// notify-send on Linux/BSD, osascript on macOS and a PowerShell toast on
+// Windows.
+func Notify(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", windowsToast(title, body))
+	default:
+		cmd = exec.Command("notify-send", "--app-name=stock-tui", title, body)
+	}
+	return cmd.Run()
+}
+
+func windowsToast(title, body string) string {
+	esc := func(s string) string { return strings.ReplaceAll(s, "'", "''") }
+	return fmt.Sprintf(`[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$t = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$x = $t.GetElementsByTagName('text')
+$x.Item(0).AppendChild($t.CreateTextNode('%s')) > $null
+$x.Item(1).AppendChild($t.CreateTextNode('%s')) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('stock-tui').Show([Windows.UI.Notifications.ToastNotification]::new($t))`,
+		esc(title), esc(body))
+}
@@ -0,0 +1,75 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Schedule limits when alerts are evaluated. The zero Schedule evaluates
+// every alert on every quote update.
+type Schedule struct {
+	Interval    time.Duration // least time between evaluations of an alert
+	MarketHours bool          // only while the symbol's regular session is open
+
+	quiet    bool
+	from, to int // quiet hours in minutes after local midnight
+}
+
+// NewSchedule reads the [alert_schedule] config. Quiet hours need both
+// ends, as local times like "22:00"; a start later than the end spans
+// midnight.
+func NewSchedule(cfg models.AlertSchedule) (Schedule, error) {
+	s := Schedule{Interval: cfg.Interval, MarketHours: cfg.MarketHours}
+	if cfg.Interval < 0 {
+		return s, errors.New("alert_schedule: interval must not be negative")
+	}
+	if cfg.QuietStart == "" && cfg.QuietEnd == "" {
+		return s, nil
+	}
+	from, err := clockMinutes(cfg.QuietStart)
+	if err != nil {
+		return s, fmt.Errorf("alert_schedule: quiet_start: %w", err)
+	}
+	to, err := clockMinutes(cfg.QuietEnd)
+	if err != nil {
+		return s, fmt.Errorf("alert_schedule: quiet_end: %w", err)
+	}
+	if from == to {
+		return s, errors.New("alert_schedule: quiet_start and quiet_end are the same time")
+	}
+	s.quiet, s.from, s.to = true, from, to
+	return s, nil
+}
+
+func clockMinutes(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("expected a time like 22:00, got %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Quiet reports whether t falls in the quiet hours.
+func (s Schedule) Quiet(t time.Time) bool {
+	if !s.quiet {
+		return false
+	}
+	m := t.Hour()*60 + t.Minute()
+	if s.from < s.to {
+		return m >= s.from && m < s.to
+	}
+	return m >= s.from || m < s.to
+}
+
+// due reports whether a should be evaluated at now.
+func (s Schedule) due(a *Alert, now time.Time) bool {
+	if s.Interval > 0 && now.Sub(a.checked) < s.Interval {
+		return false
+	}
+	// A closed market's quotes are stale, so a crossing there means nothing
+	return !s.MarketHours || market.CalendarFor(a.Symbol).State(now) == market.StateOpen
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/alerts"
+	"github.com/ni5arga/stock-tui/internal/config"
+)
+
+type alertLogSavedMsg struct {
+	err error
+}
+
+// openAlertLog shows the alerts that have fired, newest first.
+func (m *AppModel) openAlertLog() {
+	m.alertLog.SetEntries(m.firedAlerts)
+	m.alertLog.Open()
+}
+
+// logAlerts appends the triggers to the alert log, keeping the newest
+// alert_log_max entries, and saves it. A limit of zero keeps no log.
+func (m *AppModel) logAlerts(triggers []alerts.Trigger) tea.Cmd {
+	if len(triggers) == 0 || m.cfg.AlertLogMax == 0 {
+		return nil
+	}
+	for _, t := range triggers {
+		m.firedAlerts = alerts.AppendLog(m.firedAlerts, t.Entry(), m.cfg.AlertLogMax)
+	}
+	m.alertLog.SetEntries(m.firedAlerts)
+	return m.saveAlertLog()
+}
+
+// clearAlertLog empties the alert log, on screen and on disk.
+func (m *AppModel) clearAlertLog() tea.Cmd {
+	m.firedAlerts = nil
+	m.alertLog.SetEntries(nil)
+	return tea.Batch(m.saveAlertLog(), m.notify("Alert log cleared"))
+}
+
+// saveAlertLog writes the alert log in the background, one write at a
+// time like saveNotes.
+func (m *AppModel) saveAlertLog() tea.Cmd {
+	if m.alertLogSaving {
+		m.alertLogDirty = true
+		return nil
+	}
+	m.alertLogSaving = true
+	log := slices.Clone(m.firedAlerts)
+	return func() tea.Msg {
+		return alertLogSavedMsg{err: config.SaveAlertLog(log)}
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/alerts"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// jumpToAlert selects the symbol of the most recent alert, switching to
+// the first watchlist holding it when the active one doesn't, and charts
+// it at the configured alert range.
+func (m *AppModel) jumpToAlert() tea.Cmd {
+	if m.lastAlert == nil {
+		return m.notify("No alerts have fired yet")
+	}
+	sym := m.lastAlert.Alert.Symbol
+	list := m.list
+	if !slices.Contains(m.symbols(), sym) {
+		list = slices.IndexFunc(m.lists, func(l watchlistState) bool {
+			return slices.Contains(l.symbols, sym)
+		})
+	}
+	if list < 0 || !m.listModel(list).Select(sym) {
+		return m.notify(m.aliases.Name(sym) + " is not in a watchlist")
+	}
+	if tr, ok := m.alertRange(m.lastAlert.Alert); ok {
+		m.setTimeRange(tr)
+	}
+
+	var cmds []tea.Cmd
+	if list != m.list {
+		cmds = append(cmds, m.switchWatchlist(list))
+	} else {
+		cmds = append(cmds, m.loadCurrentChart())
+	}
+	m.syncCompare()
+	cmds = append(cmds, m.loadTab(sym))
+	return tea.Batch(cmds...)
+}
+
+// alertRange returns the chart range to show a fired alert at: a fixed
+// range from alert_range, or with "auto" the hour around a price crossing
+// and the day behind a daily move. Unset keeps the current range.
+func (m *AppModel) alertRange(a alerts.Alert) (models.TimeRange, bool) {
+	switch {
+	case m.cfg.AlertRange == "":
+		return "", false
+	case strings.EqualFold(m.cfg.AlertRange, "auto"):
+		if a.Condition == alerts.Move {
+			return models.Range24H, true
+		}
+		return models.Range1H, true
+	}
+	return models.ParseTimeRange(m.cfg.AlertRange)
+}
@@ -1,123 +1,646 @@
 package app
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/fsnotify/fsnotify"
+	"github.com/ni5arga/stock-tui/internal/alerts"
+	"github.com/ni5arga/stock-tui/internal/config"
 	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/keys"
+	"github.com/ni5arga/stock-tui/internal/market"
 	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/paper"
+	"github.com/ni5arga/stock-tui/internal/ui/alertlog"
 	"github.com/ni5arga/stock-tui/internal/ui/chart"
+	"github.com/ni5arga/stock-tui/internal/ui/daterange"
+	"github.com/ni5arga/stock-tui/internal/ui/earnings"
 	"github.com/ni5arga/stock-tui/internal/ui/footer"
+	"github.com/ni5arga/stock-tui/internal/ui/fundamentals"
+	"github.com/ni5arga/stock-tui/internal/ui/heatmap"
 	"github.com/ni5arga/stock-tui/internal/ui/help"
+	"github.com/ni5arga/stock-tui/internal/ui/lookup"
+	"github.com/ni5arga/stock-tui/internal/ui/news"
+	"github.com/ni5arga/stock-tui/internal/ui/note"
+	"github.com/ni5arga/stock-tui/internal/ui/options"
+	"github.com/ni5arga/stock-tui/internal/ui/orderbook"
+	"github.com/ni5arga/stock-tui/internal/ui/palette"
+	"github.com/ni5arga/stock-tui/internal/ui/picker"
+	"github.com/ni5arga/stock-tui/internal/ui/prompt"
+	"github.com/ni5arga/stock-tui/internal/ui/risk"
+	"github.com/ni5arga/stock-tui/internal/ui/settings"
+	"github.com/ni5arga/stock-tui/internal/ui/stats"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+	"github.com/ni5arga/stock-tui/internal/ui/tabs"
+	"github.com/ni5arga/stock-tui/internal/ui/ticker"
+	"github.com/ni5arga/stock-tui/internal/ui/tour"
 	"github.com/ni5arga/stock-tui/internal/ui/watchlist"
 )
 
 type AppModel struct {
 	cfg      *models.AppConfig
-	provider data.Provider
+	provider data.Provider // active watchlist's provider
+	keys     keys.KeyMap
+	onStart  []startCommand
 
-	watchlist watchlist.Model
+	watchlist watchlist.Model // active list; others are parked in lists
 	chart     chart.Model
 	footer    footer.Model
+	ticker    ticker.Model
 	help      help.Model
+	stats     stats.Model
+	risk      risk.Model
+	calendar  earnings.Model
+	alertLog  alertlog.Model
+	settings  settings.Model
+	prompt    prompt.Model
+	note      note.Model
+	dateRange daterange.Model
+	picker    picker.Model
+	news      news.Model
+	heatmap   heatmap.Model
+	lookup    lookup.Model
+	palette   palette.Model
+	tour      tour.Model
+	details   fundamentals.Model
+	options   options.Model
+	book      orderbook.Model // under the chart for crypto pairs
+	tabs      tabs.Model      // right-hand pane: chart, news, details, options
+
+	newsSource  data.NewsProvider
+	optionsSrc  data.OptionsProvider
+	bookSrc     data.OrderBookProvider
+	earningsSrc data.EarningsProvider
+	searcher    data.SymbolSearcher
+	headlines   map[string][]models.Headline // last headlines per symbol
+	fundCache   map[string]fundamentalsEntry
+	chains      map[chainKey]optionsEntry
+	bookOn      bool   // order book pane toggled on
+	bookFor     string // pair the order book is showing; "" when hidden
+	bookSeq     int    // identifies the latest order book poll
+
+	// Next earnings report per stock, for the calendar and row flags
+	earnings        map[string]models.Earnings
+	earningsUpdated time.Time
+	earningsFetched []string // stocks the last fetch asked for
+	earningsPending bool
+
+	// When each symbol's 52-week range was last asked of the fundamentals
+	yearTried   map[string]time.Time
+	yearPending bool
+
+	lists []watchlistState
+	list  int // index of the active watchlist
 
 	width  int
 	height int
 
-	timeRange     models.TimeRange
-	refreshTicker *time.Ticker
-	lastQuotes    []models.Quote
-	lastHistory   map[string][]models.Candle
-	err           error
+	wlPercent  int    // watchlist pane width in percent; 0 is automatic
+	panes      panes  // pane geometry from the last layout
+	splitDrag  bool   // the divider between the panes is being dragged
+	stacked    bool   // the watchlist is over the right-hand pane, not beside it
+	widthSeq   int    // identifies the latest resize, to save once it settles
+	focus      int    // pane with the keyboard focus, focusList or focusTab
+	layoutName string // last layout applied or saved
+
+	timeRange   models.TimeRange
+	scrubBase   models.TimeRange // range being stepped through with [ / ]
+	scrubSteps  int              // periods back from the latest; 0 is live
+	lastQuotes  []models.Quote
+	currencies  map[string]string // native currency reported per symbol
+	lastHistory map[string][]models.Candle
+	err         error
+
+	portfolio map[models.TimeRange][]models.Candle // portfolio value per range
+
+	// Conversion into display_currency, when set
+	fx             market.Rates
+	fxSource       data.FXSource
+	fxUpdated      time.Time
+	fxCodesFetched []string // currencies the last fetch asked for
+	fxPending      bool
+
+	aliases models.Aliases
+	refresh refreshRules
+	paused  map[string]bool // closed-market symbols polled since the close
+
+	// Read-only mode keeps in-app changes here instead of the config
+	state       config.State
+	stateSaving bool
+	stateDirty  bool
+	alerts      *alerts.Engine
+	alertSymbol string          // symbol the alert prompt is editing
+	lastAlert   *alerts.Trigger // most recent trigger, for the jump key
+	footerSeq   int             // identifies the latest transient footer message
+
+	// Fired alerts, oldest first, as kept in alert_log.json
+	firedAlerts    []alerts.LogEntry
+	alertLogSaving bool
+	alertLogDirty  bool
+
+	// Changes made on the settings screen, by config key, to save when it
+	// closes
+	settingsEdits map[string]any
+
+	notes       map[string]string // the user's note per symbol
+	session     config.Session    // screen to restore at start
+	notesSaving bool
+	notesDirty  bool
+
+	// Paper trading: the account, the last price of each quoted symbol to
+	// fill and value it at, and the symbol the order prompt is for
+	paper       *paper.Account
+	paperPrices map[string]float64
+	tradeSymbol string
+	paperSaving bool
+	paperDirty  bool
+
+	// Watching the config file for edits
+	watcher     *fsnotify.Watcher
+	watchedPath string
+	reloadSeq   int // identifies the latest change, to reload once writes settle
 }
 
-type tickMsg time.Time
+// statsRange is the history range backing the returns summary.
+const statsRange = models.Range1Y
+
+// Prompt tags identify which action a submitted prompt value belongs to.
+const (
+	promptAnchor = "anchor"
+	promptAlert  = "alert"
+	promptLayout = "layout"
+	promptTrade  = "trade"
+)
+
+func validateAnchor(s string) error {
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return fmt.Errorf("expected a date like 2024-03-01")
+	}
+	if !t.Before(time.Now()) {
+		return fmt.Errorf("date must be in the past")
+	}
+	return nil
+}
+
+type tickMsg struct {
+	list     int
+	schedule int // index into the list's schedules
+	at       time.Time
+	done     chan struct{} // the schedule's, to tell a replaced one
+}
 
 type quotesMsg struct {
+	list   int
 	quotes []models.Quote
 	err    error
 }
 
 type historyMsg struct {
+	provider string // name of the provider fetched from
+	symbol   string
+	tr       models.TimeRange
+	data     []models.Candle
+	err      error
+}
+
+type retryHistoryMsg struct {
 	symbol string
 	tr     models.TimeRange
-	data   []models.Candle
+}
+
+type newsMsg struct {
+	symbol    string
+	headlines []models.Headline
+	err       error
+}
+
+type lookupMsg struct {
+	query   string
+	matches []models.SymbolMatch
+	err     error
+}
+
+type fundamentalsMsg struct {
+	symbol string
+	data   models.Fundamentals
 	err    error
 }
 
-type retryHistoryMsg struct {
+type alertFlashDoneMsg struct {
 	symbol string
-	tr     models.TimeRange
 }
 
+// queueMsg reports a change in the number of requests waiting for a
+// provider's rate limit.
+type queueMsg struct {
+	depth int
+}
+
+type footerClearMsg struct {
+	seq int
+}
+
+// How long alert highlights and footer notices stay on screen.
+const (
+	flashDuration   = 5 * time.Second
+	messageDuration = 4 * time.Second
+)
+
+// lowBandwidthRefresh is the shortest polling interval in low-bandwidth
+// mode.
+const lowBandwidthRefresh = time.Minute
+
 func New(cfg *models.AppConfig) (*AppModel, error) {
-	prov, _ := data.NewProvider(cfg.Provider)
+	var state config.State
+	if cfg.ReadOnly {
+		var err error
+		if state, err = config.LoadState(); err != nil {
+			return nil, err
+		}
+		state.Apply(cfg)
+	}
+
+	tr, ok := models.ParseTimeRange(cfg.DefaultRange)
+	if !ok {
+		tr = models.Range24H
+	}
+	iv, ok := models.ParseInterval(cfg.CandleInterval)
+	if !ok {
+		return nil, fmt.Errorf("unknown candle_interval %q (use auto, 1m, 5m, 1h or 1d)", cfg.CandleInterval)
+	}
+	tr = tr.WithInterval(iv)
+
+	aliases := cfg.AliasMap()
+
+	theme, err := styles.Lookup(cfg.Theme)
+	if err != nil {
+		return nil, err
+	}
+	theme, err = theme.WithColors(cfg.Colors).WithChartColors(cfg.ChartColors)
+	if err != nil {
+		return nil, err
+	}
+	styles.Use(theme)
+
+	if cfg.AlertRange != "" && !strings.EqualFold(cfg.AlertRange, "auto") {
+		if _, ok := models.ParseTimeRange(cfg.AlertRange); !ok {
+			return nil, fmt.Errorf("unknown alert_range %q (use auto or a range such as 1H)", cfg.AlertRange)
+		}
+	}
+	schedule, err := alerts.NewSchedule(cfg.AlertSchedule)
+	if err != nil {
+		return nil, err
+	}
+	engine := alerts.NewEngine(cfg.Alerts)
+	engine.SetSchedule(schedule)
 
-	tr := models.Range24H
-	switch cfg.DefaultRange {
-	case "1H":
-		tr = models.Range1H
-	case "7D":
-		tr = models.Range7D
-	case "30D":
-		tr = models.Range30D
+	if c := cfg.DisplayCurrency; c != "" && (len(c) != 3 || strings.ContainsFunc(c, func(r rune) bool { return !unicode.IsLetter(r) })) {
+		return nil, fmt.Errorf("invalid display_currency %q (use an ISO code such as USD)", c)
 	}
 
+	switch strings.ToLower(cfg.CopyFormat) {
+	case "", "markdown", "csv":
+	default:
+		return nil, fmt.Errorf("unknown copy_format %q (use markdown or csv)", cfg.CopyFormat)
+	}
+	switch strings.ToLower(cfg.Export.WatchlistFormat) {
+	case "", "csv", "text":
+	default:
+		return nil, fmt.Errorf("unknown export watchlist_format %q (use csv or text)", cfg.Export.WatchlistFormat)
+	}
+
+	if cfg.AlertLogMax < 0 {
+		return nil, fmt.Errorf("invalid alert_log_max %d (use 0 or more)", cfg.AlertLogMax)
+	}
+
+	if cfg.ChartGridRows < 2 {
+		return nil, fmt.Errorf("invalid chart_grid_rows %d (use 2 or more)", cfg.ChartGridRows)
+	}
+
+	if err := validateLayouts(cfg.Layouts); err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(cfg.PaneLayout) {
+	case "", paneLayoutAuto, paneLayoutSide, paneLayoutStacked:
+	default:
+		return nil, fmt.Errorf("unknown pane_layout %q (use auto, side or stacked)", cfg.PaneLayout)
+	}
+	if w := cfg.WatchlistWidth; w != 0 && (w < minWatchlistWidth || w > maxWatchlistWidth) {
+		return nil, fmt.Errorf("invalid watchlist_width %d (use %d to %d)", w, minWatchlistWidth, maxWatchlistWidth)
+	}
+	if err := validateSections(cfg.Sections); err != nil {
+		return nil, err
+	}
+	if err := watchlist.CheckColumns(cfg.Columns); err != nil {
+		return nil, err
+	}
+
+	refresh, err := newRefreshRules(cfg.RefreshRules)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.LowBandwidth {
+		refresh = refresh.atLeast(lowBandwidthRefresh)
+	}
+
+	if err := data.SetRateLimits(cfg.RateLimits); err != nil {
+		return nil, err
+	}
+
+	tk, err := ticker.New(cfg.Ticker.Fields, cfg.Ticker.Speed)
+	if err != nil {
+		return nil, err
+	}
+	tk.SetAliases(aliases)
+	tk.SetStatic(cfg.ReducedMotion)
+
+	switch strings.ToLower(cfg.Export.Format) {
+	case "", "csv", "json":
+	default:
+		return nil, fmt.Errorf("unknown export format %q (use csv or json)", cfg.Export.Format)
+	}
+	switch strings.ToLower(cfg.Export.Snapshot) {
+	case "", "text", "ansi":
+	default:
+		return nil, fmt.Errorf("unknown export snapshot %q (use text or ansi)", cfg.Export.Snapshot)
+	}
+	if w := cfg.Export.Width; w != 0 && w < 20 {
+		return nil, fmt.Errorf("invalid export width %d (use 0 for the chart pane's, or 20 or more)", w)
+	}
+	if h := cfg.Export.Height; h != 0 && h < 8 {
+		return nil, fmt.Errorf("invalid export height %d (use 0 for the chart pane's, or 8 or more)", h)
+	}
+
+	km := keys.Default()
+	if err := km.Apply(cfg.Keys); err != nil {
+		return nil, err
+	}
+
+	names := slices.Clone(cfg.ProviderChain())
+	for _, l := range cfg.Lists() {
+		names = append(names, l.Provider)
+	}
+	for _, name := range names {
+		if err := data.CheckProvider(name, providerOptions(cfg)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := data.CheckPortfolio(cfg.Portfolio); err != nil {
+		return nil, err
+	}
+
+	onStart, err := parseOnStart(cfg.OnStart, cfg, km)
+	if err != nil {
+		return nil, err
+	}
+
+	var session config.Session
+	if cfg.RestoreSession {
+		var err error
+		if session, err = config.LoadSession(); err != nil {
+			return nil, fmt.Errorf("%w (start with --fresh to skip it)", err)
+		}
+	}
+
+	notes, err := config.LoadNotes()
+	if err != nil {
+		return nil, err
+	}
+	acct, err := config.LoadPaper(cfg.Portfolio.PaperCash)
+	if err != nil {
+		return nil, err
+	}
+	fired, err := config.LoadAlertLog()
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := newWatchlistStates(cfg, aliases)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].model.SetNotes(notes)
+		lists[i].model.SetKeyMap(km)
+		lists[i].model.SetSessionChange(cfg.SessionChange)
+		lists[i].model.SetSections(cfg.Sections)
+		lists[i].model.SetColumns(cfg.Columns)
+		lists[i].model.SetReducedMotion(cfg.ReducedMotion)
+	}
+	prov := lists[0].provider
+
+	ch := chart.New()
+	ch.SetAliases(aliases)
+	ch.SetIndicatorParams(cfg.Indicators)
+	ch.SetVolume(cfg.ShowVolume, cfg.VolumeMinHeight)
+	ch.SetGrid(cfg.ChartGrid, cfg.ChartGridRows)
+	ch.SetPrevCloseLine(cfg.PrevCloseLine)
+	ch.SetHighRes(strings.EqualFold(cfg.ChartResolution, "high"))
+	ch.SetLogScale(strings.EqualFold(cfg.ChartScale, "log"))
+	ch.SetSessions(cfg.ExtendedHours)
+
+	f := footer.New(prov.Name())
+	f.SetTimeRange(tr)
+	f.SetReducedMotion(cfg.ReducedMotion)
+	if len(lists) > 1 {
+		f.SetWatchlist(lists[0].name)
+	}
+
+	pr, dr, lk := prompt.New(), daterange.New(), lookup.New()
+	pr.SetReducedMotion(cfg.ReducedMotion)
+	dr.SetReducedMotion(cfg.ReducedMotion)
+	lk.SetReducedMotion(cfg.ReducedMotion)
+	pal := palette.New()
+	pal.SetReducedMotion(cfg.ReducedMotion)
+	nt := note.New()
+	nt.SetReducedMotion(cfg.ReducedMotion)
+
 	return &AppModel{
 		cfg:         cfg,
 		provider:    prov,
-		watchlist:   watchlist.New(cfg.Symbols),
-		chart:       chart.New(),
-		footer:      footer.New(prov.Name()),
-		help:        help.New(),
+		watchlist:   lists[0].model,
+		chart:       ch,
+		footer:      f,
+		ticker:      tk,
+		keys:        km,
+		help:        help.New(km.HelpPages(), km.Help),
+		stats:       stats.New(),
+		risk:        risk.New(km.PortfolioRisk),
+		calendar:    earnings.New(km.Earnings),
+		alertLog:    alertlog.New(km.AlertLog),
+		settings:    settings.New(km.Settings),
+		firedAlerts: fired,
+		prompt:      pr,
+		note:        nt,
+		notes:       notes,
+		paper:       acct,
+		paperPrices: make(map[string]float64),
+		session:     session,
+		dateRange:   dr,
+		picker:      picker.New(),
+		news:        news.New(),
+		heatmap:     heatmap.New(km.Heatmap),
+		lookup:      lk,
+		palette:     pal,
+		tour:        tour.New(km),
+		details:     fundamentals.New(),
+		options:     options.New(),
+		book:        orderbook.New(),
+		tabs:        tabs.New("Chart", "News", "Details", "Options"),
+		fundCache:   make(map[string]fundamentalsEntry),
+		yearTried:   make(map[string]time.Time),
+		chains:      make(map[chainKey]optionsEntry),
+		newsSource:  data.NewNews(cfg.ProviderChain()),
+		optionsSrc:  data.NewOptions(cfg.ProviderChain()),
+		bookSrc:     data.NewOrderBook(cfg.ProviderChain()),
+		earningsSrc: data.NewEarnings(cfg.ProviderChain()),
+		earnings:    make(map[string]models.Earnings),
+		searcher:    data.NewSearch(cfg.ProviderChain()),
+		fx:          market.NewRates(cfg.DisplayCurrency),
+		fxSource:    data.NewFX(cfg.ProviderChain()),
+		headlines:   make(map[string][]models.Headline),
+		lists:       lists,
 		timeRange:   tr,
+		wlPercent:   cfg.WatchlistWidth,
 		lastHistory: make(map[string][]models.Candle),
+		portfolio:   make(map[models.TimeRange][]models.Candle),
+		currencies:  make(map[string]string),
+		aliases:     aliases,
+		refresh:     refresh,
+		paused:      make(map[string]bool),
+		state:       state,
+		alerts:      engine,
+		onStart:     onStart,
 	}, nil
 }
 
 func (m *AppModel) Init() tea.Cmd {
-	m.refreshTicker = time.NewTicker(m.cfg.RefreshInterval)
-
+	m.restoreSession()
+	start := m.runOnStart()
+	if !config.TourSeen() {
+		m.tour.Open()
+	}
 	return tea.Batch(
 		tea.EnterAltScreen,
-		m.fetchQuotes(),
+		start,
+		m.fetchQuotes(m.list),
 		m.fetchAllHistory(),
-		m.waitForTick(),
+		m.startTickers(),
+		m.startTicker(),
+		m.refreshFX(),
+		m.refreshEarnings(),
+		m.refreshYearRanges(),
+		waitForQueue(),
+		m.watchConfig(),
 	)
 }
 
-func (m *AppModel) waitForTick() tea.Cmd {
+// waitForQueue reports the next change in the request queue depth.
+func waitForQueue() tea.Cmd {
 	return func() tea.Msg {
-		t := <-m.refreshTicker.C
-		return tickMsg(t)
+		<-data.QueueChanges()
+		return queueMsg{depth: data.QueueDepth()}
 	}
 }
 
-func (m *AppModel) fetchQuotes() tea.Cmd {
+// fetchQuotes polls quotes for all of list i with that list's provider.
+func (m *AppModel) fetchQuotes(i int) tea.Cmd {
+	m.lists[i].quoted = true
+	return m.requestQuotes(i, m.quoteSymbols(i))
+}
+
+func (m *AppModel) requestQuotes(i int, symbols []string) tea.Cmd {
+	prov := m.lists[i].provider
+	if len(symbols) == 0 {
+		return nil
+	}
 	return func() tea.Msg {
-		quotes, err := m.provider.GetQuotes(m.cfg.Symbols)
-		return quotesMsg{quotes: quotes, err: err}
+		quotes, err := prov.GetQuotes(symbols)
+		return quotesMsg{list: i, quotes: quotes, err: err}
 	}
 }
 
 func (m *AppModel) fetchHistory(symbol string, tr models.TimeRange) tea.Cmd {
+	prov := m.provider
 	return func() tea.Msg {
-		h, err := m.provider.GetHistory(symbol, tr)
-		return historyMsg{symbol: symbol, tr: tr, data: h, err: err}
+		h, err := prov.GetHistory(symbol, tr)
+		return historyMsg{provider: prov.Name(), symbol: symbol, tr: tr, data: h, err: err}
 	}
 }
 
+// historyKey is the lastHistory key for symbol at tr from the named
+// provider. Lists with different providers chart the same symbol apart.
+func historyKey(provider, symbol string, tr models.TimeRange) string {
+	return provider + "|" + symbol + "|" + string(tr)
+}
+
+// cachedHistory returns the history of symbol at tr held for the active
+// provider.
+func (m *AppModel) cachedHistory(symbol string, tr models.TimeRange) ([]models.Candle, bool) {
+	h, ok := m.lastHistory[historyKey(m.provider.Name(), symbol, tr)]
+	return h, ok
+}
+
+// fetchAllHistory loads history for every symbol of the active list. Fresh
+// cache entries and the selected symbol load at once; the rest are spread
+// over the list's refresh interval, oldest cache entry first, so a big
+// watchlist doesn't burst into the provider's rate limit. Low-bandwidth
+// mode skips the rest, leaving each to load when it is selected.
 func (m *AppModel) fetchAllHistory() tea.Cmd {
-	// Batch fetch history for all symbols
-	cmds := make([]tea.Cmd, 0, len(m.cfg.Symbols))
-	for _, sym := range m.cfg.Symbols {
-		s := sym // capture
-		cmds = append(cmds, func() tea.Msg {
-			h, err := m.provider.GetHistory(s, m.timeRange)
-			return historyMsg{symbol: s, tr: m.timeRange, data: h, err: err}
-		})
+	m.lists[m.list].fetched = true
+	type pending struct {
+		symbol string
+		age    time.Duration
+		cached bool
+	}
+	sel := m.watchlist.SelectedSymbol()
+	var now, later []pending
+	for _, sym := range m.symbols() {
+		age, fresh, ok := data.CachedHistory(m.provider, sym, m.timeRange)
+		if fresh || sym == sel || m.lists[m.list].local {
+			now = append(now, pending{symbol: sym})
+		} else {
+			later = append(later, pending{sym, age, ok})
+		}
+	}
+	// Never cached sorts as oldest
+	slices.SortStableFunc(later, func(a, b pending) int {
+		if a.cached != b.cached {
+			if a.cached {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(b.age, a.age)
+	})
+
+	cmds := make([]tea.Cmd, 0, len(now)+len(later))
+	for _, p := range now {
+		cmds = append(cmds, m.fetchHistory(p.symbol, m.timeRange))
+	}
+	if m.cfg.LowBandwidth {
+		later = nil
+	}
+	step := m.lists[m.list].interval / time.Duration(max(len(later), 1))
+	for i, p := range later {
+		if i == 0 && len(now) == 0 {
+			cmds = append(cmds, m.fetchHistory(p.symbol, m.timeRange))
+			continue
+		}
+		msg := retryHistoryMsg{symbol: p.symbol, tr: m.timeRange}
+		cmds = append(cmds, tea.Tick(time.Duration(i)*step, func(time.Time) tea.Msg { return msg }))
 	}
 	return tea.Batch(cmds...)
 }
@@ -134,7 +657,7 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
-			if msg.String() != "?" && msg.String() != "esc" {
+			if !key.Matches(msg, m.keys.Help) && msg.String() != "esc" {
 				return m, tea.Batch(cmds...)
 			}
 		case tea.WindowSizeMsg:
@@ -145,6 +668,71 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	}
 
+	if _, ok := msg.(tea.KeyMsg); ok && m.prompt.Visible() {
+		m.prompt, cmd = m.prompt.Update(msg)
+		return m, cmd
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok && m.note.Visible() {
+		m.note, cmd = m.note.Update(msg)
+		return m, cmd
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok && m.tour.Visible() {
+		m.tour, cmd = m.tour.Update(msg)
+		return m, cmd
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok && m.palette.Visible() {
+		m.palette, cmd = m.palette.Update(msg)
+		return m, cmd
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok && m.lookup.Visible() {
+		m.lookup, cmd = m.lookup.Update(msg)
+		return m, cmd
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok && m.picker.Visible() {
+		m.picker, cmd = m.picker.Update(msg)
+		return m, cmd
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok && m.dateRange.Visible() {
+		m.dateRange, cmd = m.dateRange.Update(msg)
+		return m, cmd
+	}
+
+	// The heatmap captures keys too, except the layout keys so a heatmap
+	// layout can be saved and left for another
+	if k, ok := msg.(tea.KeyMsg); ok && m.heatmap.Visible() &&
+		!key.Matches(k, m.keys.PickLayout, m.keys.SaveLayout) {
+		m.heatmap, cmd = m.heatmap.Update(msg)
+		return m, cmd
+	}
+
+	// Stats modal captures keys; data messages keep flowing underneath
+	if _, ok := msg.(tea.KeyMsg); ok && m.stats.Visible() {
+		m.stats, cmd = m.stats.Update(msg)
+		return m, cmd
+	}
+	if _, ok := msg.(tea.KeyMsg); ok && m.calendar.Visible() {
+		m.calendar, cmd = m.calendar.Update(msg)
+		return m, cmd
+	}
+	if _, ok := msg.(tea.KeyMsg); ok && m.alertLog.Visible() {
+		m.alertLog, cmd = m.alertLog.Update(msg)
+		return m, cmd
+	}
+	if _, ok := msg.(tea.KeyMsg); ok && m.settings.Visible() {
+		m.settings, cmd = m.settings.Update(msg)
+		return m, cmd
+	}
+	if _, ok := msg.(tea.KeyMsg); ok && m.risk.Visible() {
+		m.risk, cmd = m.risk.Update(msg)
+		return m, cmd
+	}
+
 	if m.watchlist.IsSearching() {
 		m.watchlist, cmd = m.watchlist.Update(msg)
 		cmds = append(cmds, cmd)
@@ -153,86 +741,576 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.MouseMsg:
+		if cmd, ok := m.dragSplit(msg); ok {
+			return m, cmd
+		}
 		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			// A click focuses the pane it lands in
+			switch {
+			case m.panes.list.contains(msg.X, msg.Y):
+				m.setFocus(focusList)
+			case m.panes.tab.contains(msg.X, msg.Y), m.panes.tabBar.contains(msg.X, msg.Y):
+				m.setFocus(focusTab)
+			}
 			// Footer click (Cycle time range)
 			if msg.Y == m.height-1 {
 				m.cycleTimeRange()
 				return m, m.refreshCurrentChart()
 			}
+			// Tab bar click
+			if bar := m.panes.tabBar; bar.contains(msg.X, msg.Y) && !m.heatmap.Visible() {
+				if i, ok := m.tabs.At(msg.X - bar.x); ok {
+					return m, m.switchTab(i)
+				}
+			}
 		}
+		m.wheel(msg)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.layout()
 
-		footerHeight := 1
-		mainHeight := m.height - footerHeight
-
-		wlWidth := int(float64(m.width) * 0.28)
-		if wlWidth < 30 {
-			wlWidth = 30
+	case tea.KeyMsg:
+		// Crosshair mode takes horizontal movement away from the watchlist
+		// and the tabs
+		if m.chart.Crosshair() && m.tabs.Active() == tabChart {
+			switch msg.String() {
+			case "left", "h":
+				m.chart.MoveCrosshair(-1)
+				return m, nil
+			case "right", "l":
+				m.chart.MoveCrosshair(1)
+				return m, nil
+			case "esc":
+				m.chart.ToggleCrosshair()
+				return m, nil
+			}
 		}
-		if wlWidth > 45 {
-			wlWidth = 45
+
+		if m.focusKey(msg) {
+			return m, nil
 		}
-		chartWidth := m.width - wlWidth
 
-		m.watchlist.SetSize(wlWidth, mainHeight)
-		m.chart.SetSize(chartWidth, mainHeight)
-		m.footer.SetSize(m.width, footerHeight)
-		m.help.SetSize(m.width, m.height)
+		// The news and options tabs scroll with j/k; the arrow keys keep
+		// moving through the watchlist. [ and ] step through the options
+		// tab's expiries. Esc goes back to the chart.
+		if m.tabs.Active() != tabChart {
+			scroll := map[string]int{"j": 1, "k": -1}[msg.String()]
+			step := map[string]int{"[": -1, "]": 1}[msg.String()]
+			switch {
+			case msg.String() == "esc":
+				return m, m.switchTab(tabChart)
+			case scroll != 0 && m.news.Visible():
+				m.news.Scroll(scroll)
+				return m, nil
+			case scroll != 0 && m.options.Visible():
+				m.options.Scroll(scroll)
+				return m, nil
+			case step != 0 && m.options.Visible():
+				return m, m.stepExpiry(step)
+			}
+		}
 
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
-		case "?":
-			m.help.Toggle()
+		case key.Matches(msg, m.keys.Help):
+			m.help.Open(m.helpPage())
 			return m, nil
 
-		case "tab":
+		case key.Matches(msg, m.keys.Focus):
+			m.setFocus(1 - m.focus)
+			return m, nil
+
+		case key.Matches(msg, m.keys.NextRange):
 			m.cycleTimeRange()
 			return m, m.loadCurrentChart()
 
-		case "1":
+		case key.Matches(msg, m.keys.Range1H):
 			m.setTimeRange(models.Range1H)
 			return m, m.loadCurrentChart()
-		case "2":
+		case key.Matches(msg, m.keys.Range24H):
 			m.setTimeRange(models.Range24H)
 			return m, m.loadCurrentChart()
-		case "3":
+		case key.Matches(msg, m.keys.Range7D):
 			m.setTimeRange(models.Range7D)
 			return m, m.loadCurrentChart()
-		case "4":
+		case key.Matches(msg, m.keys.Range30D):
 			m.setTimeRange(models.Range30D)
 			return m, m.loadCurrentChart()
+		case key.Matches(msg, m.keys.RangeYTD):
+			m.setTimeRange(models.RangeYTD)
+			return m, m.loadCurrentChart()
+		case key.Matches(msg, m.keys.Range90D):
+			m.setTimeRange(models.Range90D)
+			return m, m.loadCurrentChart()
+		case key.Matches(msg, m.keys.Range1Y):
+			m.setTimeRange(models.Range1Y)
+			return m, m.loadCurrentChart()
+		case key.Matches(msg, m.keys.Range5Y):
+			m.setTimeRange(models.Range5Y)
+			return m, m.loadCurrentChart()
+
+		case key.Matches(msg, m.keys.AnchorRange):
+			return m, m.prompt.Open(promptAnchor, "Chart from date", "YYYY-MM-DD",
+				"History from this date to now", validateAnchor)
+
+		case key.Matches(msg, m.keys.Alert):
+			return m, m.openAlertPrompt()
+		case key.Matches(msg, m.keys.JumpAlert):
+			return m, m.jumpToAlert()
+		case key.Matches(msg, m.keys.Note):
+			return m, m.openNote()
+		case key.Matches(msg, m.keys.Trade):
+			return m, m.openTradePrompt()
+
+		case key.Matches(msg, m.keys.Palette):
+			return m, m.palette.Open(m.keys.Actions())
+		case key.Matches(msg, m.keys.Tour):
+			m.tour.Open()
+			return m, nil
+		case key.Matches(msg, m.keys.AddSymbol):
+			return m, m.lookup.Open()
+		case key.Matches(msg, m.keys.RemoveSymbol):
+			return m, m.removeSelected()
+		case key.Matches(msg, m.keys.MoveUp):
+			return m, m.moveSelected(-1)
+		case key.Matches(msg, m.keys.MoveDown):
+			return m, m.moveSelected(1)
+		case key.Matches(msg, m.keys.ClearList):
+			return m, m.clearWatchlist()
+		case key.Matches(msg, m.keys.Undo):
+			return m, m.undoEdit()
 
-		case "r":
-			return m, tea.Batch(m.fetchQuotes(), m.refreshCurrentChart())
+		case key.Matches(msg, m.keys.NextWatchlist):
+			return m, m.switchWatchlist(m.list + 1)
+		case key.Matches(msg, m.keys.PrevWatchlist):
+			return m, m.switchWatchlist(m.list - 1)
+		case key.Matches(msg, m.keys.PickWatchlist):
+			m.openWatchlistPicker()
+			return m, nil
+
+		case key.Matches(msg, m.keys.CustomRange):
+			now := time.Now()
+			return m, m.dateRange.Open(m.timeRange.Start(now), m.timeRange.End(now))
+		case key.Matches(msg, m.keys.Interval):
+			m.openIntervalPicker()
+			return m, nil
+
+		case key.Matches(msg, m.keys.ScrubBack):
+			return m, m.scrub(1)
+		case key.Matches(msg, m.keys.ScrubForward):
+			return m, m.scrub(-1)
 
-		case "c":
+		case key.Matches(msg, m.keys.Refresh):
+			return m, tea.Batch(m.fetchQuotes(m.list), m.refreshCurrentChart())
+
+		case key.Matches(msg, m.keys.ChartType):
 			m.chart.CycleChartType()
 			return m, nil
+
+		case key.Matches(msg, m.keys.OrderBook):
+			return m, m.toggleBook()
+		case key.Matches(msg, m.keys.RSIPanel):
+			m.chart.ToggleRSI()
+			return m, nil
+		case key.Matches(msg, m.keys.MACDPanel):
+			m.chart.ToggleMACD()
+			return m, nil
+		case key.Matches(msg, m.keys.Indicators):
+			m.chart.CycleIndicators()
+			return m, nil
+
+		case key.Matches(msg, m.keys.LogScale):
+			m.chart.ToggleLogScale()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Crosshair):
+			m.chart.ToggleCrosshair()
+			return m, nil
+		case key.Matches(msg, m.keys.ZoomIn):
+			m.chart.ZoomIn()
+			return m, nil
+		case key.Matches(msg, m.keys.ZoomOut):
+			m.chart.ZoomOut()
+			return m, nil
+		case key.Matches(msg, m.keys.PanLeft):
+			m.chart.Pan(-1)
+			return m, nil
+		case key.Matches(msg, m.keys.PanRight):
+			m.chart.Pan(1)
+			return m, nil
+
+		case key.Matches(msg, m.keys.CompareMark):
+			sel := m.watchlist.SelectedSymbol()
+			if sel == "" {
+				return m, nil
+			}
+			m.watchlist.ToggleMark(sel)
+			m.syncCompare()
+			return m, m.fetchCompare()
+
+		case key.Matches(msg, m.keys.Stats):
+			return m, m.openStats()
+
+		case key.Matches(msg, m.keys.Portfolio):
+			return m, m.togglePortfolio()
+
+		case key.Matches(msg, m.keys.PortfolioRisk):
+			return m, m.openRisk()
+		case key.Matches(msg, m.keys.Earnings):
+			return m, m.openCalendar()
+		case key.Matches(msg, m.keys.AlertLog):
+			m.openAlertLog()
+			return m, nil
+		case key.Matches(msg, m.keys.Settings):
+			m.openSettings()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Fundamentals):
+			return m, m.toggleTab(tabDetails)
+
+		case key.Matches(msg, m.keys.SessionChange):
+			on := !m.watchlist.SessionChange()
+			for i := range m.lists {
+				m.listModel(i).SetSessionChange(on)
+			}
+			if on {
+				return m, m.notify("Showing change since start of session")
+			}
+			return m, m.notify("Showing daily change")
+
+		case key.Matches(msg, m.keys.CopyTable):
+			return m, m.copyTable()
+
+		case key.Matches(msg, m.keys.Export):
+			return m, m.exportChart()
+
+		case key.Matches(msg, m.keys.ExportList):
+			return m, m.exportWatchlist()
+
+		case key.Matches(msg, m.keys.Heatmap):
+			m.heatmap.Toggle()
+			m.syncHeatmap()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Narrower):
+			return m, m.resizeWatchlist(-1)
+		case key.Matches(msg, m.keys.Wider):
+			return m, m.resizeWatchlist(1)
+		case key.Matches(msg, m.keys.PickLayout):
+			return m, m.openLayoutPicker()
+		case key.Matches(msg, m.keys.SaveLayout):
+			return m, m.openSaveLayout()
+
+		case key.Matches(msg, m.keys.News):
+			return m, m.toggleTab(tabNews)
+		case key.Matches(msg, m.keys.Options):
+			return m, m.toggleTab(tabOptions)
+		case key.Matches(msg, m.keys.PrevTab):
+			return m, m.switchTab(m.tabs.Active() - 1)
+		case key.Matches(msg, m.keys.NextTab):
+			return m, m.switchTab(m.tabs.Active() + 1)
+		case key.Matches(msg, m.keys.TabChart):
+			return m, m.switchTab(tabChart)
+		case key.Matches(msg, m.keys.TabNews):
+			return m, m.switchTab(tabNews)
+		case key.Matches(msg, m.keys.TabDetails):
+			return m, m.switchTab(tabDetails)
+		case key.Matches(msg, m.keys.TabOptions):
+			return m, m.switchTab(tabOptions)
+		case key.Matches(msg, m.keys.OpenNews):
+			if h, ok := m.news.Selected(); ok && m.news.Visible() && h.URL != "" {
+				return m, m.openHeadline(h)
+			}
+			return m, nil
+		}
+
+	case prompt.SubmitMsg:
+		switch msg.Tag {
+		case promptAnchor:
+			from, _ := time.ParseInLocation("2006-01-02", msg.Value, time.Local)
+			m.setTimeRange(models.AnchoredRange(from))
+			return m, m.loadCurrentChart()
+		case promptAlert:
+			if strings.EqualFold(msg.Value, "clear") {
+				m.alerts.Clear(m.alertSymbol)
+				return m, tea.Batch(m.rememberAlerts(), m.notify("Alerts cleared for "+m.aliases.Name(m.alertSymbol)))
+			}
+			a, _ := alerts.Parse(m.alertSymbol, msg.Value)
+			m.alerts.Add(a)
+			return m, tea.Batch(m.rememberAlerts(), m.notify(fmt.Sprintf("Alert set: %s %s", m.aliases.Name(a.Symbol), a)))
+		case promptLayout:
+			return m, m.saveLayout(msg.Value)
+		case promptTrade:
+			return m, m.trade(m.tradeSymbol, msg.Value)
+		}
+
+	case note.SubmitMsg:
+		return m, m.setNote(msg.Symbol, msg.Text)
+
+	case picker.SelectMsg:
+		switch msg.Tag {
+		case pickerWatchlist:
+			return m, m.switchWatchlist(msg.Index)
+		case pickerLayout:
+			return m, m.applyLayout(m.cfg.Layouts[msg.Index])
+		case pickerInterval:
+			return m, m.setInterval(models.Intervals[msg.Index])
+		}
+
+	case lookup.QueryMsg:
+		if m.lookup.Pending(msg) {
+			return m, m.searchSymbols(msg.Query)
+		}
+		return m, nil
+
+	case lookupMsg:
+		m.lookup.SetResults(msg.query, msg.matches, msg.err)
+		return m, nil
+
+	case lookup.SelectMsg:
+		return m, m.addSymbol(msg.Match)
+
+	case palette.RunMsg:
+		return m.Update(msg.Action.Msg)
+
+	case tour.DoneMsg:
+		return m, markTourSeen
+
+	case fxMsg:
+		m.fxPending = false
+		m.fxUpdated = time.Now()
+		m.fxCodesFetched = msg.codes
+		if msg.err != nil {
+			return m, m.notify("Could not fetch exchange rates: " + msg.err.Error())
+		}
+		m.fx = m.fx.With(msg.rates)
+		m.applyRates()
+		return m, nil
+
+	case earningsMsg:
+		m.earningsPending = false
+		m.earningsUpdated = time.Now()
+		m.earningsFetched = msg.symbols
+		if msg.err != nil {
+			// Dates already shown stay until the next refresh
+			if len(m.earnings) == 0 {
+				m.calendar.SetError(msg.err)
+			}
+			return m, nil
+		}
+		m.earnings = make(map[string]models.Earnings, len(msg.earnings))
+		for _, e := range msg.earnings {
+			m.earnings[e.Symbol] = e
+		}
+		m.applyEarnings()
+		return m, nil
+
+	case exportMsg:
+		if msg.err != nil {
+			return m, m.notify("Export failed: " + msg.err.Error())
+		}
+		return m, m.notify(fmt.Sprintf("Exported %s (%s) to %s", msg.name, strings.Join(msg.exts, ", "), msg.dir))
+
+	case stateSavedMsg:
+		m.stateSaving = false
+		if msg.err != nil {
+			return m, m.notify("Could not save state: " + msg.err.Error())
+		}
+		if m.stateDirty {
+			m.stateDirty = false
+			return m, m.saveState()
+		}
+		return m, nil
+
+	case paperSavedMsg:
+		m.paperSaving = false
+		if msg.err != nil {
+			return m, m.notify("Could not save the paper account: " + msg.err.Error())
+		}
+		if m.paperDirty {
+			m.paperDirty = false
+			return m, m.savePaper()
+		}
+		return m, nil
+
+	case alertLogSavedMsg:
+		m.alertLogSaving = false
+		if msg.err != nil {
+			return m, m.notify("Could not save the alert log: " + msg.err.Error())
+		}
+		if m.alertLogDirty {
+			m.alertLogDirty = false
+			return m, m.saveAlertLog()
+		}
+		return m, nil
+
+	case alertlog.ClearMsg:
+		return m, m.clearAlertLog()
+
+	case settings.ChangedMsg:
+		return m, m.changeSetting(msg)
+
+	case settings.ClosedMsg:
+		return m, m.saveSettings()
+
+	case settingsSavedMsg:
+		return m, m.settingsSaved(msg)
+
+	case notesSavedMsg:
+		m.notesSaving = false
+		if msg.err != nil {
+			return m, m.notify("Could not save notes: " + msg.err.Error())
+		}
+		if m.notesDirty {
+			m.notesDirty = false
+			return m, m.saveNotes()
+		}
+		return m, nil
+
+	case widthSaveMsg:
+		if msg.seq == m.widthSeq {
+			return m, m.saveWatchlistWidth()
+		}
+		return m, nil
+
+	case widthSavedMsg:
+		if msg.err != nil {
+			return m, m.notify("Could not save the pane width: " + msg.err.Error())
+		}
+		return m, nil
+
+	case layoutSavedMsg:
+		if msg.err != nil {
+			return m, m.notify("Could not save layout: " + msg.err.Error())
+		}
+		m.rememberLayout(msg.layout)
+		return m, m.notify(fmt.Sprintf("Saved layout %q to %s", msg.layout.Name, msg.path))
+
+	case alertFlashDoneMsg:
+		m.setFlash(msg.symbol, false)
+
+	case newsMsg:
+		if msg.err != nil {
+			m.news.SetError(msg.symbol, msg.err)
+		} else {
+			m.headlines[msg.symbol] = msg.headlines
+			m.news.SetHeadlines(msg.symbol, msg.headlines)
+		}
+
+	case fundamentalsMsg:
+		if msg.err != nil {
+			m.details.SetError(msg.symbol, msg.err)
+		} else {
+			m.fundCache[msg.symbol] = fundamentalsEntry{data: msg.data, at: time.Now()}
+			m.details.SetData(msg.symbol, msg.data)
+			m.applyYearRanges()
+		}
+
+	case yearRangeMsg:
+		m.yearPending = false
+		for s, f := range msg.fundamentals {
+			m.fundCache[s] = fundamentalsEntry{data: f, at: time.Now()}
+		}
+		m.applyYearRanges()
+
+	case optionsMsg:
+		if msg.err != nil {
+			m.options.SetError(msg.symbol, msg.expiry, msg.err)
+		} else {
+			m.chains[chainKey{msg.symbol, msg.expiry}] = optionsEntry{chain: msg.chain, at: time.Now()}
+			m.options.SetChain(msg.symbol, msg.expiry, msg.chain)
+		}
+
+	case bookMsg:
+		if msg.seq == m.bookSeq {
+			if msg.err != nil {
+				m.book.SetError(msg.symbol, msg.err)
+			} else {
+				m.book.SetBook(msg.symbol, msg.book)
+			}
+			cmds = append(cmds, nextBookTick(msg.seq))
+		}
+
+	case bookTickMsg:
+		if msg.seq == m.bookSeq && m.bookFor != "" {
+			cmds = append(cmds, m.fetchBook(m.bookFor, msg.seq))
+		}
+
+	case footerClearMsg:
+		if msg.seq == m.footerSeq {
+			m.footer.SetMessage("")
+		}
+
+	case daterange.SubmitMsg:
+		m.setTimeRange(models.CustomRange(msg.From, msg.To))
+		return m, m.loadCurrentChart()
+
+	case configChangedMsg:
+		m.reloadSeq++
+		seq := m.reloadSeq
+		cmds = append(cmds, m.waitForConfig(), tea.Tick(reloadDelay, func(time.Time) tea.Msg {
+			return reloadMsg{seq: seq}
+		}))
+
+	case reloadMsg:
+		if msg.seq == m.reloadSeq {
+			cmds = append(cmds, m.reloadConfig())
 		}
 
 	case tickMsg:
-		cmds = append(cmds, m.fetchQuotes(), m.waitForTick())
+		if !m.liveTick(msg) {
+			break
+		}
+		m.markStale(msg.list)
+		cmds = append(cmds, m.fetchScheduled(msg.list, msg.schedule), m.waitForTick(msg.list, msg.schedule))
+
+	case queueMsg:
+		m.footer.SetQueued(msg.depth)
+		cmds = append(cmds, waitForQueue())
+
+	case ticker.StepMsg:
+		m.ticker.Step()
+		cmds = append(cmds, m.ticker.Tick())
 
 	case quotesMsg:
-		if msg.err != nil {
+		if msg.list != m.list {
+			// Background list: keep its rows and alerts current quietly
+			if msg.err == nil {
+				m.recordCurrencies(msg.quotes)
+				m.lists[msg.list].model.UpdateQuotes(msg.quotes)
+				cmds = append(cmds, m.refreshFX(), m.refreshEarnings(), m.refreshYearRanges())
+				cmds = append(cmds, m.fireAlerts(m.alerts.Evaluate(msg.quotes))...)
+				cmds = append(cmds, m.fillPaper(msg.quotes)...)
+			}
+		} else if msg.err != nil {
 			m.err = msg.err
 			m.footer.SetStatus(time.Now(), false, msg.err)
 		} else {
-			m.lastQuotes = msg.quotes
+			// A failover chain reports whichever source served the data
+			m.footer.SetProvider(m.provider.Name())
+			// Scheduled refreshes may cover only part of the list
+			active := m.quoteSymbols(m.list)
+			m.lastQuotes = mergeQuotes(m.lastQuotes, msg.quotes, func(s string) bool {
+				return slices.Contains(active, s)
+			})
+			m.ticker.SetQuotes(m.lastQuotes)
+			m.recordCurrencies(msg.quotes)
 			m.watchlist.UpdateQuotes(msg.quotes)
+			cmds = append(cmds, m.refreshFX(), m.refreshEarnings(), m.refreshYearRanges())
+			m.syncHeatmap()
 			m.footer.SetStatus(time.Now(), true, nil)
 			m.err = nil
+			cmds = append(cmds, m.fireAlerts(m.alerts.Evaluate(msg.quotes))...)
+			cmds = append(cmds, m.fillPaper(msg.quotes)...)
+			m.markStale(m.list)
 
 			sel := m.watchlist.SelectedSymbol()
 			if sel != "" {
-				cacheKey := sel + "|" + string(m.timeRange)
-				if _, ok := m.lastHistory[cacheKey]; !ok {
+				if _, ok := m.cachedHistory(sel, m.timeRange); !ok {
 					m.chart.SetLoading(true)
 					cmds = append(cmds, m.fetchHistory(sel, m.timeRange))
 				}
@@ -245,17 +1323,43 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		cmds = append(cmds, m.fetchHistory(msg.symbol, msg.tr))
 
+	case portfolioMsg:
+		if msg.err != nil {
+			if msg.tr == m.timeRange && m.chart.Portfolio() {
+				m.chart.SetPortfolioData(chart.Series{}, chart.Series{}, msg.err)
+			}
+			return m, nil
+		}
+		m.portfolio[msg.tr] = msg.data
+		m.syncPortfolio()
+		return m, nil
+
+	case riskMsg:
+		if msg.err != nil {
+			m.risk.SetError(msg.err)
+		} else {
+			m.risk.SetData(msg.history, msg.values, m.cfg.Portfolio)
+		}
+		return m, nil
+
 	case historyMsg:
+		// A reply from a provider since switched away from is cached but
+		// not shown
+		current := msg.provider == m.provider.Name()
+		shown := current && m.watchlist.SelectedSymbol() == msg.symbol && m.timeRange == msg.tr
 		if msg.err != nil {
+			// 1Y history backs the returns summary as well as the chart
+			if msg.tr == statsRange && current {
+				m.stats.SetError(msg.symbol, msg.err)
+			}
 			var rateLimitErr *data.RateLimitError
 			if errors.As(msg.err, &rateLimitErr) {
-				cacheKey := msg.symbol + "|" + string(msg.tr)
-				if cached, ok := m.lastHistory[cacheKey]; ok {
-					if m.watchlist.SelectedSymbol() == msg.symbol && m.timeRange == msg.tr {
-						m.chart.SetData(msg.symbol, msg.tr, cached)
+				if cached, ok := m.lastHistory[historyKey(msg.provider, msg.symbol, msg.tr)]; ok {
+					if shown {
+						m.showHistory(msg.symbol, msg.tr, cached)
 						m.chart.SetStale(rateLimitErr.RetryAfter)
 					}
-				} else {
+				} else if shown {
 					m.chart.SetError(msg.err)
 				}
 
@@ -265,15 +1369,21 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}))
 				return m, tea.Batch(cmds...)
 			}
-			m.chart.SetError(msg.err)
+			// Background fetches (other rows, compare series) fail quietly
+			if shown {
+				m.chart.SetError(msg.err)
+			}
 		} else {
-			cacheKey := msg.symbol + "|" + string(msg.tr)
-			m.lastHistory[cacheKey] = msg.data
-			if m.watchlist.SelectedSymbol() == msg.symbol && m.timeRange == msg.tr {
-				m.chart.SetData(msg.symbol, msg.tr, msg.data)
+			m.lastHistory[historyKey(msg.provider, msg.symbol, msg.tr)] = msg.data
+			if shown {
+				m.showHistory(msg.symbol, msg.tr, msg.data)
+			}
+			if msg.tr == statsRange && current {
+				m.stats.SetData(msg.symbol, msg.data)
 			}
-			// Update watchlist with % change from history (start to end)
-			if len(msg.data) > 1 {
+			// Update watchlist with % change from history (start to end).
+			// Windows that ended in the past would replace live prices.
+			if len(msg.data) > 1 && current && msg.tr == m.timeRange && !msg.tr.IsCustom() {
 				startPrice := msg.data[0].Close
 				endPrice := msg.data[len(msg.data)-1].Close
 				m.watchlist.UpdatePriceChange(msg.symbol, endPrice, startPrice)
@@ -287,33 +1397,152 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	newSel := m.watchlist.SelectedSymbol()
 	if oldSel != newSel && newSel != "" {
-		cacheKey := newSel + "|" + string(m.timeRange)
-		if cached, ok := m.lastHistory[cacheKey]; ok {
-			m.chart.SetData(newSel, m.timeRange, cached)
+		if cached, ok := m.cachedHistory(newSel, m.timeRange); ok {
+			m.showHistory(newSel, m.timeRange, cached)
 		} else {
 			m.chart.SetLoading(true)
 			cmds = append(cmds, m.fetchHistory(newSel, m.timeRange))
 		}
+		cmds = append(cmds, m.loadTab(newSel))
 	}
 
-	m.chart, cmd = m.chart.Update(msg)
-	cmds = append(cmds, cmd)
+	m.chart.SetCurrency(newSel, m.currencies[newSel])
+	m.chart.SetNote(m.notes[newSel])
+	m.details.SetNote(m.notes[newSel])
+	if newSel != "" {
+		m.footer.SetMarket(market.CalendarFor(newSel))
+	} else {
+		m.footer.SetMarket(nil)
+	}
+	for _, q := range m.lastQuotes {
+		if q.Symbol == newSel {
+			m.chart.SetExtended(q)
+			break
+		}
+	}
+	m.syncCompare()
+	m.syncPortfolio()
+	m.syncPaper()
+	m.syncBenchmark()
+	m.syncFocus()
+	cmds = append(cmds, m.syncBook())
+	// The chart only takes the mouse while its tab is showing
+	if _, mouse := msg.(tea.MouseMsg); !mouse || m.tabs.Active() == tabChart {
+		m.chart, cmd = m.chart.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// layout sizes the panes for the terminal. The right-hand column is a
+// tab bar over whichever tab is showing; stacked, it goes under the
+// watchlist instead.
+func (m *AppModel) layout() {
+	footerHeight := 1
+	if m.cfg.Ticker.Enabled {
+		footerHeight++
+	}
+	mainHeight := m.height - footerHeight
+	m.stacked = stackPanes(m.cfg.PaneLayout, m.width)
+
+	wlWidth := int(float64(m.width) * 0.28)
+	if wlWidth < 30 {
+		wlWidth = 30
+	}
+	if wlWidth > 45 {
+		wlWidth = 45
+	}
+	if m.wlPercent > 0 {
+		// A resized pane follows its percent, leaving the chart some room
+		wlWidth = max(min(m.width*m.wlPercent/100, m.width-30), 20)
+	}
+	chartWidth := m.width - wlWidth
+	listHeight := mainHeight
+	tabX, tabY := wlWidth, 0
+	if m.stacked {
+		wlWidth, chartWidth = m.width, m.width
+		listHeight = stackedListHeight(mainHeight)
+		tabX, tabY = 0, listHeight
+	}
+
+	tabHeight := mainHeight - tabY - 1 // below the tab bar
+	m.tabs.SetSize(chartWidth)
+	m.news.SetSize(chartWidth, tabHeight)
+	m.details.SetSize(chartWidth, tabHeight)
+	m.options.SetSize(chartWidth, tabHeight)
+
+	m.watchlist.SetSize(wlWidth, listHeight)
+	if m.bookFor != "" {
+		// The book's pane border comes out of the chart's share
+		bookH := bookHeight(tabHeight)
+		m.book.SetSize(chartWidth, bookH)
+		m.chart.SetSize(chartWidth, tabHeight-bookH-2)
+	} else {
+		m.chart.SetSize(chartWidth, tabHeight)
+	}
+	m.chart.SetOrigin(tabX, tabY+1)
+	m.panes = panes{
+		list:   rect{0, 0, wlWidth, listHeight},
+		tabBar: rect{tabX, tabY, chartWidth, 1},
+		tab:    rect{tabX, tabY + 1, chartWidth, tabHeight},
+	}
+	m.footer.SetSize(m.width, footerHeight)
+	m.ticker.SetSize(m.width)
+	m.help.SetSize(m.width, m.height)
+	m.stats.SetSize(m.width, m.height)
+	m.risk.SetSize(m.width, m.height)
+	m.calendar.SetSize(m.width, m.height)
+	m.alertLog.SetSize(m.width, m.height)
+	m.settings.SetSize(m.width, m.height)
+	m.prompt.SetSize(m.width, m.height)
+	m.note.SetSize(m.width, m.height)
+	m.picker.SetSize(m.width, m.height)
+	m.lookup.SetSize(m.width, m.height)
+	m.palette.SetSize(m.width, m.height)
+	m.tour.SetSize(m.width, m.height)
+	m.tour.SetPanes(wlWidth, mainHeight)
+	m.dateRange.SetSize(m.width, m.height)
+	m.heatmap.SetSize(m.width, mainHeight)
+}
+
+// startTicker starts scrolling the footer ticker when it is enabled. With
+// reduced motion it stays put and turns over on refreshes instead.
+func (m *AppModel) startTicker() tea.Cmd {
+	if !m.cfg.Ticker.Enabled || m.cfg.ReducedMotion {
+		return nil
+	}
+	return m.ticker.Tick()
+}
+
+func (m *AppModel) recordCurrencies(quotes []models.Quote) {
+	for _, q := range quotes {
+		if q.Currency != "" {
+			m.currencies[q.Symbol] = q.Currency
+		}
+	}
+}
+
 func (m *AppModel) cycleTimeRange() {
-	ranges := []models.TimeRange{models.Range1H, models.Range24H, models.Range7D, models.Range30D}
-	for i, tr := range ranges {
-		if tr == m.timeRange {
-			m.timeRange = ranges[(i+1)%len(ranges)]
+	if m.scrubSteps > 0 {
+		m.timeRange = m.scrubBase
+		m.scrubSteps = 0
+	}
+	next := models.Ranges[0]
+	for i, tr := range models.Ranges {
+		if tr == m.timeRange.Base() {
+			next = models.Ranges[(i+1)%len(models.Ranges)]
 			break
 		}
 	}
+	m.timeRange = next.WithInterval(m.timeRange.Interval())
 	m.footer.SetTimeRange(m.timeRange)
 }
 
+// setTimeRange switches to range tr, keeping the chosen candle interval.
 func (m *AppModel) setTimeRange(tr models.TimeRange) {
+	m.scrubSteps = 0
+	tr = tr.WithInterval(m.timeRange.Interval())
 	if m.timeRange == tr {
 		return
 	}
@@ -321,13 +1550,48 @@ func (m *AppModel) setTimeRange(tr models.TimeRange) {
 	m.footer.SetTimeRange(m.timeRange)
 }
 
+// scrub moves the chart steps periods further into the past (negative
+// steps move forward). A period is the length of the range being
+// scrubbed, so from 24H one step back shows the 24 hours before that.
+// Stepping forward onto the latest period returns to the live range.
+func (m *AppModel) scrub(steps int) tea.Cmd {
+	if m.scrubSteps == 0 {
+		m.scrubBase = m.timeRange
+	}
+	n := max(0, m.scrubSteps+steps)
+	if n == m.scrubSteps {
+		return nil
+	}
+	base := m.scrubBase
+	if n == 0 {
+		m.setTimeRange(base)
+		return m.loadCurrentChart()
+	}
+
+	now := time.Now().Truncate(time.Minute)
+	start, end := base.Start(now), base.End(now)
+	shift := end.Sub(start) * time.Duration(n)
+	m.setTimeRange(models.WindowRange(start.Add(-shift), end.Add(-shift)))
+	m.scrubSteps = n
+	m.scrubBase = base
+	return m.loadCurrentChart()
+}
+
 func (m *AppModel) refreshCurrentChart() tea.Cmd {
 	sel := m.watchlist.SelectedSymbol()
 	if sel == "" {
 		return nil
 	}
 	m.chart.SetLoading(true)
-	return m.fetchHistory(sel, m.timeRange)
+	return tea.Batch(m.fetchHistory(sel, m.timeRange), m.fetchBenchmark(true))
+}
+
+// showHistory charts candles along with any points the provider had to
+// leave out of them.
+func (m *AppModel) showHistory(symbol string, tr models.TimeRange, candles []models.Candle) {
+	m.chart.SetData(symbol, tr, candles)
+	d := data.HistoryDrops(symbol, tr)
+	m.chart.SetDropped(d.Dropped, d.Total)
 }
 
 func (m *AppModel) loadCurrentChart() tea.Cmd {
@@ -335,30 +1599,262 @@ func (m *AppModel) loadCurrentChart() tea.Cmd {
 	if sel == "" {
 		return nil
 	}
-	cacheKey := sel + "|" + string(m.timeRange)
-	if cached, ok := m.lastHistory[cacheKey]; ok {
-		m.chart.SetData(sel, m.timeRange, cached)
-		return nil
+	compare := tea.Batch(m.fetchCompare(), m.loadPortfolio(), m.fetchBenchmark(false))
+	if cached, ok := m.cachedHistory(sel, m.timeRange); ok {
+		m.showHistory(sel, m.timeRange, cached)
+		return compare
 	}
 	m.chart.SetLoading(true)
-	return m.fetchHistory(sel, m.timeRange)
+	return tea.Batch(m.fetchHistory(sel, m.timeRange), compare)
+}
+
+// fetchBenchmark fetches the configured benchmark's history at the current
+// range, when it is not cached or force asks for a fresh copy. One copy
+// serves every symbol's chart.
+func (m *AppModel) fetchBenchmark(force bool) tea.Cmd {
+	b := m.cfg.Benchmark
+	if b == "" || b == m.watchlist.SelectedSymbol() {
+		return nil
+	}
+	if _, ok := m.cachedHistory(b, m.timeRange); ok && !force {
+		return nil
+	}
+	return m.fetchHistory(b, m.timeRange)
+}
+
+// syncBenchmark feeds the chart the cached benchmark history at the
+// current range.
+func (m *AppModel) syncBenchmark() {
+	b := m.cfg.Benchmark
+	if b == "" {
+		m.chart.SetBenchmark(chart.Series{})
+		return
+	}
+	h, _ := m.cachedHistory(b, m.timeRange)
+	m.chart.SetBenchmark(chart.Series{Symbol: b, Data: h})
+}
+
+// syncCompare feeds the chart the cached history of every marked symbol
+// other than the selected one.
+func (m *AppModel) syncCompare() {
+	sel := m.watchlist.SelectedSymbol()
+	var series []chart.Series
+	for _, sym := range m.watchlist.Marked() {
+		if sym == sel {
+			continue
+		}
+		if cached, ok := m.cachedHistory(sym, m.timeRange); ok {
+			series = append(series, chart.Series{Symbol: sym, Data: cached})
+		}
+	}
+	m.chart.SetCompare(series)
+}
+
+// fetchCompare requests history for marked symbols missing from the cache
+// at the current range.
+func (m *AppModel) fetchCompare() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, sym := range m.watchlist.Marked() {
+		if _, ok := m.cachedHistory(sym, m.timeRange); !ok {
+			cmds = append(cmds, m.fetchHistory(sym, m.timeRange))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// syncHeatmap refreshes the heatmap tiles from the active list's quotes.
+func (m *AppModel) syncHeatmap() {
+	if !m.heatmap.Visible() {
+		return
+	}
+	quotes := make(map[string]models.Quote, len(m.lastQuotes))
+	for _, q := range m.lastQuotes {
+		quotes[q.Symbol] = q
+	}
+	tiles := make([]heatmap.Tile, 0, len(m.symbols()))
+	for _, sym := range m.symbols() {
+		q, ok := quotes[sym]
+		tiles = append(tiles, heatmap.Tile{
+			Symbol:    sym,
+			Label:     m.aliases.Name(sym),
+			Price:     q.Price,
+			ChangePct: q.ChangePct,
+			Quoted:    ok,
+		})
+	}
+	m.heatmap.SetTiles(tiles)
+}
+
+// openStats shows the returns summary for the selected symbol, fetching
+// long-range history only when it is not already cached.
+func (m *AppModel) openStats() tea.Cmd {
+	sel := m.watchlist.SelectedSymbol()
+	if sel == "" {
+		return nil
+	}
+	m.stats.Open(sel)
+	if cached, ok := m.cachedHistory(sel, statsRange); ok {
+		m.stats.SetData(sel, cached)
+		return nil
+	}
+	return m.fetchHistory(sel, statsRange)
+}
+
+func (m *AppModel) openAlertPrompt() tea.Cmd {
+	sel := m.watchlist.SelectedSymbol()
+	if sel == "" {
+		return nil
+	}
+	m.alertSymbol = sel
+
+	hint := ">price, <price, N% daily move, or clear"
+	if existing := m.alerts.For(sel); len(existing) > 0 {
+		parts := make([]string, len(existing))
+		for i, a := range existing {
+			parts[i] = a.String()
+		}
+		hint = "Active: " + strings.Join(parts, ", ") + "\n" + hint
+	}
+
+	return m.prompt.Open(promptAlert, "Alert for "+m.aliases.Name(sel), "e.g. >250 or 5%", hint, func(v string) error {
+		if strings.EqualFold(v, "clear") {
+			return nil
+		}
+		_, err := alerts.Parse(sel, v)
+		return err
+	})
+}
+
+// fireAlerts flashes the affected rows, posts a footer notice and, when
+// enabled, a desktop notification for each trigger, and adds the triggers
+// to the alert log.
+func (m *AppModel) fireAlerts(triggers []alerts.Trigger) []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, t := range triggers {
+		sym := t.Alert.Symbol
+		text := t.Message(m.aliases.Name(sym))
+		m.lastAlert = &t
+		notice := "🔔 " + text
+		if k := m.keys.JumpAlert.Help().Key; k != "" {
+			notice += "  (" + k + " to view)"
+		}
+		cmds = append(cmds, m.notify(notice))
+		// Reduced motion leaves the footer notice as the only signal
+		if !m.cfg.ReducedMotion {
+			m.setFlash(sym, true)
+			cmds = append(cmds, tea.Tick(flashDuration, func(time.Time) tea.Msg {
+				return alertFlashDoneMsg{symbol: sym}
+			}))
+		}
+		if m.cfg.Notifications {
+			cmds = append(cmds, func() tea.Msg {
+				_ = alerts.Notify("stock-tui alert", text)
+				return nil
+			})
+		}
+	}
+	return append(cmds, m.logAlerts(triggers))
+}
+
+// notify shows a transient footer message.
+func (m *AppModel) notify(text string) tea.Cmd {
+	m.footerSeq++
+	seq := m.footerSeq
+	m.footer.SetMessage(text)
+	return tea.Tick(messageDuration, func(time.Time) tea.Msg {
+		return footerClearMsg{seq: seq}
+	})
 }
 
 func (m *AppModel) View() string {
-	main := lipgloss.JoinHorizontal(lipgloss.Top, m.watchlist.View(), m.chart.View())
-	base := lipgloss.JoinVertical(lipgloss.Left, main, m.footer.View())
+	var tab string
+	switch m.tabs.Active() {
+	case tabNews:
+		tab = m.news.View()
+	case tabDetails:
+		tab = m.details.View()
+	case tabOptions:
+		tab = m.options.View()
+	default:
+		tab = m.chart.View()
+		if m.bookFor != "" {
+			tab = lipgloss.JoinVertical(lipgloss.Left, tab, m.book.View())
+		}
+	}
+	right := lipgloss.JoinVertical(lipgloss.Left, m.tabs.View(), tab)
+	main := lipgloss.JoinHorizontal(lipgloss.Top, m.watchlist.View(), right)
+	if m.stacked {
+		main = lipgloss.JoinVertical(lipgloss.Left, m.watchlist.View(), right)
+	}
+	if m.heatmap.Visible() {
+		main = m.heatmap.View()
+	}
+	footer := m.footer.View()
+	if m.cfg.Ticker.Enabled {
+		footer = lipgloss.JoinVertical(lipgloss.Left, m.ticker.View(), footer)
+	}
+	base := lipgloss.JoinVertical(lipgloss.Left, main, footer)
 
 	if m.help.Visible() {
 		helpView := m.help.View()
 		return overlayModal(base, helpView, m.width, m.height)
 	}
 
+	if m.stats.Visible() {
+		return overlayModal(base, m.stats.View(), m.width, m.height)
+	}
+
+	if m.risk.Visible() {
+		return overlayModal(base, m.risk.View(), m.width, m.height)
+	}
+
+	if m.calendar.Visible() {
+		return overlayModal(base, m.calendar.View(), m.width, m.height)
+	}
+	if m.alertLog.Visible() {
+		return overlayModal(base, m.alertLog.View(), m.width, m.height)
+	}
+	if m.settings.Visible() {
+		return overlayModal(base, m.settings.View(), m.width, m.height)
+	}
+
+	if m.prompt.Visible() {
+		return overlayModal(base, m.prompt.View(), m.width, m.height)
+	}
+
+	if m.note.Visible() {
+		return overlayModal(base, m.note.View(), m.width, m.height)
+	}
+
+	if m.dateRange.Visible() {
+		return overlayModal(base, m.dateRange.View(), m.width, m.height)
+	}
+
+	if m.picker.Visible() {
+		return overlayModal(base, m.picker.View(), m.width, m.height)
+	}
+
+	if m.lookup.Visible() {
+		return overlayModal(base, m.lookup.View(), m.width, m.height)
+	}
+
+	if m.palette.Visible() {
+		return overlayModal(base, m.palette.View(), m.width, m.height)
+	}
+
+	if m.tour.Visible() {
+		card := m.tour.View()
+		x, y := m.tour.Position(card)
+		return overlayAt(base, card, x, y)
+	}
+
 	return base
 }
 
 func (m *AppModel) Close() {
-	if m.refreshTicker != nil {
-		m.refreshTicker.Stop()
+	m.stopTickers()
+	if m.watcher != nil {
+		m.watcher.Close()
 	}
 }
 
@@ -368,6 +1864,25 @@ func overlayModal(base, modal string, w, h int) string {
 	}
 	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, modal,
 		lipgloss.WithWhitespaceChars(" "),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("#000000")),
+		lipgloss.WithWhitespaceForeground(styles.ColorInverse),
 	)
 }
+
+// overlayAt draws card over base with its top-left corner at column x and
+// row y, leaving the rest of base in view.
+func overlayAt(base, card string, x, y int) string {
+	lines := strings.Split(base, "\n")
+	cardW := lipgloss.Width(card)
+	for i, cl := range strings.Split(card, "\n") {
+		if y+i >= len(lines) {
+			break
+		}
+		line := lines[y+i]
+		left := ansi.Truncate(line, x, "")
+		if w := lipgloss.Width(left); w < x {
+			left += strings.Repeat(" ", x-w)
+		}
+		lines[y+i] = left + "\x1b[0m" + cl + ansi.TruncateLeft(line, x+cardW, "")
+	}
+	return strings.Join(lines, "\n")
+}
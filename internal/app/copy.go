@@ -0,0 +1,21 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyTable puts the visible watchlist on the clipboard in the configured
+// copy_format, Markdown unless set to csv.
+func (m *AppModel) copyTable() tea.Cmd {
+	text, format := m.watchlist.Markdown(), "Markdown"
+	if strings.EqualFold(m.cfg.CopyFormat, "csv") {
+		text, format = m.watchlist.CSV(), "CSV"
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		return m.notify("Could not copy: " + err.Error())
+	}
+	return m.notify("Copied watchlist as " + format)
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+type earningsMsg struct {
+	symbols  []string
+	earnings []models.Earnings
+	err      error
+}
+
+// earningsSymbols returns the stocks in any watchlist, the symbols that
+// can have earnings dates.
+func (m *AppModel) earningsSymbols() []string {
+	seen := make(map[string]bool)
+	for _, l := range m.lists {
+		for _, s := range l.symbols {
+			if market.AssetClass(s) == market.ClassStock {
+				seen[s] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// refreshEarnings fetches the earnings calendar when it is stale or a
+// stock without a date asked for has appeared, like refreshFX.
+func (m *AppModel) refreshEarnings() tea.Cmd {
+	if m.earningsPending {
+		return nil
+	}
+	symbols := m.earningsSymbols()
+	if len(symbols) == 0 || time.Since(m.earningsUpdated) < data.EarningsTTL && slices.Equal(symbols, m.earningsFetched) {
+		return nil
+	}
+	m.earningsPending = true
+	src := m.earningsSrc
+	return func() tea.Msg {
+		earnings, err := src.GetEarnings(symbols)
+		return earningsMsg{symbols: symbols, earnings: earnings, err: err}
+	}
+}
+
+// applyEarnings passes the report and ex-dividend dates to every list and
+// the calendar.
+func (m *AppModel) applyEarnings() {
+	for i := range m.lists {
+		m.listModel(i).SetEarnings(m.earnings)
+	}
+	m.syncCalendar()
+}
+
+// syncCalendar shows the active watchlist's reports in the calendar.
+func (m *AppModel) syncCalendar() {
+	var rows []models.Earnings
+	missing := 0
+	for _, s := range m.symbols() {
+		if e, ok := m.earnings[s]; ok && !e.Date.IsZero() {
+			rows = append(rows, e)
+		} else if market.AssetClass(s) == market.ClassStock {
+			missing++
+		}
+	}
+	m.calendar.SetData(rows, missing)
+}
+
+// openCalendar shows the earnings calendar for the active watchlist,
+// fetching dates first when they are stale or missing.
+func (m *AppModel) openCalendar() tea.Cmd {
+	m.calendar.Open()
+	cmd := m.refreshEarnings()
+	if !m.earningsUpdated.IsZero() {
+		m.syncCalendar()
+	}
+	return cmd
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editWatchlist applies a change to the active watchlist, then keeps the
+// polled symbols, heatmap and chart in step with it. The change returns
+// the footer notice, or "" when nothing changed.
+func (m *AppModel) editWatchlist(change func() string) tea.Cmd {
+	oldSel := m.watchlist.SelectedSymbol()
+	notice := change()
+	if notice == "" {
+		return nil
+	}
+	m.lists[m.list].symbols = m.watchlist.Symbols()
+	m.syncHeatmap()
+	m.syncCompare()
+
+	cmds := []tea.Cmd{m.notify(notice), m.rememberWatchlist()}
+	if sel := m.watchlist.SelectedSymbol(); sel != oldSel {
+		if sel == "" {
+			m.chart.SetData("", m.timeRange, nil)
+		}
+		cmds = append(cmds, m.loadCurrentChart())
+		cmds = append(cmds, m.loadTab(sel))
+	}
+	return tea.Batch(cmds...)
+}
+
+// removeSelected drops the selected symbol from the active watchlist.
+func (m *AppModel) removeSelected() tea.Cmd {
+	return m.editWatchlist(func() string {
+		sel := m.watchlist.SelectedSymbol()
+		if sel == "" || !m.watchlist.RemoveSymbol(sel) {
+			return ""
+		}
+		return "Removed " + m.aliases.Name(sel) + m.undoHint()
+	})
+}
+
+// moveSelected moves the selected row up or down the active watchlist.
+func (m *AppModel) moveSelected(delta int) tea.Cmd {
+	return m.editWatchlist(func() string {
+		if !m.watchlist.MoveSelected(delta) {
+			return ""
+		}
+		return "Moved " + m.aliases.Name(m.watchlist.SelectedSymbol()) + m.undoHint()
+	})
+}
+
+// clearWatchlist removes every symbol from the active watchlist.
+func (m *AppModel) clearWatchlist() tea.Cmd {
+	return m.editWatchlist(func() string {
+		if !m.watchlist.Clear() {
+			return ""
+		}
+		return "Cleared " + m.lists[m.list].name + m.undoHint()
+	})
+}
+
+// undoEdit reverts the last change to the active watchlist.
+func (m *AppModel) undoEdit() tea.Cmd {
+	cmd := m.editWatchlist(func() string {
+		what, ok := m.watchlist.Undo()
+		if !ok {
+			return ""
+		}
+		return "Undid " + what
+	})
+	if cmd == nil {
+		return m.notify("Nothing to undo")
+	}
+	return cmd
+}
+
+// undoHint names the undo key for footer notices.
+func (m *AppModel) undoHint() string {
+	if k := m.keys.Undo.Help().Key; k != "" {
+		return " (" + k + " to undo)"
+	}
+	return ""
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+type exportMsg struct {
+	dir  string
+	name string   // file name without extension
+	exts []string // one per file written
+	err  error
+}
+
+// exportChart writes the charted candles to the export directory in the
+// configured format, plus a snapshot of the rendered chart when enabled,
+// at the configured size or the pane's. Files are named after the symbol, range and time of export.
+func (m *AppModel) exportChart() tea.Cmd {
+	symbol, tr, candles := m.chart.Series()
+	if len(candles) == 0 {
+		return m.notify("No chart data to export")
+	}
+	cfg := m.cfg.Export
+	view := m.chart.Snapshot(cfg.Width, cfg.Height)
+
+	return func() tea.Msg {
+		dir, err := exportDir(cfg.Dir)
+		if err != nil {
+			return exportMsg{err: err}
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return exportMsg{err: err}
+		}
+		base := filepath.Join(dir, exportName(symbol, tr, time.Now()))
+
+		var exts []string
+		write := func(path string, fn func(io.Writer) error) error {
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			if err := fn(f); err != nil {
+				f.Close()
+				return err
+			}
+			exts = append(exts, filepath.Ext(path))
+			return f.Close()
+		}
+
+		format := strings.ToLower(cfg.Format)
+		if format == "" {
+			format = "csv"
+		}
+		err = write(base+"."+format, func(w io.Writer) error {
+			if format == "json" {
+				return writeCandlesJSON(w, symbol, candles)
+			}
+			return writeCandlesCSV(w, candles)
+		})
+		if err == nil && cfg.Snapshot != "" {
+			// The colored snapshot is kept apart from plain text as .ans
+			ext := ".ans"
+			if !strings.EqualFold(cfg.Snapshot, "ansi") {
+				view = ansi.Strip(view)
+				ext = ".txt"
+			}
+			err = write(base+ext, func(w io.Writer) error {
+				_, err := io.WriteString(w, view+"\n")
+				return err
+			})
+		}
+		return exportMsg{dir: dir, name: filepath.Base(base), exts: exts, err: err}
+	}
+}
+
+// exportWatchlist writes the active watchlist's symbols to the export
+// directory, as CSV or plain text per watchlist_format, for `stock-tui
+// import` to read on another machine.
+func (m *AppModel) exportWatchlist() tea.Cmd {
+	l := m.lists[m.list]
+	if len(l.symbols) == 0 {
+		return m.notify("No symbols to export")
+	}
+	symbols := slices.Clone(l.symbols)
+	aliases := m.aliases
+	cfg := m.cfg.Export
+
+	return func() tea.Msg {
+		dir, err := exportDir(cfg.Dir)
+		if err != nil {
+			return exportMsg{err: err}
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return exportMsg{err: err}
+		}
+		asCSV := !strings.EqualFold(cfg.WatchlistFormat, "text")
+		ext := ".csv"
+		if !asCSV {
+			ext = ".txt"
+		}
+		name := fmt.Sprintf("%s-%s", fileName.Replace(l.name), time.Now().Format("20060102-150405"))
+		f, err := os.Create(filepath.Join(dir, name+ext))
+		if err != nil {
+			return exportMsg{err: err}
+		}
+		err = config.WriteWatchlist(f, symbols, aliases, asCSV)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return exportMsg{dir: dir, name: name, exts: []string{ext}, err: err}
+	}
+}
+
+// exportDir resolves the configured directory, expanding a leading ~.
+// Unset means the current directory.
+func exportDir(dir string) (string, error) {
+	if dir == "" {
+		return ".", nil
+	}
+	if rest, ok := strings.CutPrefix(dir, "~"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, rest), nil
+	}
+	return dir, nil
+}
+
+// fileName replaces characters that are awkward in paths.
+var fileName = strings.NewReplacer("^", "", "/", "_", "\\", "_", ":", "", "=", "_", "@", "", "~", "_", " ", "_")
+
+// exportName builds a file name such as "AAPL-24H-20240115-093000".
+func exportName(symbol string, tr models.TimeRange, at time.Time) string {
+	return fmt.Sprintf("%s-%s-%s", fileName.Replace(symbol), fileName.Replace(string(tr)), at.Format("20060102-150405"))
+}
+
+type candleJSON struct {
+	Time   time.Time `json:"time"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+}
+
+func writeCandlesJSON(w io.Writer, symbol string, candles []models.Candle) error {
+	out := struct {
+		Symbol  string       `json:"symbol"`
+		Candles []candleJSON `json:"candles"`
+	}{Symbol: symbol, Candles: make([]candleJSON, len(candles))}
+	for i, c := range candles {
+		out.Candles[i] = candleJSON{c.Timestamp, c.Open, c.High, c.Low, c.Close, c.Volume}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeCandlesCSV(w io.Writer, candles []models.Candle) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"time", "open", "high", "low", "close", "volume"})
+	for _, c := range candles {
+		_ = cw.Write([]string{
+			c.Timestamp.Format(time.RFC3339),
+			strconv.FormatFloat(c.Open, 'f', -1, 64),
+			strconv.FormatFloat(c.High, 'f', -1, 64),
+			strconv.FormatFloat(c.Low, 'f', -1, 64),
+			strconv.FormatFloat(c.Close, 'f', -1, 64),
+			strconv.FormatFloat(c.Volume, 'f', -1, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Panes that can hold the keyboard focus.
+const (
+	focusList = iota // the watchlist
+	focusTab         // the right-hand pane's active tab
+)
+
+// setFocus gives the keyboard focus to pane f.
+func (m *AppModel) setFocus(f int) {
+	m.focus = f
+	m.syncFocus()
+}
+
+// syncFocus highlights the focused pane's border and names it in the
+// footer with its main keys. The right-hand pane's name and keys follow
+// its active tab.
+func (m *AppModel) syncFocus() {
+	list := m.focus == focusList
+	tab := m.tabs.Active()
+	m.watchlist.SetFocused(list)
+	m.chart.SetFocused(!list && tab == tabChart)
+	m.news.SetFocused(!list && tab == tabNews)
+	m.details.SetFocused(!list && tab == tabDetails)
+	m.options.SetFocused(!list && tab == tabOptions)
+
+	first := func(b key.Binding) string {
+		if ks := b.Keys(); len(ks) > 0 {
+			return ks[0]
+		}
+		return "-"
+	}
+	scroll := first(m.keys.Down) + "/" + first(m.keys.Up) + " Scroll"
+	switch {
+	case list:
+		m.footer.SetFocus("Watchlist", first(m.keys.Search)+" Search  "+first(m.keys.Sort)+" Sort")
+	case tab == tabChart:
+		m.footer.SetFocus("Chart", first(m.keys.Up)+"/"+first(m.keys.Down)+" Zoom  "+
+			m.keys.PanLeft.Help().Key+"/"+m.keys.PanRight.Help().Key+" Pan")
+	case tab == tabNews:
+		m.footer.SetFocus("News", scroll+"  "+first(m.keys.OpenNews)+" Open")
+	case tab == tabOptions:
+		m.footer.SetFocus("Options", scroll+"  [/] Expiry")
+	default:
+		m.footer.SetFocus("Details", "")
+	}
+}
+
+// focusKey routes the list movement keys to the right-hand pane while it
+// has the focus: they zoom the chart and scroll the news and options tabs.
+// The Details tab has nothing to scroll but still keeps them from moving
+// the watchlist. It reports false for keys left to the rest of the app.
+func (m *AppModel) focusKey(msg tea.KeyMsg) bool {
+	if m.focus != focusTab {
+		return false
+	}
+	delta := 0
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		delta = 1
+	case key.Matches(msg, m.keys.Up):
+		delta = -1
+	}
+	if delta == 0 {
+		return false
+	}
+	switch m.tabs.Active() {
+	case tabChart:
+		if delta < 0 {
+			m.chart.ZoomIn()
+		} else {
+			m.chart.ZoomOut()
+		}
+	case tabNews:
+		m.news.Scroll(delta)
+	case tabOptions:
+		m.options.Scroll(delta)
+	}
+	return true
+}
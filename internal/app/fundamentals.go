@@ -0,0 +1,35 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// fundamentalsEntry is a fetched set of fundamentals and when it arrived.
+type fundamentalsEntry struct {
+	data models.Fundamentals
+	at   time.Time
+}
+
+// loadDetails shows fundamentals for symbol, fetching them only when the
+// session has none younger than data.FundamentalsTTL.
+func (m *AppModel) loadDetails(symbol string) tea.Cmd {
+	if symbol == "" {
+		return nil
+	}
+	m.details.SetLoading(symbol)
+	if e, ok := m.fundCache[symbol]; ok {
+		m.details.SetData(symbol, e.data)
+		if time.Since(e.at) < data.FundamentalsTTL {
+			return nil
+		}
+	}
+	prov := m.provider
+	return func() tea.Msg {
+		f, err := prov.GetFundamentals(symbol)
+		return fundamentalsMsg{symbol: symbol, data: f, err: err}
+	}
+}
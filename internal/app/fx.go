@@ -0,0 +1,68 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/market"
+)
+
+// fxRefresh is how long exchange rates are used before being refetched.
+const fxRefresh = 15 * time.Minute
+
+type fxMsg struct {
+	codes []string
+	rates map[string]float64
+	err   error
+}
+
+// fxCodes returns the currencies, other than the display currency, that
+// symbols in any watchlist are quoted in.
+func (m *AppModel) fxCodes() []string {
+	seen := make(map[string]bool)
+	add := func(code string) {
+		if major, _ := market.MajorCurrency(code); code != "PTS" && major != m.fx.To {
+			seen[major] = true
+		}
+	}
+	for _, l := range m.lists {
+		for _, s := range l.symbols {
+			if !market.IsYield(s) {
+				add(market.ExchangeFor(s).Currency)
+			}
+		}
+	}
+	for _, c := range m.currencies {
+		add(c)
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// refreshFX fetches exchange rates when a display currency is set and the
+// rates are stale or a currency without one has appeared.
+func (m *AppModel) refreshFX() tea.Cmd {
+	if !m.fx.Enabled() || m.fxPending {
+		return nil
+	}
+	codes := m.fxCodes()
+	if len(codes) == 0 || time.Since(m.fxUpdated) < fxRefresh && slices.Equal(codes, m.fxCodesFetched) {
+		return nil
+	}
+	m.fxPending = true
+	src, to := m.fxSource, m.fx.To
+	return func() tea.Msg {
+		rates, err := src.GetRates(codes, to)
+		return fxMsg{codes: codes, rates: rates, err: err}
+	}
+}
+
+// applyRates pushes the current rates to everything showing prices.
+func (m *AppModel) applyRates() {
+	for i := range m.lists {
+		m.listModel(i).SetRates(m.fx)
+	}
+	m.ticker.SetRates(m.fx)
+	m.chart.SetRates(m.fx)
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+func (m *AppModel) openIntervalPicker() {
+	names := make([]string, len(models.Intervals))
+	for i, iv := range models.Intervals {
+		names[i] = iv.Label()
+	}
+	selected := max(slices.Index(models.Intervals, m.timeRange.Interval()), 0)
+	m.picker.Open(pickerInterval, "Candle interval", names, selected)
+}
+
+// setInterval redraws the chart with candles of width iv over the same
+// range. Providers that cannot serve iv for the range, or would return
+// far too many candles, fall back to their own choice.
+func (m *AppModel) setInterval(iv models.Interval) tea.Cmd {
+	if m.timeRange.Interval() == iv {
+		return nil
+	}
+	m.timeRange = m.timeRange.WithInterval(iv)
+	if m.scrubSteps > 0 {
+		m.scrubBase = m.scrubBase.WithInterval(iv)
+	}
+	m.footer.SetTimeRange(m.timeRange)
+	return tea.Batch(m.notify("Candle interval: "+iv.Label()), m.loadCurrentChart())
+}
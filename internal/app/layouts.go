@@ -0,0 +1,246 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Watchlist pane width limits, as percents of the terminal width, once the
+// user has resized it.
+const (
+	minWatchlistWidth  = 15
+	maxWatchlistWidth  = 60
+	watchlistWidthStep = 2
+)
+
+// Pane arrangements for pane_layout. Auto stacks the panes on terminals
+// narrower than stackBelowWidth.
+const (
+	paneLayoutAuto    = "auto"
+	paneLayoutSide    = "side"
+	paneLayoutStacked = "stacked"
+
+	stackBelowWidth = 80
+)
+
+// stackPanes reports whether the watchlist goes over the right-hand pane
+// rather than beside it, for the pane_layout setting at width columns.
+func stackPanes(mode string, width int) bool {
+	switch strings.ToLower(mode) {
+	case paneLayoutSide:
+		return false
+	case paneLayoutStacked:
+		return true
+	}
+	return width > 0 && width < stackBelowWidth
+}
+
+// stackedListHeight is the watchlist's height over the right-hand pane:
+// two fifths of the main area, leaving the chart the rest.
+func stackedListHeight(mainHeight int) int {
+	return min(max(8, mainHeight*2/5), max(0, mainHeight-10))
+}
+
+// widthSaveDelay is how long the pane width has to stay put before it is
+// saved, so a run of resizes writes once.
+const widthSaveDelay = time.Second
+
+type widthSaveMsg struct {
+	seq int
+}
+
+type widthSavedMsg struct {
+	err error
+}
+
+type layoutSavedMsg struct {
+	layout models.Layout
+	path   string
+	err    error
+}
+
+func validateLayouts(layouts []models.Layout) error {
+	for _, l := range layouts {
+		if l.Name == "" {
+			return fmt.Errorf("layout without a name")
+		}
+		switch strings.ToLower(l.View) {
+		case "", "split", "heatmap":
+		default:
+			return fmt.Errorf("unknown view %q in layout %q (use split or heatmap)", l.View, l.Name)
+		}
+		if err := validateTab(l.Tab, l.Name); err != nil {
+			return err
+		}
+		if l.WatchlistWidth != 0 && (l.WatchlistWidth < minWatchlistWidth || l.WatchlistWidth > maxWatchlistWidth) {
+			return fmt.Errorf("watchlist_width in layout %q must be between %d and %d", l.Name, minWatchlistWidth, maxWatchlistWidth)
+		}
+	}
+	return nil
+}
+
+// resizeWatchlist widens (steps > 0) or narrows the watchlist pane. The
+// first resize starts from the automatic width.
+func (m *AppModel) resizeWatchlist(steps int) tea.Cmd {
+	if m.width == 0 {
+		return nil
+	}
+	if m.stacked {
+		return m.notify("The watchlist spans the width while the panes are stacked")
+	}
+	pct := m.wlPercent
+	if pct == 0 {
+		w, _ := m.watchlist.Size()
+		pct = (w*100 + m.width/2) / m.width
+	}
+	m.setWatchlistWidth(pct + steps*watchlistWidthStep)
+	return m.persistWatchlistWidth()
+}
+
+// setWatchlistWidth sets the watchlist pane to pct of the terminal width,
+// within its limits, and lays the panes out again.
+func (m *AppModel) setWatchlistWidth(pct int) {
+	m.wlPercent = min(max(pct, minWatchlistWidth), maxWatchlistWidth)
+	m.layout()
+}
+
+// persistWatchlistWidth saves the pane width once it has stayed put for
+// widthSaveDelay.
+func (m *AppModel) persistWatchlistWidth() tea.Cmd {
+	m.widthSeq++
+	seq := m.widthSeq
+	return tea.Tick(widthSaveDelay, func(time.Time) tea.Msg {
+		return widthSaveMsg{seq: seq}
+	})
+}
+
+// saveWatchlistWidth writes the pane width to the config file as
+// watchlist_width, or to the state file in read-only mode, so the next
+// start opens with it.
+func (m *AppModel) saveWatchlistWidth() tea.Cmd {
+	pct := m.wlPercent
+	m.cfg.WatchlistWidth = pct
+	if m.cfg.ReadOnly {
+		m.state.SetWatchlistWidth(pct)
+		return m.saveState()
+	}
+	return func() tea.Msg {
+		_, err := config.SaveWatchlistWidth(pct)
+		return widthSavedMsg{err: err}
+	}
+}
+
+// currentLayout snapshots the screen as a layout called name.
+func (m *AppModel) currentLayout(name string) models.Layout {
+	view := "split"
+	if m.heatmap.Visible() {
+		view = "heatmap"
+	}
+	return models.Layout{
+		Name:           name,
+		Watchlist:      m.lists[m.list].name,
+		View:           view,
+		Tab:            tabNames[m.tabs.Active()],
+		WatchlistWidth: m.wlPercent,
+	}
+}
+
+// applyLayout switches to the layout's watchlist and tab and restores the
+// pane width. Layouts saved before tabs name their pane with the news and
+// details flags instead. A watchlist that no longer exists
+// leaves the current one active.
+func (m *AppModel) applyLayout(l models.Layout) tea.Cmd {
+	var cmds []tea.Cmd
+	if l.Watchlist != "" {
+		for i, s := range m.lists {
+			if strings.EqualFold(s.name, l.Watchlist) {
+				cmds = append(cmds, m.switchWatchlist(i))
+				break
+			}
+		}
+	}
+	m.wlPercent = l.WatchlistWidth
+	tab, ok := tabIndex(l.Tab)
+	switch {
+	case ok:
+	case l.News:
+		tab = tabNews
+	case l.Details:
+		tab = tabDetails
+	}
+	cmds = append(cmds, m.switchTab(tab))
+	if m.heatmap.Visible() != strings.EqualFold(l.View, "heatmap") {
+		m.heatmap.Toggle()
+		m.syncHeatmap()
+	}
+	m.layout()
+	m.layoutName = l.Name
+	cmds = append(cmds, m.notify("Layout: "+l.Name))
+	return tea.Batch(cmds...)
+}
+
+func (m *AppModel) openLayoutPicker() tea.Cmd {
+	if len(m.cfg.Layouts) == 0 {
+		return m.notify(fmt.Sprintf("No saved layouts (%s to save one)", m.keys.SaveLayout.Help().Key))
+	}
+	names := make([]string, len(m.cfg.Layouts))
+	selected := 0
+	for i, l := range m.cfg.Layouts {
+		names[i] = l.Name
+		if l.Name == m.layoutName {
+			selected = i
+		}
+	}
+	m.picker.Open(pickerLayout, "Layouts", names, selected)
+	return nil
+}
+
+func (m *AppModel) openSaveLayout() tea.Cmd {
+	hint := "Saved to the config file; an existing name is replaced"
+	if m.cfg.ReadOnly {
+		hint = "Saved to the state file; an existing name is replaced"
+	}
+	cmd := m.prompt.Open(promptLayout, "Save layout", "Layout name", hint, func(v string) error {
+		if strings.TrimSpace(v) == "" {
+			return fmt.Errorf("enter a name")
+		}
+		return nil
+	})
+	if m.layoutName != "" {
+		m.prompt.SetValue(m.layoutName)
+	}
+	return cmd
+}
+
+// saveLayout writes the current layout to the config file, or to the
+// state file in read-only mode.
+func (m *AppModel) saveLayout(name string) tea.Cmd {
+	l := m.currentLayout(strings.TrimSpace(name))
+	if m.cfg.ReadOnly {
+		m.rememberLayout(l)
+		m.state.SetLayout(l)
+		return tea.Batch(m.saveState(), m.notify(fmt.Sprintf("Saved layout %q", l.Name)))
+	}
+	return func() tea.Msg {
+		path, err := config.SaveLayout(l)
+		return layoutSavedMsg{layout: l, path: path, err: err}
+	}
+}
+
+// rememberLayout records a saved layout for the picker, in place of one
+// with the same name.
+func (m *AppModel) rememberLayout(l models.Layout) {
+	m.layoutName = l.Name
+	for i, existing := range m.cfg.Layouts {
+		if existing.Name == l.Name {
+			m.cfg.Layouts[i] = l
+			return
+		}
+	}
+	m.cfg.Layouts = append(m.cfg.Layouts, l)
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// searchSymbols runs a symbol lookup in the background.
+func (m *AppModel) searchSymbols(query string) tea.Cmd {
+	src := m.searcher
+	return func() tea.Msg {
+		matches, err := src.SearchSymbols(query)
+		return lookupMsg{query: query, matches: matches, err: err}
+	}
+}
+
+// addSymbol appends a lookup result to the active watchlist, selects it
+// and loads its quote and chart. The list is kept past this session only
+// in read-only mode's state file.
+func (m *AppModel) addSymbol(match models.SymbolMatch) tea.Cmd {
+	sym := match.Symbol
+	if !m.watchlist.AddSymbol(sym, m.aliases.Name(sym)) {
+		return m.notify(sym + " is already in the watchlist")
+	}
+	l := &m.lists[m.list]
+	l.symbols = append(slices.Clip(l.symbols), sym)
+	m.syncHeatmap()
+	cmds := []tea.Cmd{
+		m.fetchQuotes(m.list),
+		m.loadCurrentChart(),
+		m.notify("Added " + sym + " to " + l.name),
+		m.rememberWatchlist(),
+	}
+	cmds = append(cmds, m.loadTab(sym))
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rect is a pane's place on screen, for hit-testing the mouse.
+type rect struct {
+	x, y, w, h int
+}
+
+func (r rect) contains(x, y int) bool {
+	return x >= r.x && x < r.x+r.w && y >= r.y && y < r.y+r.h
+}
+
+// panes is where layout last put each part of the main view.
+type panes struct {
+	list   rect // the watchlist
+	tabBar rect // the tab bar over the right-hand pane
+	tab    rect // the active tab, the chart with any order book under it
+}
+
+// dragSplit moves the divider between the watchlist and the right-hand
+// pane: a left-button press on either border beside it starts a drag, and
+// the pane width follows the pointer until release. It reports whether
+// it took msg.
+func (m *AppModel) dragSplit(msg tea.MouseMsg) (tea.Cmd, bool) {
+	if m.splitDrag {
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			m.setWatchlistWidth(((msg.X+1)*100 + m.width/2) / m.width)
+		case tea.MouseActionRelease:
+			m.splitDrag = false
+			return m.persistWatchlistWidth(), true
+		}
+		return nil, true
+	}
+	list := m.panes.list
+	div := list.x + list.w
+	onDivider := (msg.X == div-1 || msg.X == div) && msg.Y >= list.y && msg.Y < list.y+list.h &&
+		!m.panes.tabBar.contains(msg.X, msg.Y)
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft || !onDivider || m.heatmap.Visible() || m.stacked {
+		return nil, false
+	}
+	m.splitDrag = true
+	return nil, true
+}
+
+// wheel handles a turn of the mouse wheel over the pane under the
+// pointer: it moves the cursor through the watchlist and scrolls the news
+// and options tabs. The chart zooms and pans itself, so a turn over it
+// is left to the chart. It reports whether the turn was used.
+func (m *AppModel) wheel(msg tea.MouseMsg) bool {
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if delta == 0 || msg.Action != tea.MouseActionPress || m.heatmap.Visible() {
+		return false
+	}
+	switch {
+	case m.panes.list.contains(msg.X, msg.Y):
+		m.watchlist.Scroll(delta)
+		return true
+	case m.panes.tab.contains(msg.X, msg.Y) && m.news.Visible():
+		m.news.Scroll(delta)
+		return true
+	case m.panes.tab.contains(msg.X, msg.Y) && m.options.Visible():
+		m.options.Scroll(delta)
+		return true
+	}
+	return false
+}
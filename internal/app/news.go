@@ -0,0 +1,52 @@
+package app
+
+import (
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// loadNews shows headlines for symbol, from the last fetch when available
+// unless refresh is set.
+func (m *AppModel) loadNews(symbol string, refresh bool) tea.Cmd {
+	if symbol == "" {
+		return nil
+	}
+	m.news.SetLoading(symbol)
+	if cached, ok := m.headlines[symbol]; ok {
+		m.news.SetHeadlines(symbol, cached)
+		if !refresh {
+			return nil
+		}
+	}
+	src := m.newsSource
+	return func() tea.Msg {
+		headlines, err := src.GetNews(symbol)
+		return newsMsg{symbol: symbol, headlines: headlines, err: err}
+	}
+}
+
+// openHeadline opens a story in the default browser. Starting the handler
+// is quick, so it runs inline.
+func (m *AppModel) openHeadline(h models.Headline) tea.Cmd {
+	if err := openBrowser(h.URL); err != nil {
+		return m.notify("Could not open browser: " + err.Error())
+	}
+	return m.notify("Opened in browser: " + h.Title)
+}
+
+// openBrowser opens url with the platform's default handler.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
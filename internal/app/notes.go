@@ -0,0 +1,63 @@
+package app
+
+import (
+	"maps"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/config"
+)
+
+type notesSavedMsg struct {
+	err error
+}
+
+// openNote edits the selected symbol's note.
+func (m *AppModel) openNote() tea.Cmd {
+	sel := m.watchlist.SelectedSymbol()
+	if sel == "" {
+		return nil
+	}
+	return m.note.Open(sel, "Note for "+m.aliases.Name(sel), m.notes[sel])
+}
+
+// setNote records text as symbol's note, or deletes the note when text is
+// empty, and shows it wherever the symbol is.
+func (m *AppModel) setNote(symbol, text string) tea.Cmd {
+	if m.notes[symbol] == text {
+		return nil
+	}
+	notice := "Note saved for " + m.aliases.Name(symbol)
+	if text == "" {
+		delete(m.notes, symbol)
+		notice = "Note deleted for " + m.aliases.Name(symbol)
+	} else {
+		m.notes[symbol] = text
+	}
+	m.syncNotes()
+	return tea.Batch(m.saveNotes(), m.notify(notice))
+}
+
+// syncNotes passes the notes to every list and the selected symbol's note
+// to the detail pane.
+func (m *AppModel) syncNotes() {
+	for i := range m.lists {
+		m.listModel(i).SetNotes(m.notes)
+	}
+	note := m.notes[m.watchlist.SelectedSymbol()]
+	m.chart.SetNote(note)
+	m.details.SetNote(note)
+}
+
+// saveNotes writes the notes file in the background, one write at a time
+// like saveState.
+func (m *AppModel) saveNotes() tea.Cmd {
+	if m.notesSaving {
+		m.notesDirty = true
+		return nil
+	}
+	m.notesSaving = true
+	notes := maps.Clone(m.notes)
+	return func() tea.Msg {
+		return notesSavedMsg{err: config.SaveNotes(notes)}
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+type bookMsg struct {
+	symbol string
+	seq    int
+	book   models.OrderBook
+	err    error
+}
+
+type bookTickMsg struct {
+	seq int
+}
+
+// bookHeight is the order book pane's height under the chart.
+func bookHeight(tabHeight int) int {
+	return max(4, min(12, tabHeight/3))
+}
+
+// toggleBook shows or hides the order book pane. It only opens on a
+// crypto pair, but stays on while other symbols are selected and comes
+// back with the next pair.
+func (m *AppModel) toggleBook() tea.Cmd {
+	if !m.bookOn && market.AssetClass(m.watchlist.SelectedSymbol()) != market.ClassCrypto {
+		return m.notify("The order book is only for crypto pairs")
+	}
+	m.bookOn = !m.bookOn
+	return m.syncBook()
+}
+
+// syncBook puts the order book pane under the chart for the selected
+// symbol when it is on, the chart tab is showing and the symbol is a
+// crypto pair, and hides it otherwise. A change of symbol starts a new
+// poll; the old one stops at its next tick.
+func (m *AppModel) syncBook() tea.Cmd {
+	want := ""
+	if sel := m.watchlist.SelectedSymbol(); m.bookOn && m.tabs.Active() == tabChart &&
+		market.AssetClass(sel) == market.ClassCrypto {
+		want = sel
+	}
+	if want == m.bookFor {
+		return nil
+	}
+	m.bookFor = want
+	m.bookSeq++
+	m.layout()
+	if want == "" {
+		return nil
+	}
+	m.book.SetSymbol(want)
+	return m.fetchBook(want, m.bookSeq)
+}
+
+func (m *AppModel) fetchBook(symbol string, seq int) tea.Cmd {
+	src, depth := m.bookSrc, m.book.Levels()
+	return func() tea.Msg {
+		book, err := src.GetOrderBook(symbol, depth)
+		return bookMsg{symbol: symbol, seq: seq, book: book, err: err}
+	}
+}
+
+// nextBookTick schedules the next poll of the order book, every
+// data.OrderBookRefresh while the pane is up.
+func nextBookTick(seq int) tea.Cmd {
+	return tea.Tick(data.OrderBookRefresh, func(time.Time) tea.Msg {
+		return bookTickMsg{seq: seq}
+	})
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/paper"
+	"github.com/ni5arga/stock-tui/internal/ui/chart"
+)
+
+type paperSavedMsg struct {
+	err error
+}
+
+// openTradePrompt takes a paper order for the selected symbol, showing
+// the position and open orders on it.
+func (m *AppModel) openTradePrompt() tea.Cmd {
+	sel := m.watchlist.SelectedSymbol()
+	if sel == "" {
+		return nil
+	}
+	m.tradeSymbol = sel
+
+	hint := fmt.Sprintf("Cash %s", market.FormatPrice(m.paper.Cash, "", "%.2f"))
+	if p := m.paper.Position(sel); p.Shares > 0 {
+		hint = fmt.Sprintf("Holding %s at %.2f · %s", strconv.FormatFloat(p.Shares, 'f', -1, 64), p.Cost, hint)
+	}
+	if open := m.paper.OrdersFor(sel); len(open) > 0 {
+		parts := make([]string, len(open))
+		for i, o := range open {
+			parts[i] = fmt.Sprintf("#%d %s", o.ID, o)
+		}
+		hint += "\nOpen: " + strings.Join(parts, ", ")
+	}
+	hint += "\nbuy N or sell N, @ price for a limit, or cancel"
+
+	return m.prompt.Open(promptTrade, "Paper trade "+m.aliases.Name(sel), "e.g. buy 10 or sell 5 @ 180", hint, func(v string) error {
+		if strings.EqualFold(v, "cancel") {
+			return nil
+		}
+		o, err := paper.Parse(sel, v)
+		if err != nil {
+			return err
+		}
+		// Check against a copy, so a bad order leaves the account alone
+		trial := m.paper.Clone()
+		_, err = trial.Place(o, m.paperPrices)
+		return err
+	})
+}
+
+// trade places or cancels the paper order submitted for symbol. A market
+// order, or a limit the price already meets, fills at once at the last
+// quote.
+func (m *AppModel) trade(symbol, spec string) tea.Cmd {
+	name := m.aliases.Name(symbol)
+	if strings.EqualFold(spec, "cancel") {
+		n := m.paper.Cancel(symbol)
+		if n == 0 {
+			return m.notify("No open orders for " + name)
+		}
+		return tea.Batch(m.savePaper(), m.notify(fmt.Sprintf("Cancelled %d order(s) for %s", n, name)))
+	}
+	o, err := paper.Parse(symbol, spec)
+	if err == nil {
+		o, err = m.paper.Place(o, m.paperPrices)
+	}
+	if err != nil {
+		return m.notify("Order not placed: " + err.Error())
+	}
+	cmds := []tea.Cmd{m.notify(fmt.Sprintf("Order placed: #%d %s", o.ID, o))}
+	if price := m.paperPrices[symbol]; price > 0 {
+		cmds = append(cmds, m.fillPaper([]models.Quote{{Symbol: symbol, Price: price}})...)
+	}
+	return tea.Batch(append(cmds, m.savePaper())...)
+}
+
+// fillPaper fills the open paper orders the quotes reach, posting a footer
+// notice for each fill or rejection and saving the account when any.
+func (m *AppModel) fillPaper(quotes []models.Quote) []tea.Cmd {
+	for _, q := range quotes {
+		if q.Price > 0 {
+			m.paperPrices[q.Symbol] = q.Price
+		}
+	}
+	fills, rejected := m.paper.Evaluate(quotes)
+	var cmds []tea.Cmd
+	for _, f := range fills {
+		cmds = append(cmds, m.notify(f.Message(m.aliases.Name(f.Symbol))))
+	}
+	for _, o := range rejected {
+		cmds = append(cmds, m.notify(fmt.Sprintf("Order #%d rejected: %s no longer covered", o.ID, o)))
+	}
+	if len(cmds) > 0 {
+		cmds = append(cmds, m.savePaper())
+	}
+	return cmds
+}
+
+// syncPaper lists the paper account under the portfolio chart once it has
+// traded.
+func (m *AppModel) syncPaper() {
+	a := m.paper
+	if !m.chart.Portfolio() || len(a.Positions)+len(a.Orders)+len(a.Fills) == 0 {
+		m.chart.SetPaper(nil)
+		return
+	}
+	value, unrealized := a.Value(m.paperPrices)
+	m.chart.SetPaper(&chart.PaperSummary{
+		Cash:       a.Cash,
+		Value:      value,
+		Unrealized: unrealized,
+		Realized:   a.Realized,
+		Orders:     a.Orders,
+	})
+}
+
+// savePaper writes the paper account in the background, one write at a
+// time like saveNotes.
+func (m *AppModel) savePaper() tea.Cmd {
+	if m.paperSaving {
+		m.paperDirty = true
+		return nil
+	}
+	m.paperSaving = true
+	acct := m.paper.Clone()
+	return func() tea.Msg {
+		return paperSavedMsg{err: config.SavePaper(acct)}
+	}
+}
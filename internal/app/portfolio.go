@@ -0,0 +1,108 @@
+package app
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/chart"
+)
+
+type portfolioMsg struct {
+	tr   models.TimeRange
+	data []models.Candle
+	err  error
+}
+
+type riskMsg struct {
+	history []models.Candle
+	values  map[string]float64
+	err     error
+}
+
+// noPortfolio is the notice when no transactions are configured. It
+// stands in for the chart when only the paper account has anything to show.
+const noPortfolio = "No portfolio: add [[portfolio.transactions]] to the config"
+
+// togglePortfolio switches the chart between the selected symbol and the
+// portfolio against its benchmark, with the paper account under it.
+func (m *AppModel) togglePortfolio() tea.Cmd {
+	if m.chart.Portfolio() {
+		m.chart.SetPortfolio(false)
+		return nil
+	}
+	a := m.paper
+	if len(m.cfg.Portfolio.Transactions) == 0 && len(a.Positions)+len(a.Orders)+len(a.Fills) == 0 {
+		return m.notify(noPortfolio)
+	}
+	m.chart.SetPortfolio(true)
+	return tea.Batch(m.switchTab(tabChart), m.loadPortfolio())
+}
+
+// loadPortfolio recomputes the portfolio at the current range, showing the
+// last computed series meanwhile, and fetches the benchmark when it is not
+// cached.
+func (m *AppModel) loadPortfolio() tea.Cmd {
+	if !m.chart.Portfolio() {
+		return nil
+	}
+	if len(m.cfg.Portfolio.Transactions) == 0 {
+		m.chart.SetPortfolioData(chart.Series{}, chart.Series{}, errors.New(noPortfolio))
+		return nil
+	}
+	tr := m.timeRange
+	if _, ok := m.portfolio[tr]; ok {
+		m.syncPortfolio()
+	} else {
+		m.chart.SetPortfolioData(chart.Series{}, chart.Series{}, nil)
+	}
+	prov, port := m.provider, m.cfg.Portfolio
+	cmds := []tea.Cmd{func() tea.Msg {
+		h, err := data.PortfolioHistory(prov, port, tr)
+		return portfolioMsg{tr: tr, data: h, err: err}
+	}}
+	if b := port.Benchmark; b != "" {
+		if _, ok := m.cachedHistory(b, tr); !ok {
+			cmds = append(cmds, m.fetchHistory(b, tr))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// syncPortfolio feeds the chart the portfolio and benchmark history at the
+// current range, once the portfolio's has arrived.
+func (m *AppModel) syncPortfolio() {
+	if !m.chart.Portfolio() {
+		return
+	}
+	p, ok := m.portfolio[m.timeRange]
+	if !ok {
+		return
+	}
+	b := m.cfg.Portfolio.Benchmark
+	h, _ := m.cachedHistory(b, m.timeRange)
+	m.chart.SetPortfolioData(
+		chart.Series{Symbol: chart.PortfolioSymbol, Data: p},
+		chart.Series{Symbol: b, Data: h},
+		nil,
+	)
+}
+
+// openRisk shows the risk metrics of the portfolio over the last year
+// and its current split by sector.
+func (m *AppModel) openRisk() tea.Cmd {
+	if len(m.cfg.Portfolio.Transactions) == 0 {
+		return m.notify(noPortfolio)
+	}
+	m.risk.Open()
+	prov, port := m.provider, m.cfg.Portfolio
+	return func() tea.Msg {
+		h, err := data.PortfolioHistory(prov, port, statsRange)
+		if err != nil {
+			return riskMsg{err: err}
+		}
+		values, err := data.PortfolioValues(prov, port)
+		return riskMsg{history: h, values: values, err: err}
+	}
+}
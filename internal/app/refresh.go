@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// refreshRules resolves each symbol's quote refresh interval from the
+// refresh_rules config.
+type refreshRules struct {
+	symbols map[string]time.Duration
+	classes map[string]time.Duration
+}
+
+func newRefreshRules(rules []models.RefreshRule) (refreshRules, error) {
+	r := refreshRules{
+		symbols: make(map[string]time.Duration),
+		classes: make(map[string]time.Duration),
+	}
+	for _, rule := range rules {
+		if rule.Interval <= 0 {
+			return r, fmt.Errorf("refresh_rules: %s%s needs an interval", rule.Symbol, rule.Class)
+		}
+		// Same floor as refresh_interval
+		every := max(rule.Interval, time.Second)
+		switch {
+		case rule.Symbol != "" && rule.Class != "":
+			return r, fmt.Errorf("refresh_rules: set symbol or class, not both (%s, %s)", rule.Symbol, rule.Class)
+		case rule.Symbol != "":
+			r.symbols[strings.ToUpper(rule.Symbol)] = every
+		case slices.Contains(market.Classes, strings.ToLower(rule.Class)):
+			r.classes[strings.ToLower(rule.Class)] = every
+		case rule.Class != "":
+			return r, fmt.Errorf("refresh_rules: unknown class %q (available: %s)", rule.Class, strings.Join(market.Classes, ", "))
+		default:
+			return r, fmt.Errorf("refresh_rules: a rule needs a symbol or a class")
+		}
+	}
+	return r, nil
+}
+
+// interval returns symbol's refresh interval, or fallback when no rule
+// covers it.
+func (r refreshRules) interval(symbol string, fallback time.Duration) time.Duration {
+	if d, ok := r.symbols[strings.ToUpper(symbol)]; ok {
+		return d
+	}
+	if d, ok := r.classes[market.AssetClass(symbol)]; ok {
+		return d
+	}
+	return fallback
+}
+
+// shortestRefresh returns the shortest polling interval of any list or
+// refresh rule, or 0 when there is none.
+func shortestRefresh(cfg *models.AppConfig) time.Duration {
+	var d time.Duration
+	for _, l := range cfg.Lists() {
+		if l.RefreshInterval > 0 && (d == 0 || l.RefreshInterval < d) {
+			d = l.RefreshInterval
+		}
+	}
+	for _, rule := range cfg.RefreshRules {
+		if every := max(rule.Interval, time.Second); d == 0 || every < d {
+			d = every
+		}
+	}
+	if cfg.LowBandwidth && d > 0 {
+		d = max(d, lowBandwidthRefresh)
+	}
+	return d
+}
+
+// atLeast returns the rules with every interval raised to d or more.
+func (r refreshRules) atLeast(d time.Duration) refreshRules {
+	out := refreshRules{
+		symbols: make(map[string]time.Duration, len(r.symbols)),
+		classes: make(map[string]time.Duration, len(r.classes)),
+	}
+	for s, every := range r.symbols {
+		out.symbols[s] = max(every, d)
+	}
+	for c, every := range r.classes {
+		out.classes[c] = max(every, d)
+	}
+	return out
+}
+
+// intervals returns every interval a list with the given fallback can
+// need, shortest first. Each gets its own schedule, so symbols added later
+// join the right one without restarting any.
+func (r refreshRules) intervals(fallback time.Duration) []time.Duration {
+	out := []time.Duration{fallback}
+	for _, d := range r.symbols {
+		out = append(out, d)
+	}
+	for _, d := range r.classes {
+		out = append(out, d)
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// staleAfter is how many refresh intervals a quote may miss before its
+// row is marked stale.
+const staleAfter = 2
+
+// markStale flags list i's rows whose quotes have not refreshed for
+// staleAfter intervals, naming them in the footer for the active list.
+// Symbols paused while their market is closed are not expected to update.
+func (m *AppModel) markStale(i int) {
+	every := m.lists[i].interval
+	stale := m.listModel(i).MarkStale(time.Now(), func(sym string) time.Duration {
+		if m.paused[sym] {
+			return 0
+		}
+		return staleAfter * m.refresh.interval(sym, every)
+	})
+	if i == m.list {
+		m.footer.SetStale(stale)
+	}
+}
+
+// schedule is one refresh cadence of a watchlist, polling the symbols
+// whose interval matches.
+type schedule struct {
+	every  time.Duration
+	ticker *time.Ticker
+	done   chan struct{} // closed when the schedule stops
+}
+
+// quoteSymbols returns list i's symbols plus its pinned symbol.
+func (m *AppModel) quoteSymbols(i int) []string {
+	symbols := m.lists[i].symbols
+	if pin := m.listModel(i).Pinned(); pin != "" && !slices.Contains(symbols, pin) {
+		symbols = append(slices.Clip(symbols), pin)
+	}
+	return symbols
+}
+
+// fetchScheduled polls the symbols of list i that are due on schedule s.
+func (m *AppModel) fetchScheduled(i, s int) tea.Cmd {
+	every := m.lists[i].schedules[s].every
+	all := m.quoteSymbols(i)
+	var due []string
+	for _, sym := range all {
+		if m.refresh.interval(sym, m.lists[i].interval) == every {
+			due = append(due, sym)
+		}
+	}
+	if len(due) == len(all) {
+		m.lists[i].quoted = true
+	}
+	if m.cfg.PauseWhenClosed {
+		due = m.skipClosed(due)
+	}
+	return m.requestQuotes(i, due)
+}
+
+// skipClosed drops the symbols whose market is closed and that were
+// already polled once since it closed; the first poll after the close
+// picks up the final price.
+func (m *AppModel) skipClosed(symbols []string) []string {
+	now := time.Now()
+	var out []string
+	for _, sym := range symbols {
+		if market.CalendarFor(sym).Trading(now) {
+			delete(m.paused, sym)
+		} else if m.paused[sym] {
+			continue
+		} else {
+			m.paused[sym] = true
+		}
+		out = append(out, sym)
+	}
+	return out
+}
+
+// mergeQuotes folds a possibly partial batch into the last quotes, keeping
+// only symbols for which keep is true.
+func mergeQuotes(prev, fresh []models.Quote, keep func(string) bool) []models.Quote {
+	out := make([]models.Quote, 0, max(len(prev), len(fresh)))
+	index := make(map[string]int, len(prev))
+	for _, q := range prev {
+		if keep(q.Symbol) {
+			index[q.Symbol] = len(out)
+			out = append(out, q)
+		}
+	}
+	for _, q := range fresh {
+		if i, ok := index[q.Symbol]; ok {
+			out[i] = q
+		} else {
+			index[q.Symbol] = len(out)
+			out = append(out, q)
+		}
+	}
+	return out
+}
@@ -0,0 +1,209 @@
+package app
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+	"github.com/spf13/viper"
+)
+
+// reloadDelay lets an editor finish saving, which can take several
+// writes, before the config is read again.
+const reloadDelay = 250 * time.Millisecond
+
+// configChangedMsg reports a write to the config file.
+type configChangedMsg struct{}
+
+// reloadMsg reads the config again once writes have settled; seq tells the
+// latest change from earlier ones.
+type reloadMsg struct {
+	seq int
+}
+
+// watchConfig starts watching the config file in use, if there is one.
+// The directory is watched rather than the file, since editors often save
+// by renaming a new file over the old one.
+func (m *AppModel) watchConfig() tea.Cmd {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return nil
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		w.Close()
+		return nil
+	}
+	m.watcher = w
+	m.watchedPath = filepath.Clean(path)
+	return m.waitForConfig()
+}
+
+// waitForConfig reports the next change to the config file.
+func (m *AppModel) waitForConfig() tea.Cmd {
+	w, path := m.watcher, m.watchedPath
+	return func() tea.Msg {
+		for {
+			select {
+			case ev, ok := <-w.Events:
+				if !ok {
+					return nil
+				}
+				if filepath.Clean(ev.Name) == path && ev.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					return configChangedMsg{}
+				}
+			case _, ok := <-w.Errors:
+				if !ok {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// reloadConfig reads the config file again and applies what can change
+// while running: each watchlist's symbols, the theme and the refresh
+// intervals. A config that fails to load or validate is reported and
+// changes nothing.
+func (m *AppModel) reloadConfig() tea.Cmd {
+	cfg, err := config.Reload()
+	if err != nil {
+		return m.notify("Config not reloaded: " + err.Error())
+	}
+	// Command line flags outlast the file
+	cfg.NoCache, cfg.ReadOnly = m.cfg.NoCache, m.cfg.ReadOnly
+	cfg.ReducedMotion, cfg.LowBandwidth = m.cfg.ReducedMotion, m.cfg.LowBandwidth
+	if cfg.ReadOnly {
+		m.state.Apply(cfg)
+	}
+
+	theme, err := styles.Lookup(cfg.Theme)
+	if err == nil {
+		theme, err = theme.WithColors(cfg.Colors).WithChartColors(cfg.ChartColors)
+	}
+	if err != nil {
+		return m.notify("Config not reloaded: " + err.Error())
+	}
+	refresh, err := newRefreshRules(cfg.RefreshRules)
+	if err != nil {
+		return m.notify("Config not reloaded: " + err.Error())
+	}
+	if cfg.LowBandwidth {
+		refresh = refresh.atLeast(lowBandwidthRefresh)
+	}
+
+	var changed []string
+	var cmds []tea.Cmd
+	if cfg.Theme != m.cfg.Theme || !reflect.DeepEqual(cfg.Colors, m.cfg.Colors) || !reflect.DeepEqual(cfg.ChartColors, m.cfg.ChartColors) {
+		styles.Use(theme)
+		changed = append(changed, "theme")
+	}
+
+	symbols, cmd := m.resyncLists(m.cfg.Lists(), cfg.Lists())
+	if symbols {
+		changed = append(changed, "symbols")
+		cmds = append(cmds, cmd)
+	}
+
+	retimed := !reflect.DeepEqual(refresh, m.refresh)
+	m.refresh = refresh
+	if m.setIntervals(cfg.Lists()) {
+		retimed = true
+	}
+	if retimed {
+		changed = append(changed, "refresh intervals")
+		cmds = append(cmds, m.restartTickers())
+	}
+
+	m.cfg.Theme, m.cfg.Colors, m.cfg.ChartColors = cfg.Theme, cfg.Colors, cfg.ChartColors
+	m.cfg.Symbols, m.cfg.Watchlists = cfg.Symbols, cfg.Watchlists
+	m.cfg.RefreshInterval, m.cfg.RefreshRules = cfg.RefreshInterval, cfg.RefreshRules
+	if len(changed) == 0 {
+		// Saving a layout writes the file too, changing none of this
+		return nil
+	}
+	cmds = append(cmds, m.notify("Config reloaded: "+strings.Join(changed, ", ")))
+	return tea.Batch(cmds...)
+}
+
+// setIntervals gives each running list the refresh interval of the list
+// of the same name in lists, and reports whether any changed. The caller
+// restarts the tickers.
+func (m *AppModel) setIntervals(lists []models.Watchlist) bool {
+	changed := false
+	for _, l := range lists {
+		i := slices.IndexFunc(m.lists, func(s watchlistState) bool { return s.name == l.Name })
+		if i < 0 {
+			continue
+		}
+		every := l.RefreshInterval
+		if m.cfg.LowBandwidth && !m.lists[i].local {
+			every = max(every, lowBandwidthRefresh)
+		}
+		if every != m.lists[i].interval {
+			m.lists[i].interval = every
+			changed = true
+		}
+	}
+	return changed
+}
+
+// resyncLists applies the symbols added to and removed from each watchlist
+// between two versions of the config. Edits made in the app stay, and lists
+// added or renamed in the file wait for a restart. It reports whether any
+// list changed.
+func (m *AppModel) resyncLists(before, after []models.Watchlist) (bool, tea.Cmd) {
+	changed := false
+	var cmds []tea.Cmd
+	for _, a := range after {
+		i := slices.IndexFunc(m.lists, func(s watchlistState) bool { return s.name == a.Name })
+		b := slices.IndexFunc(before, func(w models.Watchlist) bool { return w.Name == a.Name })
+		if i < 0 || b < 0 {
+			continue
+		}
+		add := difference(a.Symbols, before[b].Symbols)
+		remove := difference(before[b].Symbols, a.Symbols)
+		wl := m.listModel(i)
+		oldSel := wl.SelectedSymbol()
+		if !wl.Resync(add, remove, m.aliases) {
+			continue
+		}
+		changed = true
+		m.lists[i].symbols = wl.Symbols()
+		if i != m.list {
+			m.lists[i].quoted = false
+			continue
+		}
+		m.syncHeatmap()
+		m.syncCompare()
+		cmds = append(cmds, m.fetchQuotes(i))
+		if sel := wl.SelectedSymbol(); sel != oldSel {
+			if sel == "" {
+				m.chart.SetData("", m.timeRange, nil)
+			}
+			cmds = append(cmds, m.loadCurrentChart(), m.loadTab(sel))
+		}
+	}
+	return changed, tea.Batch(cmds...)
+}
+
+// difference returns the symbols of a missing from b, in a's order.
+func difference(a, b []string) []string {
+	var out []string
+	for _, s := range a {
+		if !slices.Contains(b, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
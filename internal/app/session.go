@@ -0,0 +1,62 @@
+package app
+
+import (
+	"slices"
+
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/watchlist"
+)
+
+// restoreSession puts back the screen saved on the last quit, before the
+// first fetch and the on_start commands. Whatever no longer applies, such
+// as a removed watchlist or symbol, is skipped.
+func (m *AppModel) restoreSession() {
+	s := m.session
+	if i := slices.IndexFunc(m.lists, func(l watchlistState) bool { return l.name == s.Watchlist }); i >= 0 {
+		m.switchWatchlist(i)
+	}
+
+	mode, okSort := watchlist.ParseSortMode(s.Sort)
+	for i := range m.lists {
+		wl := m.listModel(i)
+		if okSort {
+			wl.SetSort(mode, s.SortAsc)
+		}
+		wl.SetCollapsed(s.Collapsed)
+	}
+	if s.Symbol != "" {
+		m.watchlist.Select(s.Symbol)
+	}
+
+	saved := models.TimeRange(s.Range)
+	if tr, ok := models.ParseTimeRange(string(saved.Base())); ok {
+		if iv, ok := models.ParseInterval(string(saved.Interval())); ok {
+			m.timeRange = tr.WithInterval(iv)
+			m.footer.SetTimeRange(m.timeRange)
+		}
+	}
+	if s.ChartType != "" {
+		m.chart.SetChartTypeName(s.ChartType)
+	}
+}
+
+// SaveSession records the screen for the next start: the active watchlist
+// and its selection, the range, chart type, sort order and collapsed
+// sections. A scrubbed chart is saved at the range being scrubbed.
+func (m *AppModel) SaveSession() error {
+	tr := m.timeRange
+	if m.scrubSteps > 0 {
+		tr = m.scrubBase
+	}
+	mode, asc := m.watchlist.SortInfo()
+	return config.SaveSession(config.Session{
+		Watchlist: m.lists[m.list].name,
+		Symbol:    m.watchlist.SelectedSymbol(),
+		Range:     string(tr),
+		ChartType: m.chart.ChartTypeName(),
+		Sort:      mode.String(),
+		SortAsc:   asc,
+		Collapsed: m.watchlist.Collapsed(),
+	})
+}
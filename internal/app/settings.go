@@ -0,0 +1,303 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/settings"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// refreshChoices are the refresh intervals the settings screen offers.
+var refreshChoices = []string{"1s", "2s", "5s", "10s", "15s", "30s", "1m", "5m"}
+
+// providerChoices are the providers the settings screen offers, those
+// that are set up enough to use.
+var providerChoices = []string{"multi", "yahoo", "coingecko", "binance", "finnhub", "stooq", "simulator", "generic", "plugin"}
+
+// settingToggle is an on/off setting: its config key, its label on the
+// settings screen and the config field it sets.
+type settingToggle struct {
+	key, label string
+	on         func(*models.AppConfig) *bool
+}
+
+// settingToggles are the on/off settings the settings screen offers.
+var settingToggles = []settingToggle{
+	{"show_volume", "Volume bars", func(c *models.AppConfig) *bool { return &c.ShowVolume }},
+	{"chart_grid", "Chart grid", func(c *models.AppConfig) *bool { return &c.ChartGrid }},
+	{"previous_close_line", "Previous close line", func(c *models.AppConfig) *bool { return &c.PrevCloseLine }},
+	{"session_change", "Change since session start", func(c *models.AppConfig) *bool { return &c.SessionChange }},
+	{"extended_hours", "Extended hours", func(c *models.AppConfig) *bool { return &c.ExtendedHours }},
+	{"notifications", "Desktop notifications", func(c *models.AppConfig) *bool { return &c.Notifications }},
+	{"pause_when_closed", "Pause when market closed", func(c *models.AppConfig) *bool { return &c.PauseWhenClosed }},
+}
+
+type settingsSavedMsg struct {
+	path string
+	err  error
+}
+
+// openSettings shows the settings screen with the values in use.
+func (m *AppModel) openSettings() {
+	ranges := make([]string, len(models.Ranges))
+	for i, r := range models.Ranges {
+		ranges[i] = string(r)
+	}
+	var providers []string
+	for _, name := range providerChoices {
+		if data.CheckProvider(name, providerOptions(m.cfg)) == nil {
+			providers = append(providers, name)
+		}
+	}
+	var fixed string
+	if len(m.cfg.Providers) > 0 {
+		fixed = "The provider is set by the providers list in the config"
+	}
+
+	rows := []settings.Setting{
+		{Key: "refresh_interval", Label: "Refresh interval", Options: refreshChoices},
+		{Key: "theme", Label: "Theme", Options: styles.Names()},
+		{Key: "default_range", Label: "Default range", Options: ranges},
+		{Key: "provider", Label: "Provider", Options: providers, Fixed: fixed},
+	}
+	for i := range rows {
+		rows[i].Value = m.settingValue(rows[i].Key)
+		rows[i].Options = withCurrent(rows[i].Options, rows[i].Value)
+	}
+	for _, t := range settingToggles {
+		rows = append(rows, settings.Setting{Key: t.key, Label: t.label, Value: m.settingValue(t.key)})
+	}
+
+	hint := "Changes are saved to the state file when closed"
+	if !m.cfg.ReadOnly {
+		path, err := config.Path()
+		if err != nil {
+			hint = "Changes last for this session"
+		} else {
+			hint = "Changes are saved to " + path + " when closed"
+		}
+	}
+	m.settings.Open(rows, hint)
+}
+
+// withCurrent returns options with current added when it is not one of
+// them, so a value set by hand still shows.
+func withCurrent(options []string, current string) []string {
+	if current == "" || slices.Contains(options, current) {
+		return options
+	}
+	return append(slices.Clone(options), current)
+}
+
+// shortDuration formats d as a config value, such as 30s or 1m.
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// changeSetting applies a change made on the settings screen at once and
+// records it for saving when the screen closes. A change that cannot be
+// applied is put back, with the reason shown on the screen.
+func (m *AppModel) changeSetting(msg settings.ChangedMsg) tea.Cmd {
+	var value any = msg.Value
+	var cmd tea.Cmd
+	var err error
+	switch msg.Key {
+	case "refresh_interval":
+		var d time.Duration
+		if d, err = time.ParseDuration(msg.Value); err != nil {
+			break
+		}
+		m.cfg.RefreshInterval = d
+		if m.setIntervals(m.cfg.Lists()) {
+			cmd = m.restartTickers()
+		}
+	case "theme":
+		var theme styles.Theme
+		theme, err = styles.Lookup(msg.Value)
+		if err == nil {
+			theme, err = theme.WithColors(m.cfg.Colors).WithChartColors(m.cfg.ChartColors)
+		}
+		if err == nil {
+			styles.Use(theme)
+			m.cfg.Theme = msg.Value
+		}
+	case "default_range":
+		tr, ok := models.ParseTimeRange(msg.Value)
+		if !ok {
+			err = fmt.Errorf("unknown range %q", msg.Value)
+			break
+		}
+		m.cfg.DefaultRange = msg.Value
+		m.setTimeRange(tr)
+		cmd = m.loadCurrentChart()
+	case "provider":
+		cfg := *m.cfg
+		cfg.Provider = msg.Value
+		if cmd, err = m.reconnect(&cfg); err == nil {
+			m.cfg.Provider = msg.Value
+		}
+	default:
+		i := slices.IndexFunc(settingToggles, func(t settingToggle) bool { return t.key == msg.Key })
+		if i < 0 {
+			return nil
+		}
+		on := msg.Value == "on"
+		value = on
+		cmd, err = m.toggleSetting(msg.Key, on)
+		if err == nil {
+			*settingToggles[i].on(m.cfg) = on
+		}
+	}
+	if err != nil {
+		m.settings.SetValue(msg.Key, m.settingValue(msg.Key), err)
+		return nil
+	}
+	if m.settingsEdits == nil {
+		m.settingsEdits = make(map[string]any)
+	}
+	m.settingsEdits[msg.Key] = value
+	return cmd
+}
+
+// settingValue returns the value in use for a settings screen row.
+func (m *AppModel) settingValue(key string) string {
+	switch key {
+	case "refresh_interval":
+		return shortDuration(m.cfg.RefreshInterval)
+	case "theme":
+		if m.cfg.Theme == "" {
+			return "dark"
+		}
+		return strings.ToLower(m.cfg.Theme)
+	case "default_range":
+		if tr, ok := models.ParseTimeRange(m.cfg.DefaultRange); ok {
+			return string(tr.Base())
+		}
+		return string(models.Range24H)
+	case "provider":
+		return m.cfg.Provider
+	}
+	for _, t := range settingToggles {
+		if t.key != key {
+			continue
+		}
+		if *t.on(m.cfg) {
+			return "on"
+		}
+		return "off"
+	}
+	return ""
+}
+
+// toggleSetting switches an on/off setting in the running app.
+func (m *AppModel) toggleSetting(key string, on bool) (tea.Cmd, error) {
+	switch key {
+	case "show_volume":
+		m.chart.SetVolume(on, m.cfg.VolumeMinHeight)
+	case "chart_grid":
+		m.chart.SetGrid(on, m.cfg.ChartGridRows)
+	case "previous_close_line":
+		m.chart.SetPrevCloseLine(on)
+	case "session_change":
+		for i := range m.lists {
+			m.listModel(i).SetSessionChange(on)
+		}
+	case "extended_hours":
+		cfg := *m.cfg
+		cfg.ExtendedHours = on
+		cmd, err := m.reconnect(&cfg)
+		if err != nil {
+			return nil, err
+		}
+		m.chart.SetSessions(on)
+		return cmd, nil
+	case "pause_when_closed":
+		if !on {
+			clear(m.paused)
+		}
+	}
+	return nil, nil
+}
+
+// reconnect rebuilds the providers for cfg after the provider or extended
+// hours setting changed: every list gets a provider of the new kind, the
+// news, options, order book, earnings, search and FX sources follow the
+// new default chain, and quotes and history are fetched again.
+func (m *AppModel) reconnect(cfg *models.AppConfig) (tea.Cmd, error) {
+	for _, name := range cfg.ProviderChain() {
+		if err := data.CheckProvider(name, providerOptions(cfg)); err != nil {
+			return nil, err
+		}
+	}
+	fresh, err := newWatchlistStates(cfg, m.aliases)
+	if err != nil {
+		return nil, err
+	}
+	for i := range m.lists {
+		j := slices.IndexFunc(fresh, func(s watchlistState) bool { return s.name == m.lists[i].name })
+		if j < 0 {
+			continue
+		}
+		m.lists[i].provider = fresh[j].provider
+		m.lists[i].local = fresh[j].local
+		m.lists[i].interval = fresh[j].interval
+		m.lists[i].quoted = false
+		m.lists[i].fetched = false
+	}
+	chain := cfg.ProviderChain()
+	m.newsSource = data.NewNews(chain)
+	m.optionsSrc = data.NewOptions(chain)
+	m.bookSrc = data.NewOrderBook(chain)
+	m.earningsSrc = data.NewEarnings(chain)
+	m.searcher = data.NewSearch(chain)
+	m.fxSource = data.NewFX(chain)
+	m.provider = m.lists[m.list].provider
+	m.footer.SetProvider(m.provider.Name())
+
+	// Charts from the old provider are fetched again from the new one
+	clear(m.lastHistory)
+	clear(m.portfolio)
+	return tea.Batch(m.restartTickers(), m.fetchQuotes(m.list), m.fetchAllHistory(), m.loadCurrentChart()), nil
+}
+
+// saveSettings writes the changes made on the settings screen to the
+// config file, or to the state file in read-only mode.
+func (m *AppModel) saveSettings() tea.Cmd {
+	edits := m.settingsEdits
+	m.settingsEdits = nil
+	if len(edits) == 0 {
+		return nil
+	}
+	if m.cfg.ReadOnly {
+		for k, v := range edits {
+			m.state.SetSetting(k, v)
+		}
+		return tea.Batch(m.saveState(), m.notify("Settings saved to the state file"))
+	}
+	return func() tea.Msg {
+		path, err := config.SaveSettings(edits)
+		return settingsSavedMsg{path: path, err: err}
+	}
+}
+
+// settingsSaved reports where the settings went.
+func (m *AppModel) settingsSaved(msg settingsSavedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.notify("Could not save settings: " + msg.err.Error())
+	}
+	return m.notify("Settings saved to " + msg.path)
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/keys"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// startCommand is one on_start entry, e.g. "range 7D" or "log_scale".
+type startCommand struct {
+	verb string
+	arg  string
+}
+
+// parseOnStart checks the on_start commands against the config. Besides
+// the verbs taking an argument, any key action runs by its config name.
+func parseOnStart(lines []string, cfg *models.AppConfig, km keys.KeyMap) ([]startCommand, error) {
+	actions := km.Actions()
+	lists := cfg.Lists()
+	var out []startCommand
+	for _, line := range lines {
+		verb, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		c := startCommand{verb: strings.ToLower(verb), arg: strings.TrimSpace(arg)}
+		ok := true
+		switch c.verb {
+		case "select":
+			ok = slices.ContainsFunc(lists, func(l models.Watchlist) bool {
+				return slices.ContainsFunc(l.Symbols, func(s string) bool { return strings.EqualFold(s, c.arg) })
+			})
+			if !ok {
+				return nil, fmt.Errorf("on_start: %s is not in a watchlist", c.arg)
+			}
+		case "watchlist":
+			ok = slices.ContainsFunc(lists, func(l models.Watchlist) bool { return strings.EqualFold(l.Name, c.arg) })
+		case "layout":
+			ok = slices.ContainsFunc(cfg.Layouts, func(l models.Layout) bool { return strings.EqualFold(l.Name, c.arg) })
+		case "range":
+			_, ok = models.ParseTimeRange(c.arg)
+		case "interval":
+			_, ok = models.ParseInterval(c.arg)
+		case "tab":
+			_, ok = tabIndex(c.arg)
+		default:
+			if c.arg != "" || !slices.ContainsFunc(actions, func(a keys.Action) bool { return a.Name == c.verb }) {
+				return nil, fmt.Errorf("on_start: unknown command %q", line)
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("on_start: bad argument in %q", line)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// runOnStart applies the on_start commands in order, before the first
+// fetch. Selection, watchlist, range and interval only set state, which
+// the initial fetch then loads; the other commands keep their own loads.
+func (m *AppModel) runOnStart() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, c := range m.onStart {
+		switch c.verb {
+		case "select":
+			i := slices.IndexFunc(m.lists, func(l watchlistState) bool {
+				return slices.ContainsFunc(l.symbols, func(s string) bool { return strings.EqualFold(s, c.arg) })
+			})
+			sym := m.lists[i].symbols[slices.IndexFunc(m.lists[i].symbols, func(s string) bool { return strings.EqualFold(s, c.arg) })]
+			m.switchWatchlist(i)
+			m.watchlist.Select(sym)
+		case "watchlist":
+			m.switchWatchlist(slices.IndexFunc(m.lists, func(l watchlistState) bool { return strings.EqualFold(l.name, c.arg) }))
+		case "layout":
+			i := slices.IndexFunc(m.cfg.Layouts, func(l models.Layout) bool { return strings.EqualFold(l.Name, c.arg) })
+			cmds = append(cmds, m.applyLayout(m.cfg.Layouts[i]))
+		case "range":
+			tr, _ := models.ParseTimeRange(c.arg)
+			m.setTimeRange(tr)
+		case "interval":
+			iv, _ := models.ParseInterval(c.arg)
+			m.timeRange = m.timeRange.WithInterval(iv)
+			m.footer.SetTimeRange(m.timeRange)
+		case "tab":
+			i, _ := tabIndex(c.arg)
+			cmds = append(cmds, m.switchTab(i))
+		default:
+			for _, a := range m.keys.Actions() {
+				if a.Name == c.verb {
+					_, cmd := m.Update(a.Msg)
+					cmds = append(cmds, cmd)
+				}
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/config"
+)
+
+type stateSavedMsg struct {
+	err error
+}
+
+// saveState writes the read-only mode state file in the background. While
+// a write is in flight further changes only mark the state dirty, and one
+// more write follows when it lands, so writes never overlap.
+func (m *AppModel) saveState() tea.Cmd {
+	if !m.cfg.ReadOnly {
+		return nil
+	}
+	if m.stateSaving {
+		m.stateDirty = true
+		return nil
+	}
+	m.stateSaving = true
+	st := m.state.Clone()
+	return func() tea.Msg {
+		_, err := config.SaveState(st)
+		return stateSavedMsg{err: err}
+	}
+}
+
+// rememberWatchlist records the active list's symbols after an edit.
+func (m *AppModel) rememberWatchlist() tea.Cmd {
+	if !m.cfg.ReadOnly {
+		return nil
+	}
+	m.state.SetWatchlist(m.lists[m.list].name, m.lists[m.list].symbols)
+	return m.saveState()
+}
+
+// rememberAlerts records the alerts after one is set or cleared.
+func (m *AppModel) rememberAlerts() tea.Cmd {
+	if !m.cfg.ReadOnly {
+		return nil
+	}
+	m.state.SetAlerts(m.alerts.Rules())
+	return m.saveState()
+}
+
+// markTourSeen records that the tour was shown, so it opens only on the
+// first start. A failed write means it opens again next time.
+func markTourSeen() tea.Msg {
+	_ = config.MarkTourSeen()
+	return nil
+}
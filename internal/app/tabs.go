@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/keys"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Tabs of the right-hand pane, in bar order.
+const (
+	tabChart = iota
+	tabNews
+	tabDetails
+	tabOptions
+)
+
+// tabNames are the tabs' names in layouts, by index.
+var tabNames = []string{"chart", "news", "details", "options"}
+
+type optionsMsg struct {
+	symbol string
+	expiry time.Time // as asked for; zero for the nearest
+	chain  models.OptionChain
+	err    error
+}
+
+// optionsEntry is a fetched option chain and when it arrived.
+type optionsEntry struct {
+	chain models.OptionChain
+	at    time.Time
+}
+
+// chainKey keys the option chain cache: a symbol and the expiry asked for.
+type chainKey struct {
+	symbol string
+	expiry time.Time
+}
+
+// tabIndex returns the tab called name, or false for an unknown name.
+func tabIndex(name string) (int, bool) {
+	for i, n := range tabNames {
+		if strings.EqualFold(n, name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// switchTab shows tab i in the right-hand pane and loads its data for the
+// selected symbol. Tabs fetch only once shown; news always refetches on
+// the way in, like opening the old pane did.
+func (m *AppModel) switchTab(i int) tea.Cmd {
+	m.tabs.Set(i)
+	tab := m.tabs.Active()
+	m.news.SetVisible(tab == tabNews)
+	m.details.SetVisible(tab == tabDetails)
+	m.options.SetVisible(tab == tabOptions)
+	m.layout()
+	sel := m.watchlist.SelectedSymbol()
+	if tab == tabNews {
+		return tea.Batch(m.syncBook(), m.loadNews(sel, true))
+	}
+	return tea.Batch(m.syncBook(), m.loadTab(sel))
+}
+
+// toggleTab switches to tab i, or back to the chart when it is showing.
+func (m *AppModel) toggleTab(i int) tea.Cmd {
+	if m.tabs.Active() == i {
+		return m.switchTab(tabChart)
+	}
+	return m.switchTab(i)
+}
+
+// loadTab loads the shown tab's data for symbol. The chart tab loads with
+// the rest of the screen, so there is nothing to do for it.
+func (m *AppModel) loadTab(symbol string) tea.Cmd {
+	if symbol == "" {
+		return nil
+	}
+	switch m.tabs.Active() {
+	case tabNews:
+		return m.loadNews(symbol, false)
+	case tabDetails:
+		return m.loadDetails(symbol)
+	case tabOptions:
+		return m.loadOptions(symbol, m.options.ExpiryFor(symbol))
+	}
+	return nil
+}
+
+// loadOptions shows symbol's option chain for expiry (zero for the
+// nearest), fetching it only when the session has none younger than
+// data.OptionsTTL.
+func (m *AppModel) loadOptions(symbol string, expiry time.Time) tea.Cmd {
+	m.options.SetLoading(symbol, expiry)
+	if e, ok := m.chains[chainKey{symbol, expiry}]; ok {
+		m.options.SetChain(symbol, expiry, e.chain)
+		if time.Since(e.at) < data.OptionsTTL {
+			return nil
+		}
+	}
+	src := m.optionsSrc
+	return func() tea.Msg {
+		chain, err := src.GetOptionChain(symbol, expiry)
+		return optionsMsg{symbol: symbol, expiry: expiry, chain: chain, err: err}
+	}
+}
+
+// stepExpiry moves the options tab delta listed expiries on from the one
+// shown.
+func (m *AppModel) stepExpiry(delta int) tea.Cmd {
+	expiry, ok := m.options.StepExpiry(delta)
+	if !ok {
+		return nil
+	}
+	return m.loadOptions(m.watchlist.SelectedSymbol(), expiry)
+}
+
+func validateTab(name, layout string) error {
+	if name == "" {
+		return nil
+	}
+	if _, ok := tabIndex(name); !ok {
+		return fmt.Errorf("unknown tab %q in layout %q (use %s)", name, layout, strings.Join(tabNames, ", "))
+	}
+	return nil
+}
+
+// helpPage picks the help page for the focused pane: the watchlist's, the
+// chart's, or the tabs' for a tab other than the chart. The crosshair
+// takes the chart's keys wherever the focus is.
+func (m *AppModel) helpPage() string {
+	switch {
+	case m.focus == focusTab && m.tabs.Active() != tabChart:
+		return keys.PageTabs
+	case m.focus == focusTab || m.chart.Crosshair() && m.tabs.Active() == tabChart:
+		return keys.PageChart
+	}
+	return keys.PageWatchlist
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/config"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/watchlist"
+)
+
+// Picker tags identify which action a picker selection belongs to.
+const (
+	pickerWatchlist = "watchlist"
+	pickerLayout    = "layout"
+	pickerInterval  = "interval"
+)
+
+// watchlistState keeps a named list's own selection, sort and filter so
+// switching back restores it exactly. History is cached app-wide by symbol
+// and range, so a revisited list renders without refetching.
+//
+// Every list polls quotes on its own interval and provider, including
+// lists that are not on screen, so their alerts keep firing. Symbols
+// covered by refresh_rules poll on their own schedules instead.
+type watchlistState struct {
+	name      string
+	symbols   []string
+	model     watchlist.Model
+	provider  data.Provider
+	interval  time.Duration // for symbols without a refresh rule
+	schedules []schedule
+	fetched   bool // initial history batch has been requested
+	local     bool // provider makes no network calls, so needs no pacing
+	quoted    bool // initial quotes have been requested
+}
+
+// newWatchlistStates builds the list states. Lists without their own
+// provider use the default chain, and lists resolving to the same
+// providers share one instance. Every provider resolves symbol groups and
+// synthetic symbols, and network providers cache history on disk unless
+// NoCache is set.
+func newWatchlistStates(cfg *models.AppConfig, aliases models.Aliases) ([]watchlistState, error) {
+	cacheDir := ""
+	if !cfg.NoCache {
+		// Without a cache directory the app simply runs uncached
+		cacheDir, _ = config.CacheDir()
+	}
+
+	opts := providerOptions(cfg)
+	// Quotes another instance fetched stand in for a poll while younger
+	// than the shortest refresh interval, so an instance never reuses its
+	// own
+	shareQuotes := shortestRefresh(cfg) * 3 / 4
+	lists := cfg.Lists()
+	providers := make(map[string]data.Provider)
+	states := make([]watchlistState, len(lists))
+	for i, l := range lists {
+		names := cfg.ProviderChain()
+		if l.Provider != "" {
+			names = []string{l.Provider}
+		}
+		key := strings.Join(names, ",")
+		prov, ok := providers[key]
+		if !ok {
+			base := data.NewChain(names, opts)
+			if key != "simulator" {
+				// Extended-hours and coarse history differ, so they are
+				// cached apart
+				cacheKey := key
+				if opts.ExtendedHours {
+					cacheKey += "+ext"
+				}
+				if opts.LowBandwidth {
+					cacheKey += "+lite"
+				}
+				base = data.WithCache(base, cacheDir, cacheKey, shareQuotes)
+			}
+			var err error
+			prov, err = data.WithSynthetics(data.WithGroups(base, cfg.Groups), cfg.Synthetics)
+			if err != nil {
+				return nil, err
+			}
+			providers[key] = prov
+		}
+		wl := watchlist.New(l.Symbols)
+		wl.SetPinned(l.Pinned)
+		wl.SetAliases(aliases)
+		states[i] = watchlistState{
+			name:     l.Name,
+			symbols:  l.Symbols,
+			model:    wl,
+			provider: prov,
+			interval: l.RefreshInterval,
+			local:    key == "simulator",
+		}
+		if cfg.LowBandwidth && !states[i].local {
+			states[i].interval = max(states[i].interval, lowBandwidthRefresh)
+		}
+	}
+	return states, nil
+}
+
+// providerOptions returns the options providers are built with for cfg.
+func providerOptions(cfg *models.AppConfig) data.Options {
+	return data.Options{ExtendedHours: cfg.ExtendedHours, LowBandwidth: cfg.LowBandwidth, Generic: cfg.Generic, Plugin: cfg.Plugin, FinnhubKey: cfg.FinnhubAPIKey}
+}
+
+// startTickers starts every list's refresh schedules.
+func (m *AppModel) startTickers() tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range m.lists {
+		for _, every := range m.refresh.intervals(m.lists[i].interval) {
+			s := schedule{every: every, ticker: time.NewTicker(every), done: make(chan struct{})}
+			m.lists[i].schedules = append(m.lists[i].schedules, s)
+			cmds = append(cmds, m.waitForTick(i, len(m.lists[i].schedules)-1))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *AppModel) stopTickers() {
+	for i := range m.lists {
+		for _, s := range m.lists[i].schedules {
+			s.ticker.Stop()
+			close(s.done)
+		}
+		m.lists[i].schedules = nil
+	}
+}
+
+// restartTickers replaces every list's schedules after their intervals
+// change.
+func (m *AppModel) restartTickers() tea.Cmd {
+	m.stopTickers()
+	return m.startTickers()
+}
+
+func (m *AppModel) waitForTick(list, s int) tea.Cmd {
+	sch := m.lists[list].schedules[s]
+	return func() tea.Msg {
+		select {
+		case t := <-sch.ticker.C:
+			return tickMsg{list: list, schedule: s, at: t, done: sch.done}
+		case <-sch.done:
+			return nil
+		}
+	}
+}
+
+// liveTick reports whether msg comes from a schedule still running, not
+// one a restart replaced while its tick was on the way.
+func (m *AppModel) liveTick(msg tickMsg) bool {
+	schedules := m.lists[msg.list].schedules
+	return msg.schedule < len(schedules) && schedules[msg.schedule].done == msg.done
+}
+
+// listModel returns the watchlist model for list i, which is m.watchlist
+// for the active list.
+func (m *AppModel) listModel(i int) *watchlist.Model {
+	if i == m.list {
+		return &m.watchlist
+	}
+	return &m.lists[i].model
+}
+
+// setFlash toggles the alert highlight on symbol in every list holding it.
+func (m *AppModel) setFlash(symbol string, on bool) {
+	for i := range m.lists {
+		m.listModel(i).SetFlash(symbol, on)
+	}
+}
+
+// symbols returns the active watchlist's symbols.
+func (m *AppModel) symbols() []string {
+	return m.lists[m.list].symbols
+}
+
+// switchWatchlist activates list i (wrapping around), refreshing quotes and
+// loading the chart for its selection.
+func (m *AppModel) switchWatchlist(i int) tea.Cmd {
+	n := len(m.lists)
+	i = ((i % n) + n) % n
+	if i == m.list {
+		return nil
+	}
+
+	w, h := m.watchlist.Size()
+	m.lists[m.list].model = m.watchlist
+	m.list = i
+	m.watchlist = m.lists[i].model
+	m.watchlist.SetSize(w, h)
+	m.provider = m.lists[i].provider
+	m.footer.SetWatchlist(m.lists[i].name)
+	m.footer.SetProvider(m.provider.Name())
+	m.markStale(i)
+
+	// Lists already polled in the background are current as of their
+	// own interval, so only pull quotes for a list never fetched before
+	cmds := []tea.Cmd{m.loadCurrentChart(), m.syncBook()}
+	if !m.lists[i].quoted {
+		cmds = append(cmds, m.fetchQuotes(i))
+	}
+	if !m.lists[i].fetched {
+		cmds = append(cmds, m.fetchAllHistory())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *AppModel) openWatchlistPicker() {
+	names := make([]string, len(m.lists))
+	for i, l := range m.lists {
+		names[i] = l.name
+	}
+	m.picker.Open(pickerWatchlist, "Watchlists", names, m.list)
+}
+
+// validateSections checks that every watchlist section has a name of its
+// own.
+func validateSections(sections []models.Section) error {
+	seen := make(map[string]bool)
+	for _, s := range sections {
+		switch {
+		case s.Name == "":
+			return fmt.Errorf("sections: a section needs a name")
+		case seen[s.Name]:
+			return fmt.Errorf("sections: %q is defined twice", s.Name)
+		}
+		seen[s.Name] = true
+	}
+	return nil
+}
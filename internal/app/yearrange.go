@@ -0,0 +1,68 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ni5arga/stock-tui/internal/data"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// yearRangeMsg carries the fundamentals fetched for the watchlist's
+// 52-week badges; symbols that failed are missing.
+type yearRangeMsg struct {
+	fundamentals map[string]models.Fundamentals
+}
+
+// refreshYearRanges fetches fundamentals for the symbols in any watchlist
+// that have none younger than data.YearRangeTTL, one at a time in the
+// background. A symbol is tried once per TTL, whether or not it fails.
+func (m *AppModel) refreshYearRanges() tea.Cmd {
+	if m.yearPending {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, l := range m.lists {
+		for _, s := range l.symbols {
+			if time.Since(m.yearTried[s]) < data.YearRangeTTL {
+				continue
+			}
+			if e, ok := m.fundCache[s]; ok && time.Since(e.at) < data.YearRangeTTL {
+				continue
+			}
+			seen[s] = true
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+	symbols := slices.Sorted(maps.Keys(seen))
+	for _, s := range symbols {
+		m.yearTried[s] = time.Now()
+	}
+	m.yearPending = true
+	prov := m.provider
+	return func() tea.Msg {
+		out := make(map[string]models.Fundamentals, len(symbols))
+		for _, s := range symbols {
+			if f, err := prov.GetFundamentals(s); err == nil {
+				out[s] = f
+			}
+		}
+		return yearRangeMsg{fundamentals: out}
+	}
+}
+
+// applyYearRanges passes the cached fundamentals' 52-week ranges to every
+// list.
+func (m *AppModel) applyYearRanges() {
+	ranges := make(map[string]models.Fundamentals, len(m.fundCache))
+	for s, e := range m.fundCache {
+		ranges[s] = e.data
+	}
+	for i := range m.lists {
+		m.listModel(i).SetYearRanges(ranges)
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ni5arga/stock-tui/internal/alerts"
+)
+
+// AlertLogPath returns the file holding the log of fired alerts, beside
+// the state file.
+func AlertLogPath() (string, error) {
+	path, err := StatePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "alert_log.json"), nil
+}
+
+// LoadAlertLog reads the alert log, oldest first. A missing file is an
+// empty log.
+func LoadAlertLog() ([]alerts.LogEntry, error) {
+	path, err := AlertLogPath()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading alert log: %w", err)
+	}
+	var log []alerts.LogEntry
+	if err := json.Unmarshal(raw, &log); err != nil {
+		return nil, fmt.Errorf("unable to decode alert log: %w", err)
+	}
+	return log, nil
+}
+
+// SaveAlertLog writes the alert log, replacing it whole.
+func SaveAlertLog(log []alerts.LogEntry) error {
+	path, err := AlertLogPath()
+	if err != nil {
+		return err
+	}
+	return writeJSON(path, log)
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/alerts"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/paper"
+	"github.com/spf13/viper"
+)
+
+// Names of the files in an archive. The config holds the watchlists,
+// alerts, portfolio and layouts, and is archived as config.yaml or
+// config.json when written in those formats; the state file holds what
+// read-only mode saved over them, the notes file the symbol notes, the
+// paper file the paper-trading account and the alert log the alerts that
+// fired.
+const (
+	archiveConfig   = "config"
+	archiveState    = "state.toml"
+	archiveNotes    = "notes.json"
+	archivePaper    = "paper.json"
+	archiveAlertLog = "alert_log.json"
+)
+
+// archiveFile is a file archived beside the config, with where it lives,
+// the check its contents must pass before it replaces anything and the
+// mode it is written with when there is no file to take it from.
+type archiveFile struct {
+	name  string
+	path  func() (string, error)
+	check func(raw []byte) error
+	mode  os.FileMode
+}
+
+// archiveFiles are the files the app saves by itself that an archive
+// carries, in the order they are written.
+var archiveFiles = []archiveFile{
+	{archiveState, StatePath, func(raw []byte) error { return checkConfig(raw, "toml") }, 0o600},
+	{archiveNotes, NotesPath, func(raw []byte) error { return checkJSON(raw, &map[string]string{}) }, 0o644},
+	{archivePaper, PaperPath, func(raw []byte) error { return checkJSON(raw, &paper.Account{}) }, 0o644},
+	{archiveAlertLog, AlertLogPath, func(raw []byte) error { return checkJSON(raw, &[]alerts.LogEntry{}) }, 0o644},
+}
+
+// maxArchiveFile bounds each file read from an archive.
+const maxArchiveFile = 16 << 20
+
+// archiveName returns the name the config file at path is archived under.
+func archiveName(path string) string {
+	format, err := Format(path)
+	if err != nil {
+		format = "toml"
+	}
+	return archiveConfig + "." + format
+}
+
+// Export writes the config file, the state file, the notes, the paper
+// account and the alert log, those that exist, to w as a gzipped tar
+// archive. It returns the paths archived.
+func Export(w io.Writer) ([]string, error) {
+	cfg, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	files := []struct{ name, path string }{{archiveName(cfg), cfg}}
+	for _, f := range archiveFiles {
+		path, err := f.path()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, struct{ name, path string }{f.name, path})
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	var done []string
+	for _, f := range files {
+		info, err := os.Stat(f.path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		raw, err := os.ReadFile(f.path)
+		if err != nil {
+			return nil, err
+		}
+		hdr := &tar.Header{Name: f.name, Mode: int64(info.Mode().Perm()), Size: int64(len(raw)), ModTime: time.Now()}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(raw); err != nil {
+			return nil, err
+		}
+		done = append(done, f.path)
+	}
+	if len(done) == 0 {
+		return nil, errors.New("no config or state file to export")
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return done, gz.Close()
+}
+
+// Import restores an archive written by Export. Every file is read and
+// checked before any is written, so a damaged archive changes nothing.
+// Each file replaced is kept beside the new one with a .bak suffix; a
+// config in another format than the one in use is written beside it, and
+// the old one moved aside so the new one is found. It returns the paths
+// written.
+func Import(r io.Reader) ([]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a stock-tui archive: %w", err)
+	}
+	var cfgName string
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a stock-tui archive: %w", err)
+		}
+		var check func([]byte) error
+		if i := slices.IndexFunc(archiveFiles, func(f archiveFile) bool { return f.name == hdr.Name }); i >= 0 {
+			check = archiveFiles[i].check
+		} else if strings.HasPrefix(hdr.Name, archiveConfig+".") && cfgName == "" {
+			format, err := Format(hdr.Name)
+			if err != nil {
+				return nil, fmt.Errorf("%s in archive: %w", hdr.Name, err)
+			}
+			check = func(raw []byte) error { return checkConfig(raw, format) }
+			cfgName = hdr.Name
+		} else {
+			return nil, fmt.Errorf("unexpected file %q in archive", hdr.Name)
+		}
+		if hdr.Size > maxArchiveFile {
+			return nil, fmt.Errorf("%s in archive is too large", hdr.Name)
+		}
+		raw, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if err := check(raw); err != nil {
+			return nil, fmt.Errorf("%s in archive: %w", hdr.Name, err)
+		}
+		files[hdr.Name] = raw
+	}
+	if len(files) == 0 {
+		return nil, errors.New("archive is empty")
+	}
+
+	var done []string
+	if raw, ok := files[cfgName]; ok {
+		cfg, err := Path()
+		if err != nil {
+			return nil, err
+		}
+		path := cfg
+		if archiveName(cfg) != cfgName {
+			path = filepath.Join(filepath.Dir(cfg), cfgName)
+		}
+		// The config may hold API keys, so it keeps the mode of the one
+		// it replaces and is private otherwise
+		if err := replaceFile(path, raw, fileMode(cfg, 0o600)); err != nil {
+			return nil, err
+		}
+		done = append(done, path)
+		if path != cfg {
+			if err := os.Rename(cfg, cfg+".bak"); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return done, err
+			}
+		}
+	}
+	for _, f := range archiveFiles {
+		raw, ok := files[f.name]
+		if !ok {
+			continue
+		}
+		path, err := f.path()
+		if err != nil {
+			return done, err
+		}
+		if err := replaceFile(path, raw, f.mode); err != nil {
+			return done, err
+		}
+		done = append(done, path)
+	}
+	return done, nil
+}
+
+// checkConfig reports a file that does not decode as a config in format.
+func checkConfig(raw []byte, format string) error {
+	v := viper.New()
+	v.SetConfigType(format)
+	if err := v.ReadConfig(bytes.NewReader(raw)); err != nil {
+		return err
+	}
+	var cfg models.AppConfig
+	return v.Unmarshal(&cfg)
+}
+
+// checkJSON reports a file that does not decode into v.
+func checkJSON(raw []byte, v any) error {
+	return json.Unmarshal(raw, v)
+}
+
+// fileMode returns the permissions of the file at path, or fallback when
+// there is none.
+func fileMode(path string, fallback os.FileMode) os.FileMode {
+	if info, err := os.Stat(path); err == nil {
+		return info.Mode().Perm()
+	}
+	return fallback
+}
+
+// replaceFile writes raw to path, moving any file there to path.bak. The
+// new file keeps the permissions of the one it replaces, or gets mode
+// when there was none.
+func replaceFile(path string, raw []byte, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	mode = fileMode(path, mode)
+	tmp := path + ".new"
+	if err := os.WriteFile(tmp, raw, mode); err != nil {
+		return err
+	}
+	// WriteFile leaves the mode of a leftover file alone, and the umask
+	// may have narrowed it
+	if err := os.Chmod(tmp, mode); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, path+".bak"); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	return os.Rename(tmp, path)
+}
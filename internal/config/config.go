@@ -4,27 +4,69 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/ni5arga/stock-tui/internal/models"
 	"github.com/spf13/viper"
 )
 
-func Load(customPath string) (*models.AppConfig, error) {
+// configExts are the config file extensions, in the order a directory is
+// searched for one.
+var configExts = []string{"toml", "yaml", "yml", "json"}
+
+// Format returns the format of the config file at path from its
+// extension: toml, yaml or json. A file without one is taken for TOML.
+func Format(path string) (string, error) {
+	switch ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")); ext {
+	case "", "toml":
+		return "toml", nil
+	case "yaml", "yml":
+		return "yaml", nil
+	case "json":
+		return "json", nil
+	default:
+		return "", fmt.Errorf("unsupported config format %q (use .toml, .yaml or .json)", ext)
+	}
+}
+
+// Find returns the config file Load reads without a path: config.toml,
+// .yaml, .yml or .json in the current directory, then in the config
+// directory. It returns "" when there is none.
+func Find() string {
+	for _, dir := range append([]string{"."}, configDirs()...) {
+		for _, ext := range configExts {
+			path := filepath.Join(dir, "config."+ext)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// Locate returns the config file Load reads: customPath when given, then
+// $STOCK_TUI_CONFIG, then the file Find finds. It returns "" when there
+// is none.
+func Locate(customPath string) string {
 	if customPath != "" {
-		viper.SetConfigFile(customPath)
-	} else if envPath := os.Getenv("STOCK_TUI_CONFIG"); envPath != "" {
-		viper.SetConfigFile(envPath)
-	} else {
-		viper.SetConfigName("config")
-		viper.SetConfigType("toml")
-		viper.AddConfigPath(".")
-
-		// XDG / Standard paths
-		configDir, err := os.UserConfigDir()
-		if err == nil {
-			viper.AddConfigPath(filepath.Join(configDir, "stock-tui"))
+		return customPath
+	}
+	if path := os.Getenv("STOCK_TUI_CONFIG"); path != "" {
+		return path
+	}
+	return Find()
+}
+
+func Load(customPath string) (*models.AppConfig, error) {
+	path := Locate(customPath)
+	if path != "" {
+		format, err := Format(path)
+		if err != nil {
+			return nil, err
 		}
+		viper.SetConfigFile(path)
+		viper.SetConfigType(format)
 	}
 
 	// Defaults
@@ -32,14 +74,55 @@ func Load(customPath string) (*models.AppConfig, error) {
 	viper.SetDefault("refresh_interval", "5s")
 	viper.SetDefault("provider", "simulator")
 	viper.SetDefault("default_range", "24H")
+	viper.SetDefault("notifications", true)
+	viper.SetDefault("alert_log_max", 500)
+	viper.SetDefault("restore_session", true)
+	viper.SetDefault("chart_resolution", "normal")
+	viper.SetDefault("chart_scale", "linear")
+	viper.SetDefault("theme", "dark")
+	viper.SetDefault("pane_layout", "auto")
+	viper.SetDefault("copy_format", "markdown")
+	viper.SetDefault("export.format", "csv")
+	viper.SetDefault("export.watchlist_format", "csv")
+	viper.SetDefault("ticker.speed", "200ms")
+	viper.SetDefault("ticker.fields", []string{"symbol", "price", "change"})
+	viper.SetDefault("show_volume", true)
+	viper.SetDefault("chart_grid_rows", 5)
+	viper.SetDefault("previous_close_line", true)
+	viper.SetDefault("portfolio.benchmark", "^GSPC")
+	viper.SetDefault("portfolio.paper_cash", 100000.0)
+	viper.SetDefault("volume_min_height", 20)
+	viper.SetDefault("indicators.sma_fast", 20)
+	viper.SetDefault("indicators.sma_slow", 50)
+	viper.SetDefault("indicators.ema", 21)
+	viper.SetDefault("indicators.bollinger_period", 20)
+	viper.SetDefault("indicators.bollinger_stddev", 2.0)
+	viper.SetDefault("indicators.rsi_period", 14)
+	viper.SetDefault("indicators.rsi_overbought", 70.0)
+	viper.SetDefault("indicators.rsi_oversold", 30.0)
+	viper.SetDefault("indicators.macd_fast", 12)
+	viper.SetDefault("indicators.macd_slow", 26)
+	viper.SetDefault("indicators.macd_signal", 9)
 
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+	// Without a config file the defaults are used
+	if path != "" {
+		if err := viper.ReadInConfig(); err != nil {
 			return nil, fmt.Errorf("error reading config: %w", err)
 		}
-		// Config not found is fine, we use defaults
 	}
+	return decode()
+}
 
+// Reload reads the config file Load found again, for a running app to
+// pick up edits to it.
+func Reload() (*models.AppConfig, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+	return decode()
+}
+
+func decode() (*models.AppConfig, error) {
 	var cfg models.AppConfig
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("unable to decode config: %w", err)
@@ -49,6 +132,13 @@ func Load(customPath string) (*models.AppConfig, error) {
 	if cfg.RefreshInterval < time.Second {
 		cfg.RefreshInterval = time.Second
 	}
+	// The app edits only TOML in place, so changes made in it to a YAML or
+	// JSON config go to the state file as in read-only mode
+	if path := viper.ConfigFileUsed(); path != "" {
+		if format, _ := Format(path); format != "toml" {
+			cfg.ReadOnly = true
+		}
+	}
 
 	return &cfg, nil
 }
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/spf13/viper"
+)
+
+var entryName = regexp.MustCompile(`^\s*name\s*=\s*("(?:[^"\\]|\\.)*")`)
+
+// Path returns the config file in use, or the default location in the
+// config directory when the app started without one.
+func Path() (string, error) {
+	if p := viper.ConfigFileUsed(); p != "" {
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return DefaultPath()
+}
+
+// DefaultPath returns config.toml in the config directory, where a new
+// config goes.
+func DefaultPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.toml"), nil
+}
+
+// SaveLayout writes l to the config file as a [[layouts]] entry, replacing
+// an entry with the same name. The file is edited as text rather than
+// re-encoded so its comments and ordering survive. It returns the path
+// written.
+func SaveLayout(l models.Layout) (string, error) {
+	entry := []string{"[[layouts]]",
+		fmt.Sprintf("name = %q", l.Name),
+		fmt.Sprintf("watchlist = %q", l.Watchlist),
+		fmt.Sprintf("view = %q", l.View),
+		fmt.Sprintf("tab = %q", l.Tab)}
+	if l.WatchlistWidth > 0 {
+		entry = append(entry, fmt.Sprintf("watchlist_width = %d", l.WatchlistWidth))
+	}
+
+	return editConfig(func(lines []string) []string {
+		if start, end, ok := findEntry(lines, "[[layouts]]", l.Name); ok {
+			return slices.Replace(lines, start, end, entry...)
+		}
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		return append(append(lines, entry...), "")
+	})
+}
+
+// SaveWatchlistWidth sets the top-level watchlist_width in the config
+// file, editing it as text like SaveLayout. It returns the path written.
+func SaveWatchlistWidth(pct int) (string, error) {
+	return editConfig(func(lines []string) []string {
+		return setTopLevel(lines, "watchlist_width", strconv.Itoa(pct))
+	})
+}
+
+// editConfig rewrites the config file's lines with edit, creating the
+// file when there is none, and returns its path.
+func editConfig(edit func([]string) []string) (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	lines := edit(strings.Split(string(raw), "\n"))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// findEntry returns the line span [start, end) of the entry in the array
+// of tables header, such as [[layouts]], called name. An entry runs from
+// its header to the next table header; comments and blank lines just
+// before that header belong to the next table and are left out.
+func findEntry(lines []string, header, name string) (int, int, bool) {
+	for start := 0; start < len(lines); start++ {
+		if strings.TrimSpace(lines[start]) != header {
+			continue
+		}
+		end := start + 1
+		last := start
+		match := false
+		for ; end < len(lines); end++ {
+			t := strings.TrimSpace(lines[end])
+			if strings.HasPrefix(t, "[") {
+				break
+			}
+			if t != "" && !strings.HasPrefix(t, "#") {
+				last = end
+			}
+			if m := entryName.FindStringSubmatch(lines[end]); m != nil {
+				v, err := strconv.Unquote(m[1])
+				match = err == nil && v == name
+			}
+		}
+		if match {
+			return start, last + 1, true
+		}
+	}
+	return 0, 0, false
+}
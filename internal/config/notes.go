@@ -0,0 +1,68 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// NotesPath returns the file holding the per-symbol notes. It sits beside
+// the state file. The notes are JSON rather than TOML since symbols such
+// as BRK.B or ^GSPC do not survive as TOML keys.
+func NotesPath() (string, error) {
+	path, err := StatePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "notes.json"), nil
+}
+
+// LoadNotes reads the notes by symbol. A missing file is no notes.
+func LoadNotes() (map[string]string, error) {
+	notes := make(map[string]string)
+	path, err := NotesPath()
+	if err != nil {
+		return notes, err
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return notes, nil
+	}
+	if err != nil {
+		return notes, fmt.Errorf("error reading notes: %w", err)
+	}
+	if err := json.Unmarshal(raw, &notes); err != nil {
+		return notes, fmt.Errorf("unable to decode notes: %w", err)
+	}
+	return notes, nil
+}
+
+// SaveNotes writes the notes file, replacing it whole.
+func SaveNotes(notes map[string]string) error {
+	path, err := NotesPath()
+	if err != nil {
+		return err
+	}
+	return writeJSON(path, notes)
+}
+
+// writeJSON writes v to path as indented JSON, creating its directory.
+func writeJSON(path string, v any) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write beside the file and rename so a crash never loses what was
+	// there
+	tmp := path + ".new"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ni5arga/stock-tui/internal/paper"
+)
+
+// PaperPath returns the file holding the paper-trading account, beside
+// the state file.
+func PaperPath() (string, error) {
+	path, err := StatePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "paper.json"), nil
+}
+
+// LoadPaper reads the paper-trading account. A missing file is a new
+// account holding cash.
+func LoadPaper(cash float64) (*paper.Account, error) {
+	path, err := PaperPath()
+	if err != nil {
+		return paper.New(cash), err
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return paper.New(cash), nil
+	}
+	if err != nil {
+		return paper.New(cash), fmt.Errorf("error reading paper account: %w", err)
+	}
+	acct := paper.New(cash)
+	if err := json.Unmarshal(raw, acct); err != nil {
+		return paper.New(cash), fmt.Errorf("unable to decode paper account: %w", err)
+	}
+	return acct, nil
+}
+
+// SavePaper writes the paper-trading account, replacing it whole.
+func SavePaper(acct paper.Account) error {
+	path, err := PaperPath()
+	if err != nil {
+		return err
+	}
+	return writeJSON(path, acct)
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// The app keeps its files in three directories: the config directory,
+// read for config files and written only where the user asks; the state
+// directory, for what the app saves by itself; and the cache directory,
+// for what it can fetch again. Each follows its XDG variable when set, on
+// any platform, and the platform's own place otherwise.
+
+// ConfigDir returns the stock-tui directory under $XDG_CONFIG_HOME, or
+// under the user config directory when that is unset: ~/.config on Linux,
+// ~/Library/Application Support on macOS and %AppData% on Windows.
+func ConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "stock-tui"), nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "stock-tui"), nil
+}
+
+// configDirs returns the directories searched for a config file after the
+// current one. On macOS ~/.config/stock-tui is searched too, where many
+// set up their command-line tools.
+func configDirs() []string {
+	var dirs []string
+	if dir, err := ConfigDir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	if runtime.GOOS == "darwin" && os.Getenv("XDG_CONFIG_HOME") == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dirs = append(dirs, filepath.Join(home, ".config", "stock-tui"))
+		}
+	}
+	return dirs
+}
+
+// StateDir returns the directory for the state file and the other files
+// the app saves by itself: stock-tui under $XDG_STATE_HOME, or when that
+// is unset ~/.local/state/stock-tui on Linux, stock-tui/state under
+// ~/Library/Application Support on macOS and under %LocalAppData% on
+// Windows. Earlier versions used ~/.local/state/stock-tui everywhere, so
+// on macOS and Windows that is kept while it exists and the new place
+// does not.
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "stock-tui"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	legacy := filepath.Join(home, ".local", "state", "stock-tui")
+	var dir string
+	switch runtime.GOOS {
+	case "darwin", "ios":
+		base, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(base, "stock-tui", "state")
+	case "windows":
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(base, "stock-tui", "state")
+	default:
+		return legacy, nil
+	}
+	if !exists(dir) && exists(legacy) {
+		return legacy, nil
+	}
+	return dir, nil
+}
+
+// CacheDir returns the history and quote cache directory: stock-tui under
+// $XDG_CACHE_HOME, or under the user cache directory when that is unset:
+// ~/.cache on Linux and ~/Library/Caches on macOS. On Windows that is
+// %LocalAppData%, where state goes too, so the cache is stock-tui\cache.
+func CacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "stock-tui"), nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(dir, "stock-tui", "cache"), nil
+	}
+	return filepath.Join(dir, "stock-tui"), nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
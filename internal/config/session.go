@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// Session is the screen as it was left on quit, restored on the next
+// start unless it is skipped with --fresh.
+type Session struct {
+	Watchlist string   `mapstructure:"watchlist"`
+	Symbol    string   `mapstructure:"symbol"`
+	Range     string   `mapstructure:"range"` // with its candle interval, e.g. 7D/1h
+	ChartType string   `mapstructure:"chart_type"`
+	Sort      string   `mapstructure:"sort"`
+	SortAsc   bool     `mapstructure:"sort_asc"`
+	Collapsed []string `mapstructure:"collapsed"` // folded section names
+}
+
+// SessionPath returns the session file. It sits beside the state file.
+func SessionPath() (string, error) {
+	path, err := StatePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "session.toml"), nil
+}
+
+// LoadSession reads the session file. A missing file is an empty session.
+func LoadSession() (Session, error) {
+	var s Session
+	path, err := SessionPath()
+	if err != nil {
+		return s, err
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("toml")
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+		return s, fmt.Errorf("error reading session: %w", err)
+	}
+	if err := v.Unmarshal(&s); err != nil {
+		return s, fmt.Errorf("unable to decode session: %w", err)
+	}
+	return s, nil
+}
+
+// SaveSession writes the session file, replacing it whole.
+func SaveSession(s Session) error {
+	path, err := SessionPath()
+	if err != nil {
+		return err
+	}
+	v := viper.New()
+	v.SetConfigType("toml")
+	v.Set("watchlist", s.Watchlist)
+	v.Set("symbol", s.Symbol)
+	v.Set("range", s.Range)
+	v.Set("chart_type", s.ChartType)
+	v.Set("sort", s.Sort)
+	v.Set("sort_asc", s.SortAsc)
+	v.Set("collapsed", s.Collapsed)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := filepath.Join(filepath.Dir(path), ".session-new.toml") // viper goes by the extension
+	if err := v.WriteConfigAs(tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
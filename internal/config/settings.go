@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SaveSettings sets top-level settings in the config file, editing it as
+// text like SaveLayout, in key order. Values are strings, booleans or
+// numbers. It returns the path written.
+func SaveSettings(settings map[string]any) (string, error) {
+	return editConfig(func(lines []string) []string {
+		for _, key := range slices.Sorted(maps.Keys(settings)) {
+			lines = setTopLevel(lines, key, tomlValue(settings[key]))
+		}
+		return lines
+	})
+}
+
+// tomlValue writes v as a TOML value.
+func tomlValue(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+// setTopLevel sets key to value, as written in TOML, replacing its line
+// when the key is set already and otherwise adding one after the other
+// top-level keys.
+func setTopLevel(lines []string, key, value string) []string {
+	line := key + " = " + value
+	assign := regexp.MustCompile(`^\s*` + regexp.QuoteMeta(key) + `\s*=`)
+	// Top-level keys come before the first table header
+	top := slices.IndexFunc(lines, func(l string) bool { return strings.HasPrefix(strings.TrimSpace(l), "[") })
+	if top < 0 {
+		top = len(lines)
+	}
+	if i := slices.IndexFunc(lines[:top], assign.MatchString); i >= 0 {
+		lines[i] = line
+		return lines
+	}
+	// Comments just before the header belong to its table
+	at := top
+	for at > 0 {
+		if t := strings.TrimSpace(lines[at-1]); t != "" && !strings.HasPrefix(t, "#") {
+			break
+		}
+		at--
+	}
+	if at == len(lines) {
+		return append(lines, line, "")
+	}
+	if strings.TrimSpace(lines[at]) != "" {
+		return slices.Insert(lines, at, line, "")
+	}
+	return slices.Insert(lines, at, line)
+}
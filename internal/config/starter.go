@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Starter is the answers to `stock-tui config init`, for a first config.
+type Starter struct {
+	Provider        string
+	FinnhubKey      string // only for the finnhub provider
+	Symbols         []string
+	RefreshInterval string // as entered, e.g. "5s"
+}
+
+// WriteStarter writes a commented config with s's answers to path, which
+// must not exist yet unless overwrite. A config holding an API key is
+// readable by its owner only.
+func WriteStarter(path string, s Starter, overwrite bool) error {
+	if format, err := Format(path); err != nil || format != "toml" {
+		return errors.New("config init writes TOML; use a .toml file")
+	}
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return fmt.Errorf("%s already exists", path)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	mode := os.FileMode(0o644)
+	if s.FinnhubKey != "" {
+		mode = 0o600
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".new"
+	if err := os.WriteFile(tmp, []byte(s.render()), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// render lays out the config: the answers, each with what it does, then
+// the most used settings commented out at their defaults.
+func (s Starter) render() string {
+	quoted := make([]string, len(s.Symbols))
+	for i, sym := range s.Symbols {
+		quoted[i] = fmt.Sprintf("%q", sym)
+	}
+
+	var b strings.Builder
+	b.WriteString(`# stock-tui configuration, written by ` + "`stock-tui config init`" + `.
+# Every setting is described in the sample config.toml in the repository
+# and in the README. Check edits with ` + "`stock-tui config validate`" + `.
+
+# Data provider: "multi" (crypto and stocks), "yahoo", "coingecko",
+# "binance", "finnhub", "stooq", "simulator", "generic" or "plugin".
+# providers = [...] instead sets a failover chain, e.g. ["yahoo", "stooq"].
+`)
+	fmt.Fprintf(&b, "provider = %q\n", s.Provider)
+	if s.Provider == "finnhub" {
+		b.WriteString("\n# Finnhub API key; FINNHUB_API_KEY in the environment takes precedence.\n")
+		if s.FinnhubKey != "" {
+			fmt.Fprintf(&b, "finnhub_api_key = %q\n", s.FinnhubKey)
+		} else {
+			b.WriteString("# finnhub_api_key = \"\"\n")
+		}
+	}
+	fmt.Fprintf(&b, `
+# Symbols to watch. Several named lists can replace this one; add a
+# [[watchlists]] table per list with a name and its symbols.
+symbols = [%s]
+
+# How often to refresh prices
+refresh_interval = %q
+
+# Chart range at start: "1H", "24H", "7D", "30D", "90D", "YTD", "1Y" or "5Y"
+# default_range = "24H"
+
+# Color theme: "dark", "light", "solarized", "gruvbox" or "nord"
+# theme = "dark"
+
+# Desktop notifications for price alerts (set in-app with A)
+# notifications = true
+`, strings.Join(quoted, ", "), s.RefreshInterval)
+	return b.String()
+}
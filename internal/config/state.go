@@ -0,0 +1,198 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/spf13/viper"
+)
+
+// State is what the app saves by itself in read-only mode, where the
+// config file is never written: saved layouts, edited watchlists, the
+// alerts set in-app and settings changed on the settings screen. It lives
+// in its own file and overrides the config on the next start.
+type State struct {
+	Layouts    []models.Layout    `mapstructure:"layouts"`
+	Watchlists []models.Watchlist `mapstructure:"watchlists"` // name and symbols only
+	Alerts     []models.AlertRule `mapstructure:"alerts"`
+	// Watchlist pane width in percent, as last resized; 0 when never
+	WatchlistWidth int `mapstructure:"watchlist_width"`
+	// Top-level settings by config key, as the config would have them
+	Settings map[string]any `mapstructure:"settings"`
+
+	hasAlerts bool // alerts were saved, possibly as none
+}
+
+// StatePath returns the state file location: state.toml in the state
+// directory.
+func StatePath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.toml"), nil
+}
+
+// LoadState reads the state file. A missing file is an empty state.
+func LoadState() (State, error) {
+	var st State
+	path, err := StatePath()
+	if err != nil {
+		return st, err
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("toml")
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return st, nil
+		}
+		return st, fmt.Errorf("error reading state: %w", err)
+	}
+	if err := v.Unmarshal(&st); err != nil {
+		return st, fmt.Errorf("unable to decode state: %w", err)
+	}
+	st.hasAlerts = v.IsSet("alerts")
+	return st, nil
+}
+
+// Apply overrides cfg with the saved state. Saved layouts replace config
+// layouts of the same name; saved watchlists replace the symbols of the
+// list with their name, and are dropped when the config no longer has it.
+func (s State) Apply(cfg *models.AppConfig) {
+	for _, l := range s.Layouts {
+		i := slices.IndexFunc(cfg.Layouts, func(c models.Layout) bool { return c.Name == l.Name })
+		if i >= 0 {
+			cfg.Layouts[i] = l
+		} else {
+			cfg.Layouts = append(cfg.Layouts, l)
+		}
+	}
+	for _, wl := range s.Watchlists {
+		if i := slices.IndexFunc(cfg.Watchlists, func(c models.Watchlist) bool { return c.Name == wl.Name }); i >= 0 {
+			cfg.Watchlists[i].Symbols = wl.Symbols
+		} else if len(cfg.Watchlists) == 0 && cfg.Lists()[0].Name == wl.Name {
+			// The single list built from symbols
+			cfg.Symbols = wl.Symbols
+		}
+	}
+	if s.hasAlerts {
+		cfg.Alerts = s.Alerts
+	}
+	if s.WatchlistWidth > 0 {
+		cfg.WatchlistWidth = s.WatchlistWidth
+	}
+	if len(s.Settings) > 0 {
+		// Decoded as the config is, so only the keys saved change; the
+		// app wrote them, so a bad one is simply skipped
+		v := viper.New()
+		for k, val := range s.Settings {
+			v.Set(k, val)
+		}
+		_ = v.Unmarshal(cfg)
+	}
+}
+
+// SetLayout records l in place of a saved layout with the same name.
+func (s *State) SetLayout(l models.Layout) {
+	if i := slices.IndexFunc(s.Layouts, func(c models.Layout) bool { return c.Name == l.Name }); i >= 0 {
+		s.Layouts[i] = l
+		return
+	}
+	s.Layouts = append(s.Layouts, l)
+}
+
+// SetWatchlist records the symbols of the watchlist called name.
+func (s *State) SetWatchlist(name string, symbols []string) {
+	wl := models.Watchlist{Name: name, Symbols: slices.Clone(symbols)}
+	if i := slices.IndexFunc(s.Watchlists, func(c models.Watchlist) bool { return c.Name == name }); i >= 0 {
+		s.Watchlists[i] = wl
+		return
+	}
+	s.Watchlists = append(s.Watchlists, wl)
+}
+
+// SetAlerts records the full set of alerts.
+func (s *State) SetAlerts(rules []models.AlertRule) {
+	s.Alerts = rules
+	s.hasAlerts = true
+}
+
+// SetWatchlistWidth records the watchlist pane's width in percent.
+func (s *State) SetWatchlistWidth(pct int) {
+	s.WatchlistWidth = pct
+}
+
+// SetSetting records a top-level setting under its config key.
+func (s *State) SetSetting(key string, v any) {
+	if s.Settings == nil {
+		s.Settings = make(map[string]any)
+	}
+	s.Settings[key] = v
+}
+
+// Clone returns a copy that later Set calls leave untouched, for saving in
+// the background.
+func (s State) Clone() State {
+	s.Layouts = slices.Clone(s.Layouts)
+	s.Watchlists = slices.Clone(s.Watchlists)
+	s.Alerts = slices.Clone(s.Alerts)
+	s.Settings = maps.Clone(s.Settings)
+	return s
+}
+
+// SaveState writes the state file, replacing it whole, and returns its
+// path.
+func SaveState(s State) (string, error) {
+	path, err := StatePath()
+	if err != nil {
+		return "", err
+	}
+	v := viper.New()
+	v.SetConfigType("toml")
+	layouts := make([]map[string]any, len(s.Layouts))
+	for i, l := range s.Layouts {
+		layouts[i] = map[string]any{
+			"name": l.Name, "watchlist": l.Watchlist, "view": l.View,
+			"tab": l.Tab, "watchlist_width": l.WatchlistWidth,
+		}
+	}
+	lists := make([]map[string]any, len(s.Watchlists))
+	for i, wl := range s.Watchlists {
+		lists[i] = map[string]any{"name": wl.Name, "symbols": wl.Symbols}
+	}
+	v.Set("layouts", layouts)
+	v.Set("watchlists", lists)
+	if s.hasAlerts {
+		alerts := make([]map[string]any, len(s.Alerts))
+		for i, a := range s.Alerts {
+			alerts[i] = map[string]any{"symbol": a.Symbol, "above": a.Above, "below": a.Below, "move_pct": a.MovePct}
+		}
+		v.Set("alerts", alerts)
+	}
+	if s.WatchlistWidth > 0 {
+		v.Set("watchlist_width", s.WatchlistWidth)
+	}
+	if len(s.Settings) > 0 {
+		v.Set("settings", s.Settings)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	// Write beside the file and rename so a crash never leaves half a state
+	tmp := filepath.Join(filepath.Dir(path), ".state-new.toml") // viper goes by the extension
+	if err := v.WriteConfigAs(tmp); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
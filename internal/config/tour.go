@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// tourMarker returns the file recording that the onboarding tour was
+// shown. It sits beside the state file.
+func tourMarker() (string, error) {
+	path, err := StatePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "toured"), nil
+}
+
+// TourSeen reports whether the onboarding tour has been shown before.
+// Without a state directory it reports true, so the tour does not open on
+// every start.
+func TourSeen() bool {
+	path, err := tourMarker()
+	if err != nil {
+		return true
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// MarkTourSeen records that the onboarding tour was shown.
+func MarkTourSeen() error {
+	path, err := tourMarker()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, nil, 0o644)
+}
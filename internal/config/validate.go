@@ -0,0 +1,397 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+// Problem is something wrong in a config file, at the line and column it
+// was found when known.
+type Problem struct {
+	Line    int // from 1; 0 when not known
+	Column  int
+	Key     string // dotted path such as watchlists[0].name; "" for syntax errors
+	Message string
+}
+
+// String formats p after the file name, as "12:3: message".
+func (p Problem) String() string {
+	switch {
+	case p.Line > 0 && p.Column > 0:
+		return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+	case p.Line > 0:
+		return fmt.Sprintf("%d: %s", p.Line, p.Message)
+	}
+	return p.Message
+}
+
+// position is where a key starts in a config file.
+type position struct {
+	line, column int
+}
+
+var (
+	yamlLine    = regexp.MustCompile(`line (\d+)`)
+	decodeError = regexp.MustCompile(`^'([^']*)' (.+)$`)
+)
+
+// Validate checks the config file at path: that it parses in the format
+// its extension names, that every key is one the app knows and that each
+// value decodes to its setting's type. Problems are returned in file
+// order. The error is for a file that cannot be read.
+func Validate(path string) ([]Problem, error) {
+	format, err := Format(path)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	var pos map[string]position
+	switch format {
+	case "yaml":
+		doc, pos, err = parseYAML(raw)
+	case "json":
+		doc, pos, err = parseJSON(raw)
+	default:
+		doc, pos, err = parseTOML(raw)
+	}
+	if err != nil {
+		return []Problem{syntaxProblem(err, raw)}, nil
+	}
+
+	var problems []Problem
+	at := func(key, msg string) {
+		p := pos[strings.ToLower(key)]
+		problems = append(problems, Problem{Line: p.line, Column: p.column, Key: key, Message: msg})
+	}
+	checkKeys(reflect.TypeFor[models.AppConfig](), doc, "", func(key string) {
+		at(key, fmt.Sprintf("unknown key %q", key))
+	})
+
+	v := viper.New()
+	v.SetConfigType(format)
+	if err := v.ReadConfig(bytes.NewReader(raw)); err != nil {
+		return []Problem{syntaxProblem(err, raw)}, nil
+	}
+	var cfg models.AppConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		// Each failed setting is a line of its own: 'key' message
+		for _, line := range strings.Split(err.Error(), "\n") {
+			if m := decodeError.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				at(m[1], m[1]+": "+m[2])
+			}
+		}
+		if len(problems) == 0 {
+			problems = append(problems, Problem{Message: err.Error()})
+		}
+	}
+
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+	return problems, nil
+}
+
+// syntaxProblem places a parse error at its line where the parser gave
+// one.
+func syntaxProblem(err error, raw []byte) Problem {
+	p := Problem{Message: err.Error()}
+	var tomlErr *toml.DecodeError
+	var jsonErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &tomlErr):
+		p.Line, p.Column = tomlErr.Position()
+		p.Message = strings.TrimPrefix(tomlErr.Error(), "toml: ")
+	case errors.As(err, &jsonErr):
+		p.Line, p.Column = lineColumn(raw, int(jsonErr.Offset))
+	case errors.As(err, &typeErr):
+		p.Line, p.Column = lineColumn(raw, int(typeErr.Offset))
+	default:
+		if m := yamlLine.FindStringSubmatch(err.Error()); m != nil {
+			p.Line, _ = strconv.Atoi(m[1])
+		}
+	}
+	return p
+}
+
+// lineColumn converts a byte offset in raw to a line and column from 1.
+func lineColumn(raw []byte, offset int) (int, int) {
+	offset = min(max(offset, 0), len(raw))
+	before := raw[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	return line, offset - bytes.LastIndexByte(before, '\n')
+}
+
+// checkKeys reports each key in doc that t has no setting for, following
+// the mapstructure tags the config is decoded with. Values of the wrong
+// shape are left to decoding to report.
+func checkKeys(t reflect.Type, doc any, path string, unknown func(string)) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := doc.(map[string]any)
+		if !ok || t == reflect.TypeFor[time.Time]() {
+			return
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			f, ok := settingField(t, k)
+			if !ok {
+				unknown(join(k))
+				continue
+			}
+			checkKeys(f.Type, m[k], join(k), unknown)
+		}
+	case reflect.Map:
+		if m, ok := doc.(map[string]any); ok {
+			for k, v := range m {
+				checkKeys(t.Elem(), v, join(k), unknown)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if items, ok := doc.([]any); ok {
+			for i, v := range items {
+				checkKeys(t.Elem(), v, fmt.Sprintf("%s[%d]", path, i), unknown)
+			}
+		}
+	}
+}
+
+// settingField returns the field of t that the config key decodes into,
+// matched without regard to case as viper does.
+func settingField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// parseTOML decodes raw and finds where each key is, for tables, arrays of
+// tables, dotted keys and inline tables alike.
+func parseTOML(raw []byte) (map[string]any, map[string]position, error) {
+	var doc map[string]any
+	if err := toml.Unmarshal(raw, &doc); err != nil {
+		return nil, nil, err
+	}
+	pos := make(map[string]position)
+	counts := make(map[string]int) // entries so far of each array of tables
+	var p unstable.Parser
+	p.Reset(raw)
+
+	place := func(n *unstable.Node) position {
+		s := p.Shape(n.Raw)
+		return position{s.Start.Line, s.Start.Column}
+	}
+	// keyPath extends base with the parts of a key, stepping into the
+	// latest entry of any array of tables on the way
+	keyPath := func(base string, it unstable.Iterator, last func(string, *unstable.Node) string) string {
+		path := base
+		for it.Next() {
+			n := it.Node()
+			if path != "" {
+				path += "."
+			}
+			path += strings.ToLower(string(n.Data))
+			if it.IsLast() && last != nil {
+				return last(path, n)
+			}
+			if _, ok := pos[path]; !ok {
+				pos[path] = place(n)
+			}
+			if c, ok := counts[path]; ok {
+				path = fmt.Sprintf("%s[%d]", path, c-1)
+			}
+		}
+		return path
+	}
+	var value func(path string, n *unstable.Node)
+	value = func(path string, n *unstable.Node) {
+		switch n.Kind {
+		case unstable.InlineTable:
+			it := n.Children()
+			for it.Next() {
+				kv := it.Node()
+				value(keyPath(path, kv.Key(), nil), kv.Value())
+			}
+		case unstable.Array:
+			it := n.Children()
+			for i := 0; it.Next(); i++ {
+				value(fmt.Sprintf("%s[%d]", path, i), it.Node())
+			}
+		}
+	}
+
+	table := ""
+	for p.NextExpression() {
+		e := p.Expression()
+		switch e.Kind {
+		case unstable.Table:
+			table = keyPath("", e.Key(), nil)
+		case unstable.ArrayTable:
+			table = keyPath("", e.Key(), func(path string, n *unstable.Node) string {
+				if _, ok := pos[path]; !ok {
+					pos[path] = place(n)
+				}
+				i := counts[path]
+				counts[path] = i + 1
+				entry := fmt.Sprintf("%s[%d]", path, i)
+				pos[entry] = place(n)
+				return entry
+			})
+		case unstable.KeyValue:
+			value(keyPath(table, e.Key(), nil), e.Value())
+		}
+	}
+	if err := p.Error(); err != nil {
+		return nil, nil, err
+	}
+	return doc, pos, nil
+}
+
+// parseYAML decodes raw and finds where each key is from the node tree.
+func parseYAML(raw []byte) (map[string]any, map[string]position, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(raw, &root); err != nil {
+		return nil, nil, err
+	}
+	doc := map[string]any{}
+	if len(root.Content) == 0 {
+		return doc, nil, nil
+	}
+	if err := root.Content[0].Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+	pos := make(map[string]position)
+	var walk func(path string, n *yaml.Node)
+	walk = func(path string, n *yaml.Node) {
+		switch n.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				k := n.Content[i]
+				key := strings.ToLower(k.Value)
+				if path != "" {
+					key = path + "." + key
+				}
+				pos[key] = position{k.Line, k.Column}
+				walk(key, n.Content[i+1])
+			}
+		case yaml.SequenceNode:
+			for i, item := range n.Content {
+				key := fmt.Sprintf("%s[%d]", path, i)
+				pos[key] = position{item.Line, item.Column}
+				walk(key, item)
+			}
+		}
+	}
+	walk("", root.Content[0])
+	return doc, pos, nil
+}
+
+// parseJSON decodes raw and finds where each key is by reading it again
+// token by token.
+func parseJSON(raw []byte) (map[string]any, map[string]position, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil, err
+	}
+	pos := make(map[string]position)
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	// start skips the separators after the decoder's offset to where the
+	// next token begins
+	start := func() position {
+		off := int(dec.InputOffset())
+		for off < len(raw) && strings.IndexByte(" \t\r\n,:", raw[off]) >= 0 {
+			off++
+		}
+		line, col := lineColumn(raw, off)
+		return position{line, col}
+	}
+	var walk func(path string) error
+	walk = func(path string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				at := start()
+				k, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key := strings.ToLower(fmt.Sprint(k))
+				if path != "" {
+					key = path + "." + key
+				}
+				pos[key] = at
+				if err := walk(key); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				key := fmt.Sprintf("%s[%d]", path, i)
+				pos[key] = start()
+				if err := walk(key); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+	if err := walk(""); err != nil {
+		return nil, nil, err
+	}
+	return doc, pos, nil
+}
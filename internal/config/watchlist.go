@@ -0,0 +1,157 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+var symbolsKey = regexp.MustCompile(`^\s*symbols\s*=`)
+
+// symbolHeaders are the column names taken for the symbol in a CSV file
+// with a header row, as broker exports label it.
+var symbolHeaders = []string{"symbol", "ticker", "code", "instrument", "security"}
+
+// ReadWatchlist reads the symbols of a watchlist file: a CSV file, whose
+// symbol column is found by its header or is otherwise the first, or plain
+// text with symbols separated by spaces or new lines. Lines starting with
+// # are comments. Duplicates are dropped, keeping the first.
+func ReadWatchlist(r io.Reader) ([]string, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(bytes.NewReader(raw))
+	cr.Comma = delimiter(raw)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read watchlist: %w", err)
+	}
+
+	col := 0
+	if len(records) > 0 {
+		if i := slices.IndexFunc(records[0], func(h string) bool {
+			return slices.Contains(symbolHeaders, strings.ToLower(strings.TrimSpace(h)))
+		}); i >= 0 {
+			col = i
+			records = records[1:]
+		}
+	}
+	var symbols []string
+	for _, rec := range records {
+		if col >= len(rec) {
+			continue
+		}
+		cell := []string{rec[col]}
+		if len(rec) == 1 {
+			// Plain text may list several symbols on a line
+			cell = strings.Fields(rec[0])
+		}
+		for _, s := range cell {
+			s = strings.TrimSpace(s)
+			if s != "" && !slices.Contains(symbols, s) {
+				symbols = append(symbols, s)
+			}
+		}
+	}
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("no symbols in watchlist")
+	}
+	return symbols, nil
+}
+
+// delimiter guesses the field separator from the first line that is not a
+// comment: a tab or semicolon when it has one, otherwise a comma.
+func delimiter(raw []byte) rune {
+	sc := bufio.NewScanner(bytes.NewReader(raw))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch {
+		case strings.Contains(line, "\t"):
+			return '\t'
+		case strings.Count(line, ";") > strings.Count(line, ","):
+			return ';'
+		}
+		break
+	}
+	return ','
+}
+
+// WriteWatchlist writes symbols as a watchlist file ReadWatchlist reads
+// back: CSV with a symbol and name column, names from aliases, or plain
+// text with a symbol per line.
+func WriteWatchlist(w io.Writer, symbols []string, aliases models.Aliases, asCSV bool) error {
+	if !asCSV {
+		for _, s := range symbols {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"symbol", "name"})
+	for _, s := range symbols {
+		name := aliases.Name(s)
+		if name == s {
+			name = ""
+		}
+		_ = cw.Write([]string{s, name})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SaveWatchlist sets the symbols of the [[watchlists]] entry called
+// wl.Name in the config file, adding the entry when there is none. The
+// entry's other settings are left as they are, and the file is edited as
+// text like SaveLayout. It returns the path written.
+func SaveWatchlist(wl models.Watchlist) (string, error) {
+	quoted := make([]string, len(wl.Symbols))
+	for i, s := range wl.Symbols {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	line := "symbols = [" + strings.Join(quoted, ", ") + "]"
+
+	return editConfig(func(lines []string) []string {
+		start, end, ok := findEntry(lines, "[[watchlists]]", wl.Name)
+		if !ok {
+			for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+				lines = lines[:len(lines)-1]
+			}
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			return append(lines, "[[watchlists]]", fmt.Sprintf("name = %q", wl.Name), line, "")
+		}
+		i := slices.IndexFunc(lines[start:end], symbolsKey.MatchString)
+		if i < 0 {
+			return slices.Insert(lines, end, line)
+		}
+		// The array may run over several lines
+		first := start + i
+		last := first
+		depth := 0
+		for ; last < end; last++ {
+			depth += strings.Count(lines[last], "[") - strings.Count(lines[last], "]")
+			if depth <= 0 {
+				break
+			}
+		}
+		return slices.Replace(lines, first, min(last, end-1)+1, line)
+	})
+}
@@ -0,0 +1,375 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+const binanceBase = "https://api.binance.com/api/v3"
+
+// binanceQuoteAssets are the quote assets recognised at the end of a pair.
+var binanceQuoteAssets = []string{"FDUSD", "USDT", "USDC", "BUSD", "EUR", "GBP", "TRY", "BRL", "JPY", "BTC", "ETH", "BNB"}
+
+// Binance serves spot prices and klines for exchange pairs such as
+// BTCUSDT. Crypto trades around the clock, so candles are taken as-is with
+// no market-closed gaps to skip.
+type Binance struct {
+	coarse bool // one interval coarser on the standard ranges
+}
+
+func NewBinance() *Binance {
+	return &Binance{}
+}
+
+func newBinance(opts Options) *Binance {
+	return &Binance{coarse: opts.LowBandwidth}
+}
+
+func (b *Binance) Name() string { return "Binance" }
+
+// pair converts a symbol to a Binance pair: BTCUSDT is used as-is and
+// dashed pairs like BTC-USD map to the USDT market.
+func (b *Binance) pair(symbol string) string {
+	sym := strings.ToUpper(symbol)
+	if !strings.Contains(sym, "-") {
+		return sym
+	}
+	base, quote := market.SplitPair(sym)
+	if quote == "USD" {
+		quote = "USDT"
+	}
+	return base + quote
+}
+
+// pairCurrency returns the display currency for a pair. Dollar stablecoins
+// are shown as USD.
+func pairCurrency(pair string) string {
+	for _, q := range binanceQuoteAssets {
+		if strings.HasSuffix(pair, q) && len(pair) > len(q) {
+			switch q {
+			case "USDT", "USDC", "BUSD", "FDUSD":
+				return "USD"
+			}
+			return q
+		}
+	}
+	return "USD"
+}
+
+func (b *Binance) GetQuotes(symbols []string) ([]models.Quote, error) {
+	pairs := make([]string, len(symbols))
+	requested := make(map[string]string, len(symbols))
+	for i, s := range symbols {
+		pairs[i] = strconv.Quote(b.pair(s))
+		requested[b.pair(s)] = s
+	}
+
+	params := url.Values{}
+	params.Set("symbols", "["+strings.Join(pairs, ",")+"]")
+	fullURL := binanceBase + "/ticker/24hr?" + params.Encode()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, fullURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []struct {
+		Symbol             string `json:"symbol"`
+		LastPrice          string `json:"lastPrice"`
+		PriceChangePercent string `json:"priceChangePercent"`
+		OpenPrice          string `json:"openPrice"`
+		HighPrice          string `json:"highPrice"`
+		LowPrice           string `json:"lowPrice"`
+		Volume             string `json:"volume"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+
+	now := time.Now()
+	quotes := make([]models.Quote, 0, len(resp))
+	for _, r := range resp {
+		price, err := strconv.ParseFloat(r.LastPrice, 64)
+		if err != nil || price == 0 {
+			continue
+		}
+		pct, _ := strconv.ParseFloat(r.PriceChangePercent, 64)
+		sym := r.Symbol
+		if orig, ok := requested[sym]; ok {
+			sym = orig
+		}
+		// The figures cover the last 24 hours rather than a session
+		open, _ := strconv.ParseFloat(r.OpenPrice, 64)
+		high, _ := strconv.ParseFloat(r.HighPrice, 64)
+		low, _ := strconv.ParseFloat(r.LowPrice, 64)
+		vol, _ := strconv.ParseFloat(r.Volume, 64)
+		quotes = append(quotes, models.Quote{
+			Symbol:      sym,
+			Price:       price,
+			ChangePct:   pct,
+			Currency:    pairCurrency(r.Symbol),
+			LastUpdated: now,
+			Open:        open,
+			DayHigh:     high,
+			DayLow:      low,
+			Volume:      vol,
+		})
+	}
+	return quotes, nil
+}
+
+// binanceInterval maps a range to a kline interval and candle count.
+func binanceInterval(tr models.TimeRange) (string, int) {
+	switch tr {
+	case models.Range1H:
+		return "1m", 60
+	case models.Range7D:
+		return "1h", 168
+	case models.Range30D:
+		return "4h", 180
+	case models.Range90D:
+		return "1d", 90
+	case models.Range1Y:
+		return "1d", 365
+	case models.Range5Y:
+		return "1w", 261
+	default: // 24H
+		return "15m", 96
+	}
+}
+
+// binanceIntervals are the kline intervals used for explicit windows,
+// finest first.
+var binanceIntervals = []struct {
+	name string
+	d    time.Duration
+}{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"4h", 4 * time.Hour},
+	{"1d", 24 * time.Hour},
+}
+
+// binanceCoarser returns the next coarser interval with the candle count
+// that covers the same span, for low-bandwidth mode.
+func binanceCoarser(interval string, limit int) (string, int) {
+	for i, s := range binanceIntervals[:len(binanceIntervals)-1] {
+		if s.name == interval {
+			next := binanceIntervals[i+1]
+			return next.name, max(2, int(time.Duration(limit)*s.d/next.d))
+		}
+	}
+	return interval, limit
+}
+
+// binanceSpanInterval picks the finest interval that covers span within
+// one 1000-kline page. Longer spans fall back to daily candles fetched
+// over several pages, so deep history keeps daily resolution.
+func binanceSpanInterval(span time.Duration) (string, time.Duration) {
+	for _, s := range binanceIntervals {
+		if span/s.d <= binancePageSize {
+			return s.name, s.d
+		}
+	}
+	last := binanceIntervals[len(binanceIntervals)-1]
+	return last.name, last.d
+}
+
+// binancePageSize is the API's kline limit per request.
+const binancePageSize = 1000
+
+func (b *Binance) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	pair := b.pair(symbol)
+
+	var candles []models.Candle
+	var err error
+	now := time.Now()
+	_, anchored := tr.Anchor()
+	// Binance's kline intervals include every selectable one
+	interval, step := string(tr.Interval()), time.Duration(0)
+	if d, ok := candleStep(tr, now); ok {
+		step = d
+	} else if anchored || tr.Base() == models.RangeYTD {
+		interval, step = binanceSpanInterval(tr.End(now).Sub(tr.Start(now)))
+	}
+	var d Drops
+	if step > 0 {
+		start, end := tr.Start(now), tr.End(now)
+		candles, err = paginate(start, end, step, func(from time.Time) ([]models.Candle, error) {
+			params := url.Values{}
+			params.Set("symbol", pair)
+			params.Set("interval", interval)
+			params.Set("startTime", strconv.FormatInt(from.UnixMilli(), 10))
+			params.Set("endTime", strconv.FormatInt(end.UnixMilli(), 10))
+			params.Set("limit", strconv.Itoa(binancePageSize))
+			page, pd, err := b.klines(params)
+			d = d.add(pd)
+			return page, err
+		})
+	} else {
+		interval, limit := binanceInterval(tr.Base())
+		if b.coarse {
+			interval, limit = binanceCoarser(interval, limit)
+		}
+		params := url.Values{}
+		params.Set("symbol", pair)
+		params.Set("interval", interval)
+		params.Set("limit", strconv.Itoa(limit))
+		candles, d, err = b.klines(params)
+	}
+	if err != nil {
+		return nil, err
+	}
+	noteDrops(symbol, tr, d)
+
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no valid candles for %s", symbol)
+	}
+	return candles, nil
+}
+
+// klines fetches one page of candles, with the count of rows it had to
+// leave out.
+func (b *Binance) klines(params url.Values) ([]models.Candle, Drops, error) {
+	var d Drops
+	fullURL := binanceBase + "/klines?" + params.Encode()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, fullURL, nil)
+	if err != nil {
+		return nil, d, err
+	}
+
+	// Each kline is [openTime, open, high, low, close, volume, closeTime, ...]
+	// with prices encoded as strings. Rows are decoded one by one so a
+	// malformed row costs only its candle.
+	if err := expectArray(body); err != nil {
+		return nil, d, err
+	}
+	var rows []json.RawMessage
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, d, fmt.Errorf("parse error: %w", err)
+	}
+
+	d.Total = len(rows)
+	raw := make([]models.Candle, 0, len(rows))
+	for _, r := range rows {
+		var row []json.RawMessage
+		if err := json.Unmarshal(r, &row); err != nil || len(row) < 6 {
+			d.Dropped++
+			continue
+		}
+		openTime, ok := unixTime(row[0], true)
+		if !ok {
+			d.Dropped++
+			continue
+		}
+		var vals [5]float64
+		for i := range vals {
+			vals[i], _ = number(row[i+1])
+		}
+		raw = append(raw, models.Candle{
+			Timestamp: openTime,
+			Open:      vals[0],
+			High:      vals[1],
+			Low:       vals[2],
+			Close:     vals[3],
+			Volume:    vals[4],
+		})
+	}
+	candles, bad := cleanCandles(raw)
+	d.Dropped += bad
+	return candles, d, nil
+}
+
+// GetFundamentals returns the pair's 24h volume in the quote asset and its
+// 52-week range from daily klines. Exchange pairs carry no market cap.
+func (b *Binance) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	pair := b.pair(symbol)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, binanceBase+"/ticker/24hr?symbol="+url.QueryEscape(pair), nil)
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
+
+	var ticker struct {
+		QuoteVolume string `json:"quoteVolume"`
+	}
+	if err := json.Unmarshal(body, &ticker); err != nil {
+		return models.Fundamentals{}, fmt.Errorf("parse error: %w", err)
+	}
+
+	f := models.Fundamentals{Symbol: symbol, Currency: pairCurrency(pair)}
+	f.Volume, _ = strconv.ParseFloat(ticker.QuoteVolume, 64)
+	if high, low, err := yearRange(b, symbol); err == nil {
+		f.High52, f.Low52 = high, low
+	}
+	return f, nil
+}
+
+// GetOrderBook returns the best depth bids and asks of the pair from
+// Binance's depth endpoint.
+func (b *Binance) GetOrderBook(symbol string, depth int) (models.OrderBook, error) {
+	book := models.OrderBook{Symbol: symbol}
+	if err := checkBookable(symbol); err != nil {
+		return book, err
+	}
+	// The endpoint takes only a few limits; ask for the next one up
+	limit := 5000
+	for _, l := range []int{5, 10, 20, 50, 100, 500, 1000} {
+		if depth <= l {
+			limit = l
+			break
+		}
+	}
+	params := url.Values{}
+	params.Set("symbol", b.pair(symbol))
+	params.Set("limit", strconv.Itoa(limit))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, binanceBase+"/depth?"+params.Encode(), nil)
+	if err != nil {
+		return book, err
+	}
+
+	var resp struct {
+		Bids [][2]string `json:"bids"`
+		Asks [][2]string `json:"asks"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return book, fmt.Errorf("parse error: %w", err)
+	}
+	levels := func(in [][2]string) []models.BookLevel {
+		out := make([]models.BookLevel, 0, min(len(in), depth))
+		for _, l := range in[:min(len(in), depth)] {
+			price, _ := strconv.ParseFloat(l[0], 64)
+			size, _ := strconv.ParseFloat(l[1], 64)
+			out = append(out, models.BookLevel{Price: price, Size: size})
+		}
+		return out
+	}
+	book.Bids = levels(resp.Bids)
+	book.Asks = levels(resp.Asks)
+	book.Time = time.Now()
+	return book, nil
+}
@@ -0,0 +1,256 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Cache keeps history and fundamentals responses on disk so a restart does
+// not refetch every chart. History entries are keyed by provider, symbol
+// and range and expire after a TTL that grows with the range length;
+// fundamentals keep for FundamentalsTTL. Quotes keep for quoteTTL, only
+// long enough for other instances polling the same symbols to use them.
+//
+// Instances running at once share the directory: a miss takes a lock
+// (see lock), so one of them fetches the entry and the rest read it.
+type Cache struct {
+	base     Provider
+	dir      string
+	provider string
+	quoteTTL time.Duration
+}
+
+// How long an instance waits for another one to fill an entry before
+// fetching it itself. Quotes are polled, so are not waited on for long.
+const (
+	historyLockWait = 20 * time.Second
+	quoteLockWait   = 5 * time.Second
+)
+
+// WithCache wraps p with a history cache under dir. provider names the
+// source in the cache key so different providers never share entries.
+// Quotes are shared for quoteTTL, which should be under the shortest
+// refresh interval so an instance never takes its own quotes for fresh
+// ones; zero leaves quotes uncached. It returns p unchanged when dir is
+// empty.
+func WithCache(p Provider, dir, provider string, quoteTTL time.Duration) Provider {
+	if dir == "" {
+		return p
+	}
+	return &Cache{base: p, dir: dir, provider: provider, quoteTTL: quoteTTL}
+}
+
+// historyTTL is how long cached history for tr stays fresh. Custom
+// windows that closed in the past never change, so they keep for a month.
+func historyTTL(tr models.TimeRange) time.Duration {
+	now := time.Now()
+	if tr.IsCustom() && tr.End(now).Before(now) {
+		return 30 * 24 * time.Hour
+	}
+	switch tr.Base() {
+	case models.Range1H:
+		return time.Minute
+	case models.Range24H:
+		return 5 * time.Minute
+	case models.Range7D:
+		return 30 * time.Minute
+	case models.Range30D:
+		return 2 * time.Hour
+	case models.Range5Y:
+		return 24 * time.Hour
+	default:
+		return 6 * time.Hour
+	}
+}
+
+// historyAger is implemented by providers that can tell how old their
+// cached history is without fetching.
+type historyAger interface {
+	cachedHistory(symbol string, tr models.TimeRange) (age time.Duration, fresh, ok bool)
+}
+
+// CachedHistory reports the age of p's cached history for symbol and tr,
+// and whether it is fresh enough to be served without a fetch. ok is false
+// when nothing is cached or p keeps no cache.
+func CachedHistory(p Provider, symbol string, tr models.TimeRange) (age time.Duration, fresh, ok bool) {
+	if a, isAger := p.(historyAger); isAger {
+		return a.cachedHistory(symbol, tr)
+	}
+	return 0, false, false
+}
+
+func (c *Cache) cachedHistory(symbol string, tr models.TimeRange) (time.Duration, bool, bool) {
+	info, err := os.Stat(c.path("history", symbol+"_"+string(tr)))
+	if err != nil {
+		return 0, false, false
+	}
+	age := time.Since(info.ModTime())
+	return age, age <= historyTTL(tr), true
+}
+
+// oldestCached combines the cache state of the symbols a derived symbol is
+// built from: the oldest age, fresh only when all are.
+func oldestCached(p Provider, symbols []string, tr models.TimeRange) (time.Duration, bool, bool) {
+	var oldest time.Duration
+	allFresh := true
+	for _, s := range symbols {
+		age, fresh, ok := CachedHistory(p, s, tr)
+		if !ok {
+			return 0, false, false
+		}
+		oldest = max(oldest, age)
+		allFresh = allFresh && fresh
+	}
+	return oldest, allFresh, len(symbols) > 0
+}
+
+func (c *Cache) Name() string { return c.base.Name() }
+
+// GetQuotes fetches only the symbols no other instance has fetched within
+// quoteTTL, returning quotes in the order asked for.
+func (c *Cache) GetQuotes(symbols []string) ([]models.Quote, error) {
+	if c.quoteTTL <= 0 {
+		return c.base.GetQuotes(symbols)
+	}
+	got := make(map[string]models.Quote, len(symbols))
+	missing := c.sharedQuotes(symbols, got)
+	if len(missing) > 0 {
+		dir := filepath.Dir(c.path("quotes", ""))
+		release := lock(filepath.Join(dir, "quotes.lock"), quoteLockWait, func() bool {
+			return len(c.sharedQuotes(missing, got)) == 0
+		})
+		defer release()
+		missing = c.sharedQuotes(missing, got)
+	}
+	if len(missing) > 0 {
+		fresh, err := c.base.GetQuotes(missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range fresh {
+			got[q.Symbol] = q
+			_ = writeCache(c.path("quotes", q.Symbol), q)
+		}
+	}
+	quotes := make([]models.Quote, 0, len(got))
+	for _, s := range symbols {
+		if q, ok := got[s]; ok {
+			quotes = append(quotes, q)
+		}
+	}
+	return quotes, nil
+}
+
+// sharedQuotes reads the fresh cached quotes of symbols into got and
+// returns the symbols still missing.
+func (c *Cache) sharedQuotes(symbols []string, got map[string]models.Quote) []string {
+	var missing []string
+	for _, s := range symbols {
+		if _, ok := got[s]; ok {
+			continue
+		}
+		var q models.Quote
+		if readCache(c.path("quotes", s), c.quoteTTL, &q) && q.Symbol == s {
+			got[s] = q
+			continue
+		}
+		missing = append(missing, s)
+	}
+	return missing
+}
+
+func (c *Cache) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	path := c.path("history", symbol+"_"+string(tr))
+	var candles []models.Candle
+	cached := func() bool {
+		return readCache(path, historyTTL(tr), &candles) && len(candles) > 0
+	}
+	if cached() {
+		return candles, nil
+	}
+	release := lock(path+".lock", historyLockWait, cached)
+	defer release()
+	if cached() {
+		return candles, nil
+	}
+	candles, err := c.base.GetHistory(symbol, tr)
+	if err != nil {
+		return nil, err
+	}
+	// Best effort: a failed write only costs a refetch next time
+	if len(candles) > 0 {
+		_ = writeCache(path, candles)
+	}
+	return candles, nil
+}
+
+func (c *Cache) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	path := c.path("fundamentals", symbol)
+	var f models.Fundamentals
+	cached := func() bool { return readCache(path, FundamentalsTTL, &f) }
+	if cached() {
+		return f, nil
+	}
+	release := lock(path+".lock", historyLockWait, cached)
+	defer release()
+	if cached() {
+		return f, nil
+	}
+	f, err := c.base.GetFundamentals(symbol)
+	if err != nil {
+		return f, err
+	}
+	_ = writeCache(path, f)
+	return f, nil
+}
+
+// path maps a cache key to a file under kind, replacing characters that
+// are not safe in file names.
+func (c *Cache) path(kind, key string) string {
+	clean := strings.NewReplacer("/", "_", "\\", "_", ":", "_", "@", "at-", ",", "+", " ", "_")
+	return filepath.Join(c.dir, kind, clean.Replace(c.provider), clean.Replace(strings.ToUpper(key))+".json")
+}
+
+// readCache decodes a fresh entry into v and reports whether it did.
+func readCache(path string, ttl time.Duration, v any) bool {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return false
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+// writeCache writes through a temp file so a crash never leaves a
+// truncated entry behind.
+func writeCache(path string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ni5arga/stock-tui/internal/market"
 	"github.com/ni5arga/stock-tui/internal/models"
 )
 
@@ -19,20 +22,16 @@ type CoinGecko struct {
 func NewCoinGecko() *CoinGecko {
 	return &CoinGecko{
 		idMap: map[string]string{
-			"BTC":     "bitcoin",
-			"BTC-USD": "bitcoin",
-			"ETH":     "ethereum",
-			"ETH-USD": "ethereum",
-			"SOL":     "solana",
-			"SOL-USD": "solana",
-			"XRP":     "ripple",
-			"XRP-USD": "ripple",
-			"DOGE":    "dogecoin",
-			"ADA":     "cardano",
-			"DOT":     "polkadot",
-			"AVAX":    "avalanche-2",
-			"MATIC":   "matic-network",
-			"LINK":    "chainlink",
+			"BTC":   "bitcoin",
+			"ETH":   "ethereum",
+			"SOL":   "solana",
+			"XRP":   "ripple",
+			"DOGE":  "dogecoin",
+			"ADA":   "cardano",
+			"DOT":   "polkadot",
+			"AVAX":  "avalanche-2",
+			"MATIC": "matic-network",
+			"LINK":  "chainlink",
 		},
 	}
 }
@@ -40,24 +39,36 @@ func NewCoinGecko() *CoinGecko {
 func (c *CoinGecko) Name() string { return "CoinGecko" }
 
 func (c *CoinGecko) symbolToID(symbol string) string {
-	sym := strings.ToUpper(strings.TrimSuffix(symbol, "-USD"))
-	if id, ok := c.idMap[sym]; ok {
+	base, _ := market.SplitPair(symbol)
+	if id, ok := c.idMap[base]; ok {
 		return id
 	}
-	return strings.ToLower(sym)
+	return strings.ToLower(base)
+}
+
+// vsCurrency returns the CoinGecko quote currency for a pair.
+func vsCurrency(symbol string) string {
+	_, quote := market.SplitPair(symbol)
+	return strings.ToLower(quote)
 }
 
 func (c *CoinGecko) GetQuotes(symbols []string) ([]models.Quote, error) {
 	ids := make([]string, 0, len(symbols))
+	vsSet := make(map[string]bool)
+	var vs []string
 	symToID := make(map[string]string)
 	for _, s := range symbols {
 		id := c.symbolToID(s)
 		ids = append(ids, id)
 		symToID[s] = id
+		if cur := vsCurrency(s); !vsSet[cur] {
+			vsSet[cur] = true
+			vs = append(vs, cur)
+		}
 	}
 
-	url := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=usd&include_24hr_change=true",
-		coingeckoBase, strings.Join(ids, ","))
+	url := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=%s&include_24hr_change=true&include_24hr_vol=true&include_market_cap=true",
+		coingeckoBase, strings.Join(ids, ","), strings.Join(vs, ","))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
@@ -67,10 +78,9 @@ func (c *CoinGecko) GetQuotes(symbols []string) ([]models.Quote, error) {
 		return nil, err
 	}
 
-	var data map[string]struct {
-		USD       float64 `json:"usd"`
-		Change24h float64 `json:"usd_24h_change"`
-	}
+	// Keyed by id, then by "<vs>", "<vs>_24h_change", "<vs>_24h_vol" and
+	// "<vs>_market_cap"
+	var data map[string]map[string]float64
 	if err := json.Unmarshal(body, &data); err != nil {
 		return nil, fmt.Errorf("parse error: %w", err)
 	}
@@ -78,15 +88,29 @@ func (c *CoinGecko) GetQuotes(symbols []string) ([]models.Quote, error) {
 	now := time.Now()
 	quotes := make([]models.Quote, 0, len(symbols))
 	for _, sym := range symbols {
-		id := symToID[sym]
-		if d, ok := data[id]; ok {
-			quotes = append(quotes, models.Quote{
-				Symbol:      sym,
-				Price:       d.USD,
-				ChangePct:   d.Change24h,
-				LastUpdated: now,
-			})
+		d, ok := data[symToID[sym]]
+		if !ok {
+			continue
+		}
+		cur := vsCurrency(sym)
+		price, ok := d[cur]
+		if !ok {
+			continue
+		}
+		q := models.Quote{
+			Symbol:      sym,
+			Price:       price,
+			ChangePct:   d[cur+"_24h_change"],
+			Currency:    strings.ToUpper(cur),
+			LastUpdated: now,
+			MarketCap:   d[cur+"_market_cap"],
 		}
+		// Volume is the traded value in the quote currency; in coins it
+		// matches the other sources
+		if price > 0 {
+			q.Volume = d[cur+"_24h_vol"] / price
+		}
+		quotes = append(quotes, q)
 	}
 
 	return quotes, nil
@@ -96,7 +120,9 @@ func (c *CoinGecko) GetHistory(symbol string, tr models.TimeRange) ([]models.Can
 	id := c.symbolToID(symbol)
 
 	var days string
-	switch tr {
+	// CoinGecko picks the granularity from the span, so a chosen interval
+	// is ignored
+	switch tr.Base() {
 	case models.Range1H:
 		days = "1"
 	case models.Range24H:
@@ -105,11 +131,28 @@ func (c *CoinGecko) GetHistory(symbol string, tr models.TimeRange) ([]models.Can
 		days = "7"
 	case models.Range30D:
 		days = "30"
+	case models.Range90D:
+		days = "90"
+	case models.Range1Y:
+		days = "365"
+	case models.Range5Y:
+		days = "1825"
 	default:
 		days = "1"
 	}
 
-	url := fmt.Sprintf("%s/coins/%s/market_chart?vs_currency=usd&days=%s", coingeckoBase, id, days)
+	vs := vsCurrency(symbol)
+	if tr.Base() == models.RangeYTD {
+		span := time.Since(tr.Start(time.Now()))
+		days = strconv.Itoa(max(1, int(math.Ceil(span.Hours()/24))))
+	}
+	url := fmt.Sprintf("%s/coins/%s/market_chart?vs_currency=%s&days=%s", coingeckoBase, id, vs, days)
+	if _, ok := tr.Anchor(); ok {
+		// Anchored and custom windows fetch exactly the requested period
+		now := time.Now()
+		url = fmt.Sprintf("%s/coins/%s/market_chart/range?vs_currency=%s&from=%d&to=%d",
+			coingeckoBase, id, vs, tr.Start(now).Unix(), tr.End(now).Unix())
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
@@ -119,21 +162,33 @@ func (c *CoinGecko) GetHistory(symbol string, tr models.TimeRange) ([]models.Can
 		return nil, err
 	}
 
+	// Each point is [ms, price]; points are decoded one by one so a
+	// malformed one costs only itself
+	if err := expectFields(body, "prices"); err != nil {
+		return nil, err
+	}
 	var data struct {
-		Prices [][]float64 `json:"prices"`
+		Prices []json.RawMessage `json:"prices"`
 	}
 	if err := json.Unmarshal(body, &data); err != nil {
 		return nil, fmt.Errorf("parse error: %w", err)
 	}
 
-	candles := make([]models.Candle, 0, len(data.Prices))
-	for _, p := range data.Prices {
-		if len(p) < 2 {
+	d := Drops{Total: len(data.Prices)}
+	raw := make([]models.Candle, 0, len(data.Prices))
+	for _, r := range data.Prices {
+		var p []json.RawMessage
+		if err := json.Unmarshal(r, &p); err != nil || len(p) < 2 {
+			d.Dropped++
+			continue
+		}
+		ts, ok := unixTime(p[0], true)
+		if !ok {
+			d.Dropped++
 			continue
 		}
-		ts := time.UnixMilli(int64(p[0]))
-		price := p[1]
-		candles = append(candles, models.Candle{
+		price, _ := number(p[1])
+		raw = append(raw, models.Candle{
 			Timestamp: ts,
 			Open:      price,
 			High:      price,
@@ -141,6 +196,48 @@ func (c *CoinGecko) GetHistory(symbol string, tr models.TimeRange) ([]models.Can
 			Close:     price,
 		})
 	}
+	candles, bad := cleanCandles(raw)
+	d.Dropped += bad
+	noteDrops(symbol, tr, d)
 
 	return candles, nil
 }
+
+// GetFundamentals returns market cap and volume from the markets endpoint
+// and the 52-week range from a year of history. Coins have no earnings or
+// dividends.
+func (c *CoinGecko) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	vs := vsCurrency(symbol)
+	url := fmt.Sprintf("%s/coins/markets?vs_currency=%s&ids=%s", coingeckoBase, vs, c.symbolToID(symbol))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, url, nil)
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
+
+	var rows []struct {
+		MarketCap   float64 `json:"market_cap"`
+		TotalVolume float64 `json:"total_volume"`
+	}
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return models.Fundamentals{}, fmt.Errorf("parse error: %w", err)
+	}
+	if len(rows) == 0 {
+		return models.Fundamentals{}, fmt.Errorf("no fundamentals for %s", symbol)
+	}
+
+	f := models.Fundamentals{
+		Symbol:    symbol,
+		Currency:  strings.ToUpper(vs),
+		MarketCap: rows[0].MarketCap,
+		Volume:    rows[0].TotalVolume,
+	}
+	// The range is a bonus; the free tier may rate limit the second call
+	if high, low, err := yearRange(c, symbol); err == nil {
+		f.High52, f.Low52 = high, low
+	}
+	return f, nil
+}
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// EarningsTTL is how long the earnings calendar stays fresh. Report dates
+// are set weeks ahead, so a few refreshes a day is plenty.
+const EarningsTTL = 6 * time.Hour
+
+// EarningsProvider is implemented by sources that carry earnings dates.
+// Symbols with neither an upcoming report nor an ex-dividend date are
+// left out of the result.
+type EarningsProvider interface {
+	GetEarnings(symbols []string) ([]models.Earnings, error)
+}
+
+// NewEarnings returns the earnings source for a provider chain, following
+// NewNews: the simulator's made-up dates when it is the only provider,
+// Yahoo Finance otherwise.
+func NewEarnings(names []string) EarningsProvider {
+	if len(names) == 1 && names[0] == "simulator" {
+		return NewSimulator()
+	}
+	return NewYahoo()
+}
+
+// reporting returns the symbols that report earnings at all: stocks, as
+// opposed to crypto, indices, currencies and the rest.
+func reporting(symbols []string) []string {
+	var out []string
+	for _, s := range symbols {
+		if market.AssetClass(s) == market.ClassStock {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// reportTiming tells from a report's timestamp whether it falls before
+// the open or after the close of the symbol's exchange. Timestamps at
+// midnight or in the session say nothing, so they give "".
+func reportTiming(symbol string, t time.Time) string {
+	switch market.CalendarFor(symbol).State(t) {
+	case market.StatePre:
+		return models.EarningsBeforeOpen
+	case market.StatePost:
+		return models.EarningsAfterClose
+	}
+	return ""
+}
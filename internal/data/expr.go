@@ -0,0 +1,211 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+)
+
+// expr is a parsed synthetic-symbol expression such as "AAPL / MSFT" or
+// "GLD - SLV * 2".
+type expr interface {
+	eval(vals map[string]float64) (float64, error)
+}
+
+type numberExpr float64
+
+func (n numberExpr) eval(map[string]float64) (float64, error) { return float64(n), nil }
+
+type symbolExpr string
+
+func (s symbolExpr) eval(vals map[string]float64) (float64, error) {
+	v, ok := vals[string(s)]
+	if !ok {
+		return 0, fmt.Errorf("no price for %s", string(s))
+	}
+	return v, nil
+}
+
+type negExpr struct{ x expr }
+
+func (n negExpr) eval(vals map[string]float64) (float64, error) {
+	v, err := n.x.eval(vals)
+	return -v, err
+}
+
+type binaryExpr struct {
+	op   byte
+	l, r expr
+}
+
+func (b binaryExpr) eval(vals map[string]float64) (float64, error) {
+	l, err := b.l.eval(vals)
+	if err != nil {
+		return 0, err
+	}
+	r, err := b.r.eval(vals)
+	if err != nil {
+		return 0, err
+	}
+	switch b.op {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	default:
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	}
+}
+
+// parseExpr parses an arithmetic expression over symbols with + - * /,
+// parentheses and numeric constants. A dash inside a symbol is kept when
+// it introduces a fiat quote currency (BTC-USD); otherwise it subtracts,
+// so "GLD-SLV" is a spread. It returns the expression and the symbols it
+// references.
+func parseExpr(s string) (expr, []string, error) {
+	toks, err := tokenize(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &exprParser{toks: toks}
+	e, err := p.parseSum()
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, nil, fmt.Errorf("unexpected %q", p.toks[p.pos])
+	}
+	return e, p.symbols, nil
+}
+
+func isSymbolRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(".^=_", r)
+}
+
+func tokenize(s string) ([]string, error) {
+	runes := []rune(s)
+	var toks []string
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("+-*/()", r):
+			toks = append(toks, string(r))
+			i++
+		case isSymbolRune(r):
+			j := i
+			for j < len(runes) {
+				if isSymbolRune(runes[j]) {
+					j++
+					continue
+				}
+				// Keep pair suffixes like -USD or -EUR as part of the symbol
+				if runes[j] == '-' {
+					k := j + 1
+					for k < len(runes) && unicode.IsLetter(runes[k]) {
+						k++
+					}
+					if k > j+1 && market.IsFiat(string(runes[j+1:k])) && (k == len(runes) || !isSymbolRune(runes[k])) {
+						j = k
+						continue
+					}
+				}
+				break
+			}
+			toks = append(toks, string(runes[i:j]))
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return toks, nil
+}
+
+type exprParser struct {
+	toks    []string
+	pos     int
+	symbols []string
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *exprParser) parseSum() (expr, error) {
+	l, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == "+" || op == "-"; op = p.peek() {
+		p.pos++
+		r, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryExpr{op: op[0], l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *exprParser) parseProduct() (expr, error) {
+	l, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == "*" || op == "/"; op = p.peek() {
+		p.pos++
+		r, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryExpr{op: op[0], l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *exprParser) parseFactor() (expr, error) {
+	tok := p.peek()
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case "-":
+		p.pos++
+		x, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return negExpr{x}, nil
+	case "(":
+		p.pos++
+		e, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return e, nil
+	case "+", "*", "/", ")":
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+	p.pos++
+	if v, err := strconv.ParseFloat(tok, 64); err == nil {
+		return numberExpr(v), nil
+	}
+	sym := strings.ToUpper(tok)
+	p.symbols = append(p.symbols, sym)
+	return symbolExpr(sym), nil
+}
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// probeInterval is how long a failing provider is skipped before the chain
+// tries it again.
+const probeInterval = time.Minute
+
+// Fallback tries an ordered list of providers for each request. A provider
+// that errors or rate limits is benched for probeInterval (or for its
+// Retry-After, if longer), after which it is probed again; the first
+// success moves the chain back to it.
+type Fallback struct {
+	providers []Provider
+
+	mu        sync.Mutex
+	served    int // index of the provider that served the last request
+	downUntil []time.Time
+}
+
+func NewFallback(providers ...Provider) *Fallback {
+	return &Fallback{
+		providers: providers,
+		downUntil: make([]time.Time, len(providers)),
+	}
+}
+
+// NewChain builds a provider from an ordered list of names. Unknown names
+// are skipped; a single usable name yields that provider directly.
+func NewChain(names []string, opts Options) Provider {
+	var providers []Provider
+	for _, name := range names {
+		if p, err := NewProvider(name, opts); err == nil {
+			providers = append(providers, p)
+		}
+	}
+	switch len(providers) {
+	case 0:
+		return newMulti(opts)
+	case 1:
+		return providers[0]
+	default:
+		return NewFallback(providers...)
+	}
+}
+
+// Name reports the provider that served the most recent request, marked
+// when it is not the primary.
+func (f *Fallback) Name() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := f.providers[f.served].Name()
+	if f.served > 0 {
+		name += " (fallback)"
+	}
+	return name
+}
+
+// order lists provider indexes to try: healthy ones in configured order,
+// then benched ones as a last resort.
+func (f *Fallback) order(now time.Time) []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	healthy := make([]int, 0, len(f.providers))
+	var benched []int
+	for i, until := range f.downUntil {
+		if now.Before(until) {
+			benched = append(benched, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	return append(healthy, benched...)
+}
+
+func (f *Fallback) record(i int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		f.served = i
+		f.downUntil[i] = time.Time{}
+		return
+	}
+	wait := probeInterval
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter > wait {
+		wait = rateLimitErr.RetryAfter
+	}
+	f.downUntil[i] = time.Now().Add(wait)
+}
+
+func (f *Fallback) try(call func(Provider) error) error {
+	var lastErr error
+	for _, i := range f.order(time.Now()) {
+		err := call(f.providers[i])
+		f.record(i, err)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+	return lastErr
+}
+
+func (f *Fallback) GetQuotes(symbols []string) ([]models.Quote, error) {
+	var quotes []models.Quote
+	err := f.try(func(p Provider) error {
+		q, err := p.GetQuotes(symbols)
+		if err == nil && len(q) == 0 && len(symbols) > 0 {
+			err = errors.New("no quotes returned")
+		}
+		quotes = q
+		return err
+	})
+	return quotes, err
+}
+
+func (f *Fallback) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	var candles []models.Candle
+	err := f.try(func(p Provider) error {
+		c, err := p.GetHistory(symbol, tr)
+		candles = c
+		return err
+	})
+	return candles, err
+}
+
+func (f *Fallback) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	var fund models.Fundamentals
+	err := f.try(func(p Provider) error {
+		v, err := p.GetFundamentals(symbol)
+		fund = v
+		return err
+	})
+	return fund, err
+}
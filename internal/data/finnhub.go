@@ -0,0 +1,295 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+const finnhubBase = "https://finnhub.io/api/v1"
+
+// errFinnhubKey is returned when the finnhub provider is configured
+// without a key.
+var errFinnhubKey = errors.New("the finnhub provider needs an API key in FINNHUB_API_KEY or finnhub_api_key")
+
+// Finnhub serves US stock quotes and candles from Finnhub's REST API,
+// authenticated with the key in FINNHUB_API_KEY or the config.
+type Finnhub struct {
+	token  string
+	coarse bool // one resolution coarser on the standard ranges
+}
+
+func newFinnhub(opts Options) (*Finnhub, error) {
+	token := finnhubKey(opts)
+	if token == "" {
+		return nil, errFinnhubKey
+	}
+	return &Finnhub{token: token, coarse: opts.LowBandwidth}, nil
+}
+
+// finnhubKey returns the API key, the environment's over the config's.
+func finnhubKey(opts Options) string {
+	if key := strings.TrimSpace(os.Getenv("FINNHUB_API_KEY")); key != "" {
+		return key
+	}
+	return strings.TrimSpace(opts.FinnhubKey)
+}
+
+func (f *Finnhub) Name() string { return "Finnhub" }
+
+// get fetches an API path. The key goes in a header rather than the URL,
+// so it never shows up in an error message.
+func (f *Finnhub) get(path string, params url.Values) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	opts := defaultFetchOptions()
+	opts.Header = http.Header{"X-Finnhub-Token": {f.token}}
+	body, err := fetch(ctx, finnhubBase+path+"?"+params.Encode(), &opts)
+	var herr *httpError
+	if errors.As(err, &herr) {
+		switch herr.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("finnhub: API key rejected")
+		case http.StatusForbidden:
+			return nil, fmt.Errorf("finnhub: %s is not included in your plan", strings.TrimPrefix(path, "/"))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Some errors come back as 200 with an error message
+	var e struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &e) == nil && e.Error != "" {
+		return nil, fmt.Errorf("finnhub: %s", e.Error)
+	}
+	return body, nil
+}
+
+// GetQuotes requests each symbol on its own, as the quote endpoint takes
+// one at a time. Unknown symbols come back as zeros and are left out.
+func (f *Finnhub) GetQuotes(symbols []string) ([]models.Quote, error) {
+	now := time.Now()
+	quotes := make([]models.Quote, 0, len(symbols))
+	for _, s := range symbols {
+		body, err := f.get("/quote", url.Values{"symbol": {strings.ToUpper(s)}})
+		if err != nil {
+			return nil, err
+		}
+		if err := expectFields(body, "c", "dp"); err != nil {
+			return nil, err
+		}
+		var q struct {
+			Current   float64  `json:"c"`
+			ChangePct *float64 `json:"dp"`
+			PrevClose float64  `json:"pc"`
+			Open      float64  `json:"o"`
+			High      float64  `json:"h"`
+			Low       float64  `json:"l"`
+		}
+		if err := json.Unmarshal(body, &q); err != nil {
+			return nil, fmt.Errorf("parse error: %w", err)
+		}
+		if !usable(q.Current) {
+			continue
+		}
+		quote := models.Quote{
+			Symbol: s, Price: q.Current, Currency: "USD", LastUpdated: now, PreviousClose: q.PrevClose,
+			Open: q.Open, DayHigh: q.High, DayLow: q.Low,
+		}
+		if q.ChangePct != nil {
+			quote.ChangePct = *q.ChangePct
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes, nil
+}
+
+// finnhubResolutions are the candle resolutions, finest first.
+var finnhubResolutions = []struct {
+	name string
+	d    time.Duration
+}{
+	{"1", time.Minute},
+	{"5", 5 * time.Minute},
+	{"15", 15 * time.Minute},
+	{"60", time.Hour},
+	{"D", 24 * time.Hour},
+	{"W", 7 * 24 * time.Hour},
+}
+
+// finnhubMaxCandles bounds the candles asked for in one request.
+const finnhubMaxCandles = 5000
+
+// finnhubResolution picks the resolution for tr: the chosen interval when
+// it fits, the usual one for a standard range (a step coarser when coarse
+// is set), and otherwise the finest that covers the period.
+func finnhubResolution(tr models.TimeRange, coarse bool, now time.Time) string {
+	if step, ok := candleStep(tr, now); ok {
+		for _, r := range finnhubResolutions {
+			if r.d == step {
+				return r.name
+			}
+		}
+	}
+	i := -1
+	switch tr.Base() {
+	case models.Range1H:
+		i = 0
+	case models.Range24H:
+		i = 1
+	case models.Range7D:
+		i = 2
+	case models.Range30D:
+		i = 3
+	case models.Range90D, models.RangeYTD, models.Range1Y:
+		i = 4
+	case models.Range5Y:
+		i = 5
+	}
+	if i >= 0 {
+		// Daily candles stay daily in low-bandwidth mode
+		if coarse && i < 4 {
+			i++
+		}
+		return finnhubResolutions[i].name
+	}
+	span := tr.End(now).Sub(tr.Start(now))
+	for _, r := range finnhubResolutions {
+		if span/r.d <= finnhubMaxCandles {
+			return r.name
+		}
+	}
+	return "W"
+}
+
+func (f *Finnhub) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	now := time.Now()
+	start, end := tr.Start(now), tr.End(now)
+	// Stocks do not trade overnight or at weekends, so the intraday ranges
+	// reach back to the last session and keep its final hour or day
+	var keep time.Duration
+	if base := tr.Base(); base == models.Range1H || base == models.Range24H {
+		keep = end.Sub(start)
+		start = start.AddDate(0, 0, -4)
+	}
+
+	params := url.Values{}
+	params.Set("symbol", strings.ToUpper(symbol))
+	params.Set("resolution", finnhubResolution(tr, f.coarse, now))
+	params.Set("from", strconv.FormatInt(start.Unix(), 10))
+	params.Set("to", strconv.FormatInt(end.Unix(), 10))
+	body, err := f.get("/stock/candle", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := expectFields(body, "s"); err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Status string            `json:"s"`
+		Time   []json.RawMessage `json:"t"`
+		Open   []json.RawMessage `json:"o"`
+		High   []json.RawMessage `json:"h"`
+		Low    []json.RawMessage `json:"l"`
+		Close  []json.RawMessage `json:"c"`
+		Volume []json.RawMessage `json:"v"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+	if resp.Status != "ok" || len(resp.Time) == 0 {
+		return nil, fmt.Errorf("no data for %s", symbol)
+	}
+
+	at := func(vals []json.RawMessage, i int) float64 {
+		if i < len(vals) {
+			v, _ := number(vals[i])
+			return v
+		}
+		return 0
+	}
+	raw := make([]models.Candle, 0, len(resp.Time))
+	d := Drops{Total: len(resp.Time)}
+	for i, rawTS := range resp.Time {
+		ts, ok := unixTime(rawTS, false)
+		if !ok {
+			d.Dropped++
+			continue
+		}
+		raw = append(raw, models.Candle{
+			Timestamp: ts,
+			Open:      at(resp.Open, i),
+			High:      at(resp.High, i),
+			Low:       at(resp.Low, i),
+			Close:     at(resp.Close, i),
+			Volume:    at(resp.Volume, i),
+		})
+	}
+	candles, bad := cleanCandles(raw)
+	d.Dropped += bad
+	noteDrops(symbol, tr, d)
+
+	if keep > 0 && len(candles) > 0 {
+		cut := candles[len(candles)-1].Timestamp.Add(-keep)
+		i := 0
+		for i < len(candles)-1 && candles[i].Timestamp.Before(cut) {
+			i++
+		}
+		candles = candles[i:]
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no valid candles for %s", symbol)
+	}
+	return candles, nil
+}
+
+// GetFundamentals reads Finnhub's basic financials. Market cap and average
+// volume are reported in millions.
+func (f *Finnhub) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	body, err := f.get("/stock/metric", url.Values{"symbol": {strings.ToUpper(symbol)}, "metric": {"all"}})
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
+	if err := expectFields(body, "metric"); err != nil {
+		return models.Fundamentals{}, err
+	}
+	var resp struct {
+		Metric struct {
+			MarketCap float64 `json:"marketCapitalization"`
+			PE        float64 `json:"peTTM"`
+			EPS       float64 `json:"epsTTM"`
+			High52    float64 `json:"52WeekHigh"`
+			Low52     float64 `json:"52WeekLow"`
+			Yield     float64 `json:"currentDividendYieldTTM"`
+			AvgVolume float64 `json:"10DayAverageTradingVolume"`
+		} `json:"metric"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return models.Fundamentals{}, fmt.Errorf("parse error: %w", err)
+	}
+	m := resp.Metric
+	return models.Fundamentals{
+		Symbol:        symbol,
+		Currency:      "USD",
+		MarketCap:     m.MarketCap * 1e6,
+		PE:            m.PE,
+		EPS:           m.EPS,
+		High52:        m.High52,
+		Low52:         m.Low52,
+		DividendYield: m.Yield,
+		AvgVolume:     m.AvgVolume * 1e6,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package data
+
+import (
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// FundamentalsTTL is how long fundamentals stay fresh. They move slowly,
+// so a few refreshes a day is plenty.
+const FundamentalsTTL = 6 * time.Hour
+
+// YearRangeTTL is how often the watchlist refetches fundamentals for its
+// 52-week badges. A new high or low shows in the quotes meanwhile.
+const YearRangeTTL = 24 * time.Hour
+
+// yearRange returns the 52-week high and low from a year of daily
+// history, for sources without a statistics endpoint.
+func yearRange(p Provider, symbol string) (high, low float64, err error) {
+	candles, err := p.GetHistory(symbol, models.Range1Y)
+	if err != nil {
+		return 0, 0, err
+	}
+	for i, c := range candles {
+		if i == 0 || c.High > high {
+			high = c.High
+		}
+		if i == 0 || c.Low < low {
+			low = c.Low
+		}
+	}
+	return high, low, nil
+}
@@ -0,0 +1,51 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+)
+
+// FXSource fetches exchange rates for converting prices into a display
+// currency.
+type FXSource interface {
+	// GetRates returns units of to per unit of each currency in codes.
+	// Currencies without a quote are left out.
+	GetRates(codes []string, to string) (map[string]float64, error)
+}
+
+// NewFX returns the exchange-rate source for a provider chain. Like
+// NewNews, a simulator-only chain stays offline with simulated rates and
+// anything else uses Yahoo Finance's currency pairs.
+func NewFX(names []string) FXSource {
+	if len(names) == 1 && names[0] == "simulator" {
+		return fxQuotes{NewSimulator()}
+	}
+	return fxQuotes{NewYahoo()}
+}
+
+// fxQuotes reads rates from a provider's quotes for "EURUSD=X" style pairs.
+type fxQuotes struct {
+	p Provider
+}
+
+func (f fxQuotes) GetRates(codes []string, to string) (map[string]float64, error) {
+	pairs := make(map[string]string, len(codes))
+	symbols := make([]string, 0, len(codes))
+	for _, c := range codes {
+		sym := market.FXSymbol(c, to)
+		pairs[sym] = c
+		symbols = append(symbols, sym)
+	}
+	quotes, err := f.p.GetQuotes(symbols)
+	if err != nil {
+		return nil, fmt.Errorf("fx rates: %w", err)
+	}
+	rates := make(map[string]float64, len(quotes))
+	for _, q := range quotes {
+		if c, ok := pairs[q.Symbol]; ok && q.Price > 0 {
+			rates[c] = q.Price
+		}
+	}
+	return rates, nil
+}
@@ -0,0 +1,362 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Generic reads any JSON API described in the config's [generic] table:
+// URL templates to request and paths to the fields in the responses.
+type Generic struct {
+	cfg    models.GenericProvider
+	header http.Header
+}
+
+func newGeneric(opts Options) (*Generic, error) {
+	if err := checkGeneric(opts.Generic); err != nil {
+		return nil, err
+	}
+	g := &Generic{cfg: opts.Generic, header: make(http.Header)}
+	for k, v := range opts.Generic.Headers {
+		g.header.Set(k, os.ExpandEnv(v))
+	}
+	return g, nil
+}
+
+// checkGeneric reports what the generic provider's config is missing.
+func checkGeneric(g models.GenericProvider) error {
+	switch {
+	case g.QuoteURL == "":
+		return errors.New("generic provider: quote_url is required")
+	case !strings.Contains(g.QuoteURL, "{symbol}"):
+		return errors.New("generic provider: quote_url needs a {symbol} placeholder")
+	case g.Quote.Price == "":
+		return errors.New("generic provider: quote.price is required")
+	case g.HistoryURL != "" && !strings.Contains(g.HistoryURL, "{symbol}"):
+		return errors.New("generic provider: history_url needs a {symbol} placeholder")
+	case g.HistoryURL != "" && (g.History.Time == "" || g.History.Close == ""):
+		return errors.New("generic provider: history.time and history.close are required with history_url")
+	}
+	return nil
+}
+
+func (g *Generic) Name() string {
+	if g.cfg.Name != "" {
+		return g.cfg.Name
+	}
+	return "Generic"
+}
+
+// get expands a URL template and decodes the JSON response. $NAME and
+// ${NAME} come from the environment, so keys can stay out of the config;
+// the {placeholders} are filled from vars.
+func (g *Generic) get(template string, vars map[string]string) (any, error) {
+	pairs := make([]string, 0, 2*len(vars))
+	for k, v := range vars {
+		pairs = append(pairs, "{"+k+"}", url.QueryEscape(v))
+	}
+	u := strings.NewReplacer(pairs...).Replace(os.ExpandEnv(template))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	opts := defaultFetchOptions()
+	opts.Header = g.header
+	body, err := fetch(ctx, u, &opts)
+	if err != nil {
+		// A network error quotes the URL, which may hold a key
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			return nil, fmt.Errorf("request failed: %w", uerr.Err)
+		}
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("unexpected response: not JSON")
+	}
+	return v, nil
+}
+
+// lookup follows a dot path such as "data.quotes.0.price" through decoded
+// JSON. Numbers index arrays, negative ones from the end ("-1" is the
+// last element); an empty path is the value itself.
+func lookup(v any, path string) (any, bool) {
+	if path == "" {
+		return v, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			v = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, false
+			}
+			if i < 0 {
+				i += len(node)
+			}
+			if i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// numberAt reads a number, or a numeric string, at path.
+func numberAt(v any, path string) (float64, bool) {
+	if path == "" {
+		return 0, false
+	}
+	x, ok := lookup(v, path)
+	if !ok {
+		return 0, false
+	}
+	var s string
+	switch x := x.(type) {
+	case json.Number:
+		s = x.String()
+	case string:
+		s = x
+	default:
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f, err == nil
+}
+
+// timeAt reads a timestamp at path in the configured format.
+func (g *Generic) timeAt(v any, path string) (time.Time, bool) {
+	switch format := g.cfg.History.TimeFormat; format {
+	case "", "unix", "unix_ms":
+		f, ok := numberAt(v, path)
+		if !ok || f <= 0 {
+			return time.Time{}, false
+		}
+		if format == "unix_ms" {
+			return time.UnixMilli(int64(f)), true
+		}
+		return time.Unix(int64(f), 0), true
+	default:
+		x, _ := lookup(v, path)
+		s, ok := x.(string)
+		if !ok {
+			return time.Time{}, false
+		}
+		if format == "rfc3339" {
+			format = time.RFC3339
+		}
+		t, err := time.Parse(format, s)
+		return t, err == nil
+	}
+}
+
+// GetQuotes requests each symbol from quote_url in turn.
+func (g *Generic) GetQuotes(symbols []string) ([]models.Quote, error) {
+	q := g.cfg.Quote
+	now := time.Now()
+	quotes := make([]models.Quote, 0, len(symbols))
+	for _, sym := range symbols {
+		v, err := g.get(g.cfg.QuoteURL, map[string]string{"symbol": sym})
+		if err != nil {
+			return nil, err
+		}
+		price, ok := numberAt(v, q.Price)
+		if !ok {
+			return nil, fmt.Errorf("unexpected response: no %s", q.Price)
+		}
+		if !usable(price) {
+			continue
+		}
+		quote := models.Quote{Symbol: sym, Price: price, Currency: market.ExchangeFor(sym).Currency, LastUpdated: now}
+		if prev, ok := numberAt(v, q.PrevClose); ok && usable(prev) {
+			quote.PreviousClose = prev
+		}
+		if pct, ok := numberAt(v, q.ChangePct); ok {
+			quote.ChangePct = pct
+		} else if chg, ok := numberAt(v, q.Change); ok && price != chg {
+			quote.ChangePct = chg / (price - chg) * 100
+		} else if prev, ok := numberAt(v, q.PrevClose); ok && usable(prev) {
+			quote.ChangePct = (price/prev - 1) * 100
+		}
+		if q.Currency != "" {
+			if c, ok := lookup(v, q.Currency); ok {
+				if s, ok := c.(string); ok && s != "" {
+					quote.Currency = s
+				}
+			}
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes, nil
+}
+
+// genericIntervals names the candle widths for the {interval} placeholder,
+// unless the config's intervals table renames them.
+var genericIntervals = []struct {
+	name string
+	d    time.Duration
+}{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"1d", 24 * time.Hour},
+}
+
+// genericInterval picks the candle width for tr: the chosen interval when
+// it fits, else one suited to the range.
+func genericInterval(tr models.TimeRange, now time.Time) time.Duration {
+	if step, ok := candleStep(tr, now); ok {
+		return step
+	}
+	switch tr.Base() {
+	case models.Range1H:
+		return time.Minute
+	case models.Range24H:
+		return 5 * time.Minute
+	case models.Range7D:
+		return 15 * time.Minute
+	case models.Range30D:
+		return time.Hour
+	case models.Range90D, models.RangeYTD, models.Range1Y, models.Range5Y:
+		return 24 * time.Hour
+	}
+	switch span := tr.End(now).Sub(tr.Start(now)); {
+	case span <= 2*24*time.Hour:
+		return 5 * time.Minute
+	case span <= 60*24*time.Hour:
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// intervalName returns the name of a candle width in genericIntervals.
+func intervalName(step time.Duration) string {
+	for _, iv := range genericIntervals {
+		if iv.d == step {
+			return iv.name
+		}
+	}
+	return ""
+}
+
+// GetHistory fills history_url's {symbol}, {from} and {to} (Unix seconds),
+// {from_ms} and {to_ms}, {from_date} and {to_date} (YYYY-MM-DD) and
+// {interval}.
+func (g *Generic) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	if g.cfg.HistoryURL == "" {
+		return nil, errors.New("generic provider: no history_url configured")
+	}
+	now := time.Now()
+	start, end := tr.Start(now), tr.End(now)
+	interval := intervalName(genericInterval(tr, now))
+	if name, ok := g.cfg.Intervals[interval]; ok {
+		interval = name
+	}
+	v, err := g.get(g.cfg.HistoryURL, map[string]string{
+		"symbol":    symbol,
+		"from":      strconv.FormatInt(start.Unix(), 10),
+		"to":        strconv.FormatInt(end.Unix(), 10),
+		"from_ms":   strconv.FormatInt(start.UnixMilli(), 10),
+		"to_ms":     strconv.FormatInt(end.UnixMilli(), 10),
+		"from_date": start.Format("2006-01-02"),
+		"to_date":   end.Format("2006-01-02"),
+		"interval":  interval,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h := g.cfg.History
+	// With a candles array each field is read from one element; without,
+	// each path leads to an array and the field is read at the index
+	var rows []any
+	at := func(path string, i int) string { return path }
+	if h.Candles != "" {
+		arr, _ := lookup(v, h.Candles)
+		rows, _ = arr.([]any)
+	} else {
+		arr, _ := lookup(v, h.Time)
+		times, _ := arr.([]any)
+		rows = make([]any, len(times))
+		for i := range rows {
+			rows[i] = v
+		}
+		at = func(path string, i int) string {
+			if path == "" {
+				return ""
+			}
+			return path + "." + strconv.Itoa(i)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no data for %s", symbol)
+	}
+
+	raw := make([]models.Candle, 0, len(rows))
+	d := Drops{Total: len(rows)}
+	for i, row := range rows {
+		ts, ok := g.timeAt(row, at(h.Time, i))
+		if !ok {
+			d.Dropped++
+			continue
+		}
+		num := func(path string) float64 {
+			f, _ := numberAt(row, at(path, i))
+			return f
+		}
+		raw = append(raw, models.Candle{
+			Timestamp: ts,
+			Open:      num(h.Open),
+			High:      num(h.High),
+			Low:       num(h.Low),
+			Close:     num(h.Close),
+			Volume:    num(h.Volume),
+		})
+	}
+	candles, bad := cleanCandles(raw)
+	d.Dropped += bad
+	noteDrops(symbol, tr, d)
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no valid candles for %s", symbol)
+	}
+	return candles, nil
+}
+
+// GetFundamentals has no endpoint to read; with history configured, the
+// 52-week range comes from a year of daily candles.
+func (g *Generic) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	f := models.Fundamentals{Symbol: symbol, Currency: market.ExchangeFor(symbol).Currency}
+	if g.cfg.HistoryURL == "" {
+		return f, nil
+	}
+	high, low, err := yearRange(g, symbol)
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
+	f.High52, f.Low52 = high, low
+	return f, nil
+}
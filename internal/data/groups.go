@@ -0,0 +1,224 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// GroupCurrency marks group quotes, which are index points rather than a
+// price in any currency.
+const GroupCurrency = "PTS"
+
+// Groups resolves symbol groups (baskets) on top of another provider. A
+// group symbol is quoted as a weighted index that stands at 100 at the
+// previous close, and its history is the weighted index of its members
+// rebased to 100 at the start of the range. Other symbols pass through.
+type Groups struct {
+	base   Provider
+	groups map[string]models.SymbolGroup
+}
+
+// WithGroups wraps p so the configured groups can be quoted and charted.
+// It returns p unchanged when there are no groups.
+func WithGroups(p Provider, groups []models.SymbolGroup) Provider {
+	if len(groups) == 0 {
+		return p
+	}
+	g := &Groups{base: p, groups: make(map[string]models.SymbolGroup, len(groups))}
+	for _, grp := range groups {
+		if grp.Name != "" && len(grp.Symbols) > 0 {
+			g.groups[strings.ToUpper(grp.Name)] = grp
+		}
+	}
+	return g
+}
+
+func (g *Groups) Name() string { return g.base.Name() }
+
+func (g *Groups) cachedHistory(symbol string, tr models.TimeRange) (time.Duration, bool, bool) {
+	if grp, ok := g.group(symbol); ok {
+		return oldestCached(g.base, grp.Symbols, tr)
+	}
+	return CachedHistory(g.base, symbol, tr)
+}
+
+func (g *Groups) group(symbol string) (models.SymbolGroup, bool) {
+	grp, ok := g.groups[strings.ToUpper(symbol)]
+	return grp, ok
+}
+
+func (g *Groups) GetQuotes(symbols []string) ([]models.Quote, error) {
+	requested := make(map[string]bool)
+	var fetch []string
+	add := func(s string) {
+		if !requested[s] {
+			requested[s] = true
+			fetch = append(fetch, s)
+		}
+	}
+
+	var groups []string // group symbols as requested
+	direct := make(map[string]bool)
+	for _, s := range symbols {
+		if grp, ok := g.group(s); ok {
+			groups = append(groups, s)
+			for _, member := range grp.Symbols {
+				add(member)
+			}
+			continue
+		}
+		direct[s] = true
+		add(s)
+	}
+
+	quotes, err := g.base.GetQuotes(fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	bySym := make(map[string]models.Quote, len(quotes))
+	out := make([]models.Quote, 0, len(symbols))
+	for _, q := range quotes {
+		bySym[q.Symbol] = q
+		if direct[q.Symbol] {
+			out = append(out, q)
+		}
+	}
+
+	for _, sym := range groups {
+		grp, _ := g.group(sym)
+		var sum, total float64
+		var updated time.Time
+		for i, member := range grp.Symbols {
+			q, ok := bySym[member]
+			if !ok {
+				continue
+			}
+			w := grp.Weight(i)
+			sum += w * q.ChangePct
+			total += w
+			if q.LastUpdated.After(updated) {
+				updated = q.LastUpdated
+			}
+		}
+		if total == 0 {
+			continue
+		}
+		pct := sum / total
+		out = append(out, models.Quote{
+			Symbol:      sym,
+			Price:       100 * (1 + pct/100),
+			ChangePct:   pct,
+			Currency:    GroupCurrency,
+			LastUpdated: updated,
+		})
+	}
+	return out, nil
+}
+
+// GetFundamentals passes through for plain symbols. A basket has no
+// statistics of its own beyond its index's 52-week range.
+func (g *Groups) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	if _, ok := g.group(symbol); !ok {
+		return g.base.GetFundamentals(symbol)
+	}
+	high, low, err := yearRange(g, symbol)
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
+	return models.Fundamentals{Symbol: symbol, Currency: GroupCurrency, High52: high, Low52: low}, nil
+}
+
+func (g *Groups) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	grp, ok := g.group(symbol)
+	if !ok {
+		return g.base.GetHistory(symbol, tr)
+	}
+
+	series, errs := fetchHistories(g.base, grp.Symbols, tr)
+
+	// Members that failed are left out of the index
+	var members [][]models.Candle
+	var weights []float64
+	var firstErr error
+	for i, s := range series {
+		if errs[i] != nil || len(s) == 0 {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		members = append(members, s)
+		weights = append(weights, grp.Weight(i))
+	}
+	if len(members) == 0 {
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return nil, fmt.Errorf("no history for group %s", grp.Name)
+	}
+	members, length := alignTail(members)
+
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+
+	candles := make([]models.Candle, length)
+	for t := 0; t < length; t++ {
+		var c models.Candle
+		for j, window := range members {
+			base := window[0].Close
+			if base == 0 {
+				continue
+			}
+			w := weights[j] / total * 100 / base
+			k := window[t]
+			c.Open += w * k.Open
+			c.High += w * k.High
+			c.Low += w * k.Low
+			c.Close += w * k.Close
+			if j == 0 {
+				c.Timestamp = k.Timestamp
+			}
+		}
+		candles[t] = c
+	}
+	return candles, nil
+}
+
+// fetchHistories loads history for several symbols concurrently.
+func fetchHistories(p Provider, symbols []string, tr models.TimeRange) ([][]models.Candle, []error) {
+	series := make([][]models.Candle, len(symbols))
+	errs := make([]error, len(symbols))
+	var wg sync.WaitGroup
+	for i, sym := range symbols {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			series[i], errs[i] = p.GetHistory(sym, tr)
+		}()
+	}
+	wg.Wait()
+	return series, errs
+}
+
+// alignTail trims every series to the length of the shortest, keeping the
+// most recent candles, since providers sample at slightly different times.
+func alignTail(series [][]models.Candle) ([][]models.Candle, int) {
+	length := -1
+	for _, s := range series {
+		if length < 0 || len(s) < length {
+			length = len(s)
+		}
+	}
+	out := make([][]models.Candle, len(series))
+	for i, s := range series {
+		out[i] = s[len(s)-length:]
+	}
+	return out, length
+}
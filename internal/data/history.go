@@ -0,0 +1,60 @@
+package data
+
+import (
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// maxHistoryPages bounds a paginated history fetch so a very long window
+// cannot turn into an unbounded run of requests.
+const maxHistoryPages = 20
+
+// maxIntervalCandles bounds the candles a chosen interval may produce, so
+// a fine interval over a long range falls back to the provider's own
+// choice instead of a long run of pages.
+const maxIntervalCandles = 10000
+
+// candleStep returns the candle width chosen for tr, when one is chosen and
+// the range spans a reasonable number of candles at that width.
+func candleStep(tr models.TimeRange, now time.Time) (time.Duration, bool) {
+	step := tr.Interval().Duration()
+	if step == 0 {
+		return 0, false
+	}
+	n := tr.End(now).Sub(tr.Start(now)) / step
+	return step, n >= 2 && n <= maxIntervalCandles
+}
+
+// GetHistoryBetween fetches history for an explicit period. Providers
+// paginate where their API caps the candles per request, so the period can
+// reach well past the standard ranges.
+func GetHistoryBetween(p Provider, symbol string, start, end time.Time) ([]models.Candle, error) {
+	return p.GetHistory(symbol, models.WindowRange(start, end))
+}
+
+// paginate collects candles from start to end a page at a time. page
+// returns candles in time order beginning at from; the next page starts
+// one step after the last candle received. It stops at end, on an empty
+// page or after maxHistoryPages.
+func paginate(start, end time.Time, step time.Duration, page func(from time.Time) ([]models.Candle, error)) ([]models.Candle, error) {
+	var out []models.Candle
+	from := start
+	for i := 0; i < maxHistoryPages && from.Before(end); i++ {
+		batch, err := page(from)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range batch {
+			if len(out) > 0 && !c.Timestamp.After(out[len(out)-1].Timestamp) {
+				continue
+			}
+			out = append(out, c)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		from = batch[len(batch)-1].Timestamp.Add(step)
+	}
+	return out, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -37,9 +38,28 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
 }
 
+// retryAfter reads how long a 429 asks to wait: Retry-After in seconds,
+// or X-Ratelimit-Reset as a Unix time (Finnhub). It defaults to a minute.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	if s := h.Get("Retry-After"); s != "" {
+		if d, err := time.ParseDuration(s + "s"); err == nil {
+			return d
+		}
+	}
+	if s := h.Get("X-Ratelimit-Reset"); s != "" {
+		if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+			if d := time.Unix(sec, 0).Sub(now); d > 0 {
+				return d.Truncate(time.Second) + time.Second
+			}
+		}
+	}
+	return 60 * time.Second
+}
+
 type fetchOptions struct {
 	MaxRetries int
 	BaseDelay  time.Duration
+	Header     http.Header // added to every request, e.g. an API key
 }
 
 func defaultFetchOptions() fetchOptions {
@@ -66,12 +86,25 @@ func fetch(ctx context.Context, url string, opts *fetchOptions) ([]byte, error)
 			}
 		}
 
+		// Wait for the provider's budget. Callers' timeouts are meant for
+		// the request, so time spent queued pushes the deadline back.
+		if queued := requests.wait(url); queued > 0 {
+			if deadline, ok := ctx.Deadline(); ok {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(context.WithoutCancel(ctx), deadline.Add(queued))
+				defer cancel()
+			}
+		}
+
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		if err != nil {
 			return nil, err
 		}
 		req.Header.Set("User-Agent", "stock-tui/1.0")
 		req.Header.Set("Accept", "application/json")
+		for k, v := range opts.Header {
+			req.Header[k] = v
+		}
 
 		resp, err := defaultClient.Do(req)
 		if err != nil {
@@ -89,14 +122,7 @@ func fetch(ctx context.Context, url string, opts *fetchOptions) ([]byte, error)
 
 		if resp.StatusCode == http.StatusTooManyRequests {
 			// Do not retry 429 inside the library, let the app handle it
-			retryStr := resp.Header.Get("Retry-After")
-			retryAfter := 60 * time.Second // default
-			if retryStr != "" {
-				if d, err := time.ParseDuration(retryStr + "s"); err == nil {
-					retryAfter = d
-				}
-			}
-			return nil, &RateLimitError{RetryAfter: retryAfter}
+			return nil, &RateLimitError{RetryAfter: retryAfter(resp.Header, time.Now())}
 		}
 
 		if resp.StatusCode != http.StatusOK {
@@ -0,0 +1,49 @@
+package data
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Instances sharing a cache directory take a lock before fetching what
+// the cache lacks, so the others wait for the entry instead of spending
+// their own requests on it. A lock is a file created exclusively, which
+// behaves the same on every platform.
+const (
+	lockPoll  = 100 * time.Millisecond
+	lockStale = time.Minute // older locks belong to an instance that died holding them
+)
+
+// lock takes the lock file at path, waiting up to wait for another holder.
+// While waiting it calls ready and returns early, without the lock, once
+// ready reports true: the holder has written what the caller needs. It
+// also gives up at wait, so a stuck instance only slows the others down.
+// The returned release is safe to call either way.
+func lock(path string, wait time.Duration, ready func() bool) (release func()) {
+	noop := func() {}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return noop
+	}
+	deadline := time.Now().Add(wait)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return noop
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockStale {
+			os.Remove(path)
+			continue
+		}
+		if ready() || time.Now().After(deadline) {
+			return noop
+		}
+		time.Sleep(lockPoll)
+	}
+}
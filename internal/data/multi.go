@@ -1,70 +1,71 @@
 package data
 
 import (
-	"strings"
 	"sync"
 
+	"github.com/ni5arga/stock-tui/internal/market"
 	"github.com/ni5arga/stock-tui/internal/models"
 )
 
 type Multi struct {
 	crypto Provider
 	stocks Provider
+	yields Provider // the yields Yahoo has no feed for
 }
 
-func NewMulti() *Multi {
+func newMulti(opts Options) *Multi {
 	return &Multi{
 		crypto: NewCoinGecko(),
-		stocks: NewYahoo(),
+		stocks: newYahoo(opts),
+		yields: NewStooq(),
 	}
 }
 
 func (m *Multi) Name() string { return "Multi (CoinGecko + Yahoo)" }
 
+// cryptoBases are the coins routed to the crypto provider. Pairs may use
+// any supported fiat quote currency (BTC-USD, ETH-EUR).
+var cryptoBases = map[string]bool{
+	"BTC": true, "ETH": true, "SOL": true, "XRP": true, "DOGE": true,
+	"ADA": true, "DOT": true, "AVAX": true, "MATIC": true, "LINK": true,
+}
+
 func (m *Multi) isCrypto(symbol string) bool {
-	sym := strings.ToUpper(symbol)
-	cryptoSymbols := map[string]bool{
-		"BTC": true, "BTC-USD": true,
-		"ETH": true, "ETH-USD": true,
-		"SOL": true, "SOL-USD": true,
-		"XRP": true, "XRP-USD": true,
-		"DOGE": true, "DOGE-USD": true,
-		"ADA": true, "ADA-USD": true,
-		"DOT": true, "DOT-USD": true,
-		"AVAX": true, "AVAX-USD": true,
-		"MATIC": true, "MATIC-USD": true,
-		"LINK": true, "LINK-USD": true,
+	base, _ := market.SplitPair(symbol)
+	return cryptoBases[base]
+}
+
+// route returns the provider for symbol: CoinGecko for the listed coins,
+// Stooq for the yields Yahoo lacks (DE10Y) and Yahoo for the rest.
+func (m *Multi) route(symbol string) Provider {
+	if m.isCrypto(symbol) {
+		return m.crypto
 	}
-	return cryptoSymbols[sym]
+	if _, ok := market.StooqYield(symbol); ok {
+		return m.yields
+	}
+	return m.stocks
 }
 
 func (m *Multi) GetQuotes(symbols []string) ([]models.Quote, error) {
-	var cryptoSyms, stockSyms []string
+	var routes []Provider
+	groups := make(map[Provider][]string)
 	for _, s := range symbols {
-		if m.isCrypto(s) {
-			cryptoSyms = append(cryptoSyms, s)
-		} else {
-			stockSyms = append(stockSyms, s)
+		p := m.route(s)
+		if _, ok := groups[p]; !ok {
+			routes = append(routes, p)
 		}
+		groups[p] = append(groups[p], s)
 	}
 
 	var wg sync.WaitGroup
-	var cryptoQuotes, stockQuotes []models.Quote
-	var cryptoErr, stockErr error
-
-	if len(cryptoSyms) > 0 {
+	results := make([][]models.Quote, len(routes))
+	errs := make([]error, len(routes))
+	for i, p := range routes {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			cryptoQuotes, cryptoErr = m.crypto.GetQuotes(cryptoSyms)
-		}()
-	}
-
-	if len(stockSyms) > 0 {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			stockQuotes, stockErr = m.stocks.GetQuotes(stockSyms)
+			results[i], errs[i] = p.GetQuotes(groups[p])
 		}()
 	}
 
@@ -72,25 +73,26 @@ func (m *Multi) GetQuotes(symbols []string) ([]models.Quote, error) {
 
 	// Return partial results even if one fails
 	var quotes []models.Quote
-	quotes = append(quotes, cryptoQuotes...)
-	quotes = append(quotes, stockQuotes...)
+	for _, r := range results {
+		quotes = append(quotes, r...)
+	}
 
-	// Prioritize returning data; only error if both failed
+	// Prioritize returning data; only error if all failed
 	if len(quotes) == 0 {
-		if cryptoErr != nil {
-			return nil, cryptoErr
-		}
-		if stockErr != nil {
-			return nil, stockErr
+		for _, err := range errs {
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return quotes, nil
 }
 
+func (m *Multi) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	return m.route(symbol).GetFundamentals(symbol)
+}
+
 func (m *Multi) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
-	if m.isCrypto(symbol) {
-		return m.crypto.GetHistory(symbol, tr)
-	}
-	return m.stocks.GetHistory(symbol, tr)
+	return m.route(symbol).GetHistory(symbol, tr)
 }
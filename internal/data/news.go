@@ -0,0 +1,18 @@
+package data
+
+import "github.com/ni5arga/stock-tui/internal/models"
+
+// NewsProvider is implemented by sources that carry headlines.
+type NewsProvider interface {
+	GetNews(symbol string) ([]models.Headline, error)
+}
+
+// NewNews returns the headline source for a provider chain: made-up
+// headlines when the chain is only the simulator, so it stays offline, and
+// Yahoo Finance news otherwise.
+func NewNews(names []string) NewsProvider {
+	if len(names) == 1 && names[0] == "simulator" {
+		return NewSimulator()
+	}
+	return NewYahoo()
+}
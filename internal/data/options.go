@@ -0,0 +1,40 @@
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// OptionsTTL is how long an option chain stays fresh. Quotes on a chain
+// move with the underlying, but the tab is for browsing strikes rather
+// than trading off it.
+const OptionsTTL = 5 * time.Minute
+
+// OptionsProvider is implemented by sources that carry option chains.
+// A zero expiry asks for the nearest one.
+type OptionsProvider interface {
+	GetOptionChain(symbol string, expiry time.Time) (models.OptionChain, error)
+}
+
+// NewOptions returns the option chain source for a provider chain,
+// following NewNews: the simulator's made-up chains when it is the only
+// provider, Yahoo Finance otherwise.
+func NewOptions(names []string) OptionsProvider {
+	if len(names) == 1 && names[0] == "simulator" {
+		return NewSimulator()
+	}
+	return NewYahoo()
+}
+
+// checkOptionable rejects the asset classes that have no listed options
+// here, before any request is made.
+func checkOptionable(symbol string) error {
+	switch market.AssetClass(symbol) {
+	case market.ClassStock, market.ClassIndex:
+		return nil
+	}
+	return fmt.Errorf("no options listed for %s", symbol)
+}
@@ -0,0 +1,37 @@
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// OrderBookRefresh is how often the order book pane is polled while it is
+// shown.
+const OrderBookRefresh = 2 * time.Second
+
+// OrderBookProvider is implemented by sources that carry order books.
+type OrderBookProvider interface {
+	GetOrderBook(symbol string, depth int) (models.OrderBook, error)
+}
+
+// NewOrderBook returns the order book source for a provider chain,
+// following NewNews: the simulator's made-up books when it is the only
+// provider, Binance otherwise.
+func NewOrderBook(names []string) OrderBookProvider {
+	if len(names) == 1 && names[0] == "simulator" {
+		return NewSimulator()
+	}
+	return NewBinance()
+}
+
+// checkBookable rejects everything but crypto pairs before any request is
+// made.
+func checkBookable(symbol string) error {
+	if market.AssetClass(symbol) != market.ClassCrypto {
+		return fmt.Errorf("no order book for %s", symbol)
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// pluginTimeout bounds how long a plugin may take to answer. A plugin
+// that misses it is stopped and started again on the next request.
+const pluginTimeout = 15 * time.Second
+
+// Plugin runs an external program as a data source. The program stays
+// running and reads one JSON request per line on stdin, writing one JSON
+// response per line on stdout:
+//
+//	{"id":1,"method":"get_quotes","symbols":["AAPL"]}
+//	{"id":1,"quotes":[{"symbol":"AAPL","price":189.5,"change_pct":1.2}]}
+//
+//	{"id":2,"method":"get_history","symbol":"AAPL","range":"24H","from":1700000000,"to":1700086400,"interval":"5m"}
+//	{"id":2,"candles":[{"time":1700000000,"open":189,"high":190,"low":188.5,"close":189.5,"volume":1200}]}
+//
+// Any response may instead carry "error", and "retry_after" in seconds to
+// report a rate limit. The program should exit when stdin closes.
+type Plugin struct {
+	cfg models.PluginProvider
+
+	mu    sync.Mutex // one request at a time
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan []byte // response lines, closed when stdout ends
+	next  int
+}
+
+// pluginRequest is one request line. Fields a method does not use are
+// left out.
+type pluginRequest struct {
+	ID       int      `json:"id"`
+	Method   string   `json:"method"`
+	Symbols  []string `json:"symbols,omitempty"`
+	Symbol   string   `json:"symbol,omitempty"`
+	Range    string   `json:"range,omitempty"`
+	From     int64    `json:"from,omitempty"`
+	To       int64    `json:"to,omitempty"`
+	Interval string   `json:"interval,omitempty"`
+}
+
+type pluginResponse struct {
+	ID         int           `json:"id"`
+	Error      string        `json:"error"`
+	RetryAfter float64       `json:"retry_after"`
+	Quotes     []pluginQuote `json:"quotes"`
+	Candles    []struct {
+		Time   int64   `json:"time"`
+		Open   float64 `json:"open"`
+		High   float64 `json:"high"`
+		Low    float64 `json:"low"`
+		Close  float64 `json:"close"`
+		Volume float64 `json:"volume"`
+	} `json:"candles"`
+}
+
+type pluginQuote struct {
+	Symbol        string  `json:"symbol"`
+	Price         float64 `json:"price"`
+	ChangePct     float64 `json:"change_pct"`
+	Currency      string  `json:"currency"`
+	PreviousClose float64 `json:"previous_close"`
+}
+
+func newPlugin(opts Options) (*Plugin, error) {
+	if err := checkPlugin(opts.Plugin); err != nil {
+		return nil, err
+	}
+	return &Plugin{cfg: opts.Plugin}, nil
+}
+
+// checkPlugin reports a plugin config without a runnable command.
+func checkPlugin(p models.PluginProvider) error {
+	if len(p.Command) == 0 || p.Command[0] == "" {
+		return errors.New("plugin provider: command is required")
+	}
+	if _, err := exec.LookPath(os.ExpandEnv(p.Command[0])); err != nil {
+		return fmt.Errorf("plugin provider: %w", err)
+	}
+	return nil
+}
+
+func (p *Plugin) Name() string {
+	if p.cfg.Name != "" {
+		return p.cfg.Name
+	}
+	return "Plugin"
+}
+
+// start runs the program unless it is already running. The caller holds
+// p.mu.
+func (p *Plugin) start() error {
+	if p.cmd != nil {
+		return nil
+	}
+	args := make([]string, len(p.cfg.Command))
+	for i, a := range p.cfg.Command {
+		args[i] = os.ExpandEnv(a)
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	// Stderr would draw over the screen, so it goes nowhere
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("plugin: %w", err)
+	}
+
+	lines := make(chan []byte)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(stdout)
+		sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for sc.Scan() {
+			lines <- append([]byte(nil), sc.Bytes()...)
+		}
+	}()
+	p.cmd, p.stdin, p.lines = cmd, stdin, lines
+	return nil
+}
+
+// stop ends the program, to be started afresh by the next request. The
+// caller holds p.mu.
+func (p *Plugin) stop() {
+	if p.cmd == nil {
+		return
+	}
+	p.stdin.Close()
+	p.cmd.Process.Kill()
+	go func(lines chan []byte, cmd *exec.Cmd) {
+		for range lines {
+		}
+		cmd.Wait()
+	}(p.lines, p.cmd)
+	p.cmd, p.stdin, p.lines = nil, nil, nil
+}
+
+// call sends req and waits for the response with its id, skipping any
+// stale ones left by an earlier request.
+func (p *Plugin) call(req pluginRequest) (pluginResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.start(); err != nil {
+		return pluginResponse{}, err
+	}
+	p.next++
+	req.ID = p.next
+	line, err := json.Marshal(req)
+	if err != nil {
+		return pluginResponse{}, err
+	}
+	if _, err := p.stdin.Write(append(line, '\n')); err != nil {
+		p.stop()
+		return pluginResponse{}, fmt.Errorf("plugin exited: %w", err)
+	}
+
+	timeout := time.After(pluginTimeout)
+	for {
+		select {
+		case line, ok := <-p.lines:
+			if !ok {
+				p.stop()
+				return pluginResponse{}, errors.New("plugin exited")
+			}
+			var resp pluginResponse
+			if err := json.Unmarshal(line, &resp); err != nil {
+				continue // not a response, e.g. a debug print
+			}
+			if resp.ID != req.ID {
+				continue
+			}
+			if resp.RetryAfter > 0 {
+				return resp, &RateLimitError{RetryAfter: time.Duration(resp.RetryAfter * float64(time.Second))}
+			}
+			if resp.Error != "" {
+				return resp, errors.New(resp.Error)
+			}
+			return resp, nil
+		case <-timeout:
+			p.stop()
+			return pluginResponse{}, fmt.Errorf("plugin did not answer %s within %s", req.Method, pluginTimeout)
+		}
+	}
+}
+
+func (p *Plugin) GetQuotes(symbols []string) ([]models.Quote, error) {
+	resp, err := p.call(pluginRequest{Method: "get_quotes", Symbols: symbols})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	quotes := make([]models.Quote, 0, len(resp.Quotes))
+	for _, q := range resp.Quotes {
+		if q.Symbol == "" || !usable(q.Price) {
+			continue
+		}
+		currency := q.Currency
+		if currency == "" {
+			currency = market.ExchangeFor(q.Symbol).Currency
+		}
+		quotes = append(quotes, models.Quote{
+			Symbol:        q.Symbol,
+			Price:         q.Price,
+			ChangePct:     q.ChangePct,
+			Currency:      currency,
+			LastUpdated:   now,
+			PreviousClose: q.PreviousClose,
+		})
+	}
+	return quotes, nil
+}
+
+// GetHistory asks for the range by its bounds in Unix seconds, and by
+// name unless it is a custom one, with the candle width the generic
+// provider would use.
+func (p *Plugin) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	now := time.Now()
+	req := pluginRequest{
+		Method:   "get_history",
+		Symbol:   symbol,
+		From:     tr.Start(now).Unix(),
+		To:       tr.End(now).Unix(),
+		Interval: intervalName(genericInterval(tr, now)),
+	}
+	if !tr.IsCustom() {
+		req.Range = string(tr.Base())
+	}
+	resp, err := p.call(req)
+	if err != nil {
+		return nil, err
+	}
+	raw := make([]models.Candle, 0, len(resp.Candles))
+	d := Drops{Total: len(resp.Candles)}
+	for _, c := range resp.Candles {
+		if c.Time <= 0 {
+			d.Dropped++
+			continue
+		}
+		raw = append(raw, models.Candle{
+			Timestamp: time.Unix(c.Time, 0),
+			Open:      c.Open,
+			High:      c.High,
+			Low:       c.Low,
+			Close:     c.Close,
+			Volume:    c.Volume,
+		})
+	}
+	candles, bad := cleanCandles(raw)
+	d.Dropped += bad
+	noteDrops(symbol, tr, d)
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no data for %s", symbol)
+	}
+	return candles, nil
+}
+
+// GetFundamentals has no request of its own; the 52-week range comes from
+// a year of history.
+func (p *Plugin) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	high, low, err := yearRange(p, symbol)
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
+	return models.Fundamentals{Symbol: symbol, Currency: market.ExchangeFor(symbol).Currency, High52: high, Low52: low}, nil
+}
@@ -0,0 +1,184 @@
+package data
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// holding is one symbol of a portfolio and its transactions, oldest first.
+type holding struct {
+	symbol string
+	days   []time.Time
+	shares []float64
+}
+
+// held returns the shares held at t.
+func (h holding) held(t time.Time) float64 {
+	var n float64
+	for i, day := range h.days {
+		if !day.After(t) {
+			n += h.shares[i]
+		}
+	}
+	return n
+}
+
+// holdings groups the transactions by symbol. Transactions with a bad
+// date are an error.
+func holdings(txs []models.Transaction) ([]holding, error) {
+	var out []holding
+	for _, tx := range txs {
+		day, err := tx.Day()
+		if err != nil {
+			return nil, errors.New("portfolio: expected dates like 2024-03-01, got " + tx.Date)
+		}
+		sym := strings.ToUpper(strings.TrimSpace(tx.Symbol))
+		i := slices.IndexFunc(out, func(h holding) bool { return h.symbol == sym })
+		if i < 0 {
+			out = append(out, holding{symbol: sym})
+			i = len(out) - 1
+		}
+		out[i].days = append(out[i].days, day)
+		out[i].shares = append(out[i].shares, tx.Shares)
+	}
+	return out, nil
+}
+
+// CheckPortfolio reports transactions that cannot be read.
+func CheckPortfolio(p models.Portfolio) error {
+	_, err := holdings(p.Transactions)
+	return err
+}
+
+// PortfolioValues returns the market value of each holding at the latest
+// prices, leaving out the ones sold off.
+func PortfolioValues(p Provider, port models.Portfolio) (map[string]float64, error) {
+	all, err := holdings(port.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	shares := make(map[string]float64)
+	var symbols []string
+	for _, h := range all {
+		if n := h.held(now); n != 0 {
+			shares[h.symbol] = n
+			symbols = append(symbols, h.symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+	quotes, err := p.GetQuotes(symbols)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]float64, len(quotes))
+	for _, q := range quotes {
+		if n, ok := shares[strings.ToUpper(q.Symbol)]; ok {
+			values[strings.ToUpper(q.Symbol)] = n * q.Price
+		}
+	}
+	return values, nil
+}
+
+// PortfolioHistory reconstructs the portfolio's value over tr from its
+// transactions and the history of its holdings. Buying and selling move
+// the value without being a gain or loss, so the series is time-weighted:
+// each step compounds the return of the shares held going into it. It is
+// scaled so the last point is the current market value, summed across
+// the holdings' own currencies.
+func PortfolioHistory(p Provider, port models.Portfolio, tr models.TimeRange) ([]models.Candle, error) {
+	all, err := holdings(port.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	start := tr.Start(now)
+
+	// Only holdings with shares in the range need history
+	var hs []holding
+	for _, h := range all {
+		if h.held(start) != 0 || slices.ContainsFunc(h.days, func(d time.Time) bool { return d.After(start) }) {
+			hs = append(hs, h)
+		}
+	}
+	if len(hs) == 0 {
+		return nil, errors.New("portfolio: no holdings in this range")
+	}
+	symbols := make([]string, len(hs))
+	for i, h := range hs {
+		symbols[i] = h.symbol
+	}
+	series, errs := fetchHistories(p, symbols, tr)
+	for i, s := range series {
+		// A missing holding would skew the whole series, so it is an error
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if len(s) == 0 {
+			return nil, errors.New("portfolio: no history for " + symbols[i])
+		}
+	}
+
+	// Holdings trade at different hours (crypto on weekends too), so the
+	// timeline is every candle time of any holding, each priced at its
+	// latest close
+	var times []time.Time
+	for _, s := range series {
+		for _, c := range s {
+			times = append(times, c.Timestamp)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	times = slices.CompactFunc(times, func(a, b time.Time) bool { return a.Equal(b) })
+
+	pos := make([]int, len(series))
+	closeAt := func(j int, t time.Time) float64 {
+		s := series[j]
+		for pos[j]+1 < len(s) && !s[pos[j]+1].Timestamp.After(t) {
+			pos[j]++
+		}
+		return s[pos[j]].Close
+	}
+
+	index := make([]float64, len(times))
+	prev := make([]float64, len(series))
+	held := make([]float64, len(series))
+	var value float64
+	for i, t := range times {
+		var before, after float64
+		value = 0
+		for j := range series {
+			c := closeAt(j, t)
+			before += held[j] * prev[j]
+			after += held[j] * c
+			prev[j] = c
+			held[j] = hs[j].held(t)
+			value += held[j] * c
+		}
+		index[i] = 1
+		if i > 0 {
+			index[i] = index[i-1]
+		}
+		if before > 0 {
+			index[i] *= after / before
+		}
+	}
+
+	scale := 100 / index[len(index)-1]
+	if value > 0 {
+		scale = value / index[len(index)-1]
+	}
+	candles := make([]models.Candle, len(times))
+	for i, t := range times {
+		v := index[i] * scale
+		candles[i] = models.Candle{Timestamp: t, Open: v, High: v, Low: v, Close: v}
+	}
+	return candles, nil
+}
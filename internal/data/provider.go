@@ -7,24 +7,78 @@ import (
 )
 
 // Provider defines the interface for data sources.
+//
+// GetHistory accepts the standard ranges as well as explicit periods:
+// anchored and custom ranges, and windows from models.WindowRange (see
+// GetHistoryBetween). Providers whose API caps a request fetch long
+// periods in pages.
+//
+// GetFundamentals fills in whatever statistics the source carries and
+// leaves the rest zero.
 type Provider interface {
 	Name() string
 	GetQuotes(symbols []string) ([]models.Quote, error)
 	GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error)
+	GetFundamentals(symbol string) (models.Fundamentals, error)
+}
+
+// Options tune how providers fetch data.
+type Options struct {
+	// ExtendedHours includes pre-market and after-hours candles in the
+	// intraday ranges (1H, 24H) of sources that separate them.
+	ExtendedHours bool
+	// LowBandwidth asks for coarser candles on the standard ranges, a
+	// fraction of the points per chart.
+	LowBandwidth bool
+	// Generic describes the API behind the generic provider.
+	Generic models.GenericProvider
+	// Plugin is the program behind the plugin provider.
+	Plugin models.PluginProvider
+	// FinnhubKey is the finnhub provider's API key when FINNHUB_API_KEY
+	// is unset.
+	FinnhubKey string
+}
+
+// CheckProvider reports a named provider that cannot run as configured,
+// such as finnhub without its API key, generic without its URLs or a
+// plugin whose program is not found.
+// Unknown names are not errors here: they fall back to multi.
+func CheckProvider(name string, opts Options) error {
+	switch name {
+	case "finnhub":
+		if finnhubKey(opts) == "" {
+			return errFinnhubKey
+		}
+	case "generic":
+		return checkGeneric(opts.Generic)
+	case "plugin":
+		return checkPlugin(opts.Plugin)
+	}
+	return nil
 }
 
 // NewProvider returns the requested provider implementation.
-func NewProvider(name string) (Provider, error) {
+func NewProvider(name string, opts Options) (Provider, error) {
 	switch name {
 	case "simulator":
 		return NewSimulator(), nil
 	case "coingecko":
 		return NewCoinGecko(), nil
+	case "binance":
+		return newBinance(opts), nil
 	case "yahoo":
-		return NewYahoo(), nil
+		return newYahoo(opts), nil
+	case "finnhub":
+		return newFinnhub(opts)
+	case "stooq":
+		return NewStooq(), nil
+	case "generic":
+		return newGeneric(opts)
+	case "plugin":
+		return newPlugin(opts)
 	case "multi", "auto":
-		return NewMulti(), nil
+		return newMulti(opts), nil
 	default:
-		return NewMulti(), fmt.Errorf("unknown provider %q, using multi", name)
+		return newMulti(opts), fmt.Errorf("unknown provider %q, using multi", name)
 	}
 }
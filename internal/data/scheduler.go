@@ -0,0 +1,145 @@
+package data
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// DefaultRateLimits are the request budgets for each network provider,
+// kept under the free tiers' published or observed limits.
+var DefaultRateLimits = map[string]models.RateLimit{
+	"yahoo":     {PerMinute: 120, Burst: 5},
+	"coingecko": {PerMinute: 25, Burst: 3},
+	"binance":   {PerMinute: 600, Burst: 20},
+	"finnhub":   {PerMinute: 55, Burst: 5},
+	"stooq":     {PerMinute: 30, Burst: 5},
+}
+
+// providerHosts maps API hosts to the provider whose budget they draw on.
+var providerHosts = map[string]string{
+	"query1.finance.yahoo.com": "yahoo",
+	"query2.finance.yahoo.com": "yahoo",
+	"api.coingecko.com":        "coingecko",
+	"api.binance.com":          "binance",
+	"finnhub.io":               "finnhub",
+	"stooq.com":                "stooq",
+}
+
+// bucket is a token bucket for one provider. Callers reserve tokens in
+// arrival order, so tokens may go negative: a negative balance is the
+// queue ahead of the next caller, which waits until it is paid back.
+type bucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(l models.RateLimit) *bucket {
+	burst := float64(max(l.Burst, 1))
+	return &bucket{rate: l.PerMinute / 60, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rate, b.burst)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// scheduler paces outbound requests per provider and counts the ones
+// waiting for their turn.
+type scheduler struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	queued  int
+	changed chan struct{}
+}
+
+var requests = &scheduler{
+	buckets: make(map[string]*bucket),
+	changed: make(chan struct{}, 1),
+}
+
+// SetRateLimits overrides the default budgets of the named providers.
+func SetRateLimits(limits map[string]models.RateLimit) error {
+	requests.mu.Lock()
+	defer requests.mu.Unlock()
+	for name, l := range limits {
+		name = strings.ToLower(name)
+		if _, ok := DefaultRateLimits[name]; !ok {
+			return fmt.Errorf("rate_limits: unknown provider %q (use yahoo, coingecko, binance, finnhub or stooq)", name)
+		}
+		if l.PerMinute <= 0 {
+			return fmt.Errorf("rate_limits: %s needs a per_minute above 0", name)
+		}
+		requests.buckets[name] = newBucket(l)
+	}
+	return nil
+}
+
+// QueueDepth returns the number of requests waiting for their provider's
+// budget.
+func QueueDepth() int {
+	requests.mu.Lock()
+	defer requests.mu.Unlock()
+	return requests.queued
+}
+
+// QueueChanges signals, at most once per read, that QueueDepth changed.
+func QueueChanges() <-chan struct{} {
+	return requests.changed
+}
+
+// wait blocks until rawURL's provider has budget for one more request and
+// returns the time spent queued. Hosts without a budget pass straight
+// through.
+func (s *scheduler) wait(rawURL string) time.Duration {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0
+	}
+	name, ok := providerHosts[u.Hostname()]
+	if !ok {
+		return 0
+	}
+
+	s.mu.Lock()
+	b, ok := s.buckets[name]
+	if !ok {
+		b = newBucket(DefaultRateLimits[name])
+		s.buckets[name] = b
+	}
+	delay := b.reserve(time.Now())
+	if delay == 0 {
+		s.mu.Unlock()
+		return 0
+	}
+	s.queued++
+	s.mu.Unlock()
+	s.notify()
+
+	time.Sleep(delay)
+
+	s.mu.Lock()
+	s.queued--
+	s.mu.Unlock()
+	s.notify()
+	return delay
+}
+
+func (s *scheduler) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,19 @@
+package data
+
+import "github.com/ni5arga/stock-tui/internal/models"
+
+// SymbolSearcher is implemented by sources with a symbol-lookup endpoint.
+type SymbolSearcher interface {
+	SearchSymbols(query string) ([]models.SymbolMatch, error)
+}
+
+// NewSearch returns the symbol lookup for a provider chain. Like NewNews,
+// a simulator-only chain searches the simulator's own symbols so it stays
+// offline, and anything else uses Yahoo Finance, which covers stocks,
+// ETFs, indices and the major crypto pairs.
+func NewSearch(names []string) SymbolSearcher {
+	if len(names) == 1 && names[0] == "simulator" {
+		return NewSimulator()
+	}
+	return NewYahoo()
+}
@@ -1,10 +1,16 @@
 package data
 
 import (
+	"fmt"
+	"hash/fnv"
 	"math"
 	"math/rand"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/ni5arga/stock-tui/internal/market"
 	"github.com/ni5arga/stock-tui/internal/models"
 )
 
@@ -20,6 +26,9 @@ func NewSimulator() *Simulator {
 			"AAPL":    225.0,
 			"GOOGL":   175.0,
 			"TSLA":    240.0,
+			"US10Y":   4.25,
+			"US30Y":   4.45,
+			"DE10Y":   2.35,
 		},
 	}
 }
@@ -35,6 +44,15 @@ func (s *Simulator) GetQuotes(symbols []string) ([]models.Quote, error) {
 		if !ok {
 			base = 100.0 // Default for unknown symbols
 		}
+		if rate, ok := simulatedRate(sym); ok {
+			quotes = append(quotes, models.Quote{
+				Symbol:      sym,
+				Price:       rate * (1 + (rand.Float64()-0.5)*0.002),
+				Currency:    sym[3:6],
+				LastUpdated: now,
+			})
+			continue
+		}
 
 		// Random walk
 		volatility := base * 0.02
@@ -42,21 +60,81 @@ func (s *Simulator) GetQuotes(symbols []string) ([]models.Quote, error) {
 		current := base + change
 		pct := (change / base) * 100
 
-		quotes = append(quotes, models.Quote{
-			Symbol:      sym,
-			Price:       current,
-			ChangePct:   pct,
-			LastUpdated: now,
-		})
+		q := models.Quote{
+			Symbol:        sym,
+			Price:         current,
+			ChangePct:     pct,
+			Currency:      market.ExchangeFor(sym).Currency,
+			LastUpdated:   now,
+			PreviousClose: base,
+			Open:          base,
+			DayHigh:       max(base, current) * (1 + rand.Float64()*0.005),
+			DayLow:        min(base, current) * (1 - rand.Float64()*0.005),
+			Volume:        math.Round(1e6 + rand.Float64()*4e7),
+			MarketCap:     current * 1e9,
+			High52:        base * 1.3,
+			Low52:         base * 0.7,
+		}
+		if market.AssetClass(sym) == market.ClassStock && market.ExchangeFor(sym) == market.US {
+			if session := usSession(now); session != "" {
+				q.ExtSession = session
+				q.ExtChangePct = (rand.Float64() - 0.5) * 2
+				q.ExtPrice = current * (1 + q.ExtChangePct/100)
+			}
+		}
+		quotes = append(quotes, q)
 	}
 	return quotes, nil
 }
 
+// simulatedUSD is roughly what one unit of each currency buys in dollars.
+var simulatedUSD = map[string]float64{
+	"USD": 1, "EUR": 1.08, "GBP": 1.27, "JPY": 0.0067, "CNY": 0.14,
+	"INR": 0.012, "KRW": 0.00074, "HKD": 0.128, "CAD": 0.73, "AUD": 0.66,
+	"SGD": 0.74, "BRL": 0.18, "CHF": 1.13, "SEK": 0.095, "NOK": 0.094,
+	"DKK": 0.145,
+}
+
+// simulatedRate quotes an "EURUSD=X" style currency pair from simulatedUSD.
+func simulatedRate(symbol string) (float64, bool) {
+	pair, ok := strings.CutSuffix(symbol, "=X")
+	if !ok || len(pair) != 6 {
+		return 0, false
+	}
+	from, okFrom := simulatedUSD[pair[:3]]
+	to, okTo := simulatedUSD[pair[3:]]
+	if !okFrom || !okTo {
+		return 0, false
+	}
+	return from / to, true
+}
+
+// usSession reports which US extended-hours session t falls in: 4:00 to
+// 9:30 Eastern is pre-market and 16:00 to 20:00 after hours, on weekdays.
+func usSession(t time.Time) models.ExtSession {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.FixedZone("EST", -5*60*60)
+	}
+	t = t.In(loc)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return ""
+	}
+	minute := t.Hour()*60 + t.Minute()
+	switch {
+	case minute >= 4*60 && minute < 9*60+30:
+		return models.SessionPre
+	case minute >= 16*60 && minute < 20*60:
+		return models.SessionPost
+	}
+	return ""
+}
+
 func (s *Simulator) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
 	var points int
 	var duration time.Duration
 
-	switch tr {
+	switch tr.Base() {
 	case models.Range1H:
 		points = 60
 		duration = time.Minute
@@ -66,10 +144,37 @@ func (s *Simulator) GetHistory(symbol string, tr models.TimeRange) ([]models.Can
 	case models.Range30D:
 		points = 30
 		duration = 24 * time.Hour
+	case models.Range90D:
+		points = 90
+		duration = 24 * time.Hour
+	case models.Range1Y:
+		points = 365
+		duration = 24 * time.Hour
+	case models.Range5Y:
+		points = 260
+		duration = 7 * 24 * time.Hour
 	default: // 24H
 		points = 48 // 30-min intervals
 		duration = 30 * time.Minute
 	}
+	endTime := time.Now()
+	if step, ok := candleStep(tr, endTime); ok {
+		endTime = tr.End(endTime)
+		duration = step
+		points = int(endTime.Sub(tr.Start(endTime)) / step)
+	} else if _, ok := tr.Anchor(); ok || tr.Base() == models.RangeYTD {
+		endTime = tr.End(endTime)
+		span := endTime.Sub(tr.Start(endTime))
+		switch {
+		case span <= 48*time.Hour:
+			duration = 30 * time.Minute
+		case span <= 30*24*time.Hour:
+			duration = 4 * time.Hour
+		default:
+			duration = 24 * time.Hour
+		}
+		points = max(2, int(span/duration))
+	}
 
 	base, ok := s.basePrices[symbol]
 	if !ok {
@@ -79,7 +184,6 @@ func (s *Simulator) GetHistory(symbol string, tr models.TimeRange) ([]models.Can
 	candles := make([]models.Candle, points)
 	currentPrice := base
 
-	endTime := time.Now()
 	startTime := endTime.Add(-time.Duration(points) * duration)
 
 	for i := 0; i < points; i++ {
@@ -105,3 +209,257 @@ func (s *Simulator) GetHistory(symbol string, tr models.TimeRange) ([]models.Can
 
 	return candles, nil
 }
+
+// GetFundamentals returns plausible statistics scaled to the simulated
+// price, so the details pane works offline.
+func (s *Simulator) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	base, ok := s.basePrices[symbol]
+	if !ok {
+		base = 100.0
+	}
+	f := models.Fundamentals{
+		Symbol:   symbol,
+		Currency: market.ExchangeFor(symbol).Currency,
+		High52:   base * 1.25,
+		Low52:    base * 0.7,
+		Volume:   1e6 + rand.Float64()*4e7,
+	}
+	f.AvgVolume = f.Volume * (0.8 + rand.Float64()*0.4)
+	if market.IsYield(symbol) {
+		return f, nil
+	}
+	f.MarketCap = base * 1e9 * (5 + rand.Float64()*20)
+	if coin, _ := market.SplitPair(symbol); !cryptoBases[coin] {
+		f.EPS = base * 0.04
+		f.PE = 25
+		f.DividendYield = 0.5 + rand.Float64()*2
+		// Quarterly payouts over two years, growing a little each year
+		now := time.Now()
+		for q := range 8 {
+			f.Dividends = append(f.Dividends, models.Dividend{
+				ExDate: now.AddDate(0, -3*q-1, 0),
+				Amount: math.Round(base*f.DividendYield/400*math.Pow(0.95, float64(q/4))*1e4) / 1e4,
+			})
+		}
+	}
+	return f, nil
+}
+
+var simulatedNews = []string{
+	"%s shares move as traders weigh rate outlook",
+	"Analysts revisit price targets for %s",
+	"What the latest earnings season means for %s",
+	"%s volume spikes in early trading",
+	"Options traders position for a big move in %s",
+	"Is %s still a buy? Three things to watch",
+}
+
+// GetNews returns made-up headlines so the news pane works offline.
+func (s *Simulator) GetNews(symbol string) ([]models.Headline, error) {
+	now := time.Now()
+	out := make([]models.Headline, len(simulatedNews))
+	for i, title := range simulatedNews {
+		out[i] = models.Headline{
+			Title:     fmt.Sprintf(title, symbol),
+			Publisher: "Simulated Wire",
+			URL:       "https://example.com/news/" + url.PathEscape(symbol),
+			Published: now.Add(-time.Duration(i*3+rand.Intn(3)) * time.Hour),
+		}
+	}
+	return out, nil
+}
+
+// GetOptionChain makes up a chain for expiry, listing six weekly expiries
+// from the Friday after next: strikes in steps of about 2.5% around the
+// price, valued as intrinsic value plus time value that fades away from
+// the money and grows with the time left.
+func (s *Simulator) GetOptionChain(symbol string, expiry time.Time) (models.OptionChain, error) {
+	chain := models.OptionChain{Symbol: symbol}
+	if err := checkOptionable(symbol); err != nil {
+		return chain, err
+	}
+	base, ok := s.basePrices[symbol]
+	if !ok {
+		base = 100.0
+	}
+	now := time.Now().UTC()
+	friday := now.AddDate(0, 0, (int(time.Friday)-int(now.Weekday())+7)%7+7)
+	first := time.Date(friday.Year(), friday.Month(), friday.Day(), 0, 0, 0, 0, time.UTC)
+	for i := range 6 {
+		chain.Expiries = append(chain.Expiries, first.AddDate(0, 0, 7*i))
+	}
+	chain.Expiry = first
+	if !expiry.IsZero() {
+		chain.Expiry = expiry.UTC()
+	}
+	chain.Underlying = base
+	weeks := math.Max(1, chain.Expiry.Sub(now).Hours()/(24*7))
+
+	// Listed strikes sit on round steps: 1, 2.5, 5 or 10 times a power of ten
+	raw := base * 0.025
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := 10 * mag
+	for _, f := range []float64{1, 2.5, 5} {
+		if raw <= f*mag {
+			step = f * mag
+			break
+		}
+	}
+	atm := math.Round(base/step) * step
+	for i := -10; i <= 10; i++ {
+		strike := atm + float64(i)*step
+		dist := math.Abs(strike-base) / base
+		timeValue := base * 0.03 * math.Sqrt(weeks/2) * math.Exp(-dist*12)
+		iv := 0.25 + dist*0.8 + rand.Float64()*0.02
+		contract := func(intrinsic float64) models.OptionContract {
+			mid := intrinsic + timeValue
+			spread := math.Max(0.01, mid*0.02)
+			return models.OptionContract{
+				Strike:       strike,
+				Last:         mid,
+				Bid:          math.Max(0, mid-spread/2),
+				Ask:          mid + spread/2,
+				Volume:       math.Round(rand.Float64() * 5000 * math.Exp(-dist*8)),
+				OpenInterest: math.Round(rand.Float64() * 20000 * math.Exp(-dist*6)),
+				ImpliedVol:   iv,
+				InTheMoney:   intrinsic > 0,
+			}
+		}
+		chain.Calls = append(chain.Calls, contract(math.Max(0, base-strike)))
+		chain.Puts = append(chain.Puts, contract(math.Max(0, strike-base)))
+	}
+	return chain, nil
+}
+
+// GetEarnings makes up a report date for each stock within the next two
+// months, and for some an ex-dividend date, the same on every call for a
+// given symbol and day.
+func (s *Simulator) GetEarnings(symbols []string) ([]models.Earnings, error) {
+	var out []models.Earnings
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	for _, sym := range reporting(symbols) {
+		h := fnv.New32a()
+		h.Write([]byte(sym))
+		n := int(h.Sum32())
+		base, ok := s.basePrices[sym]
+		if !ok {
+			base = 100.0
+		}
+		date := today.AddDate(0, 0, 1+n%60)
+		// Companies report on weekdays
+		for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			date = date.AddDate(0, 0, 1)
+		}
+		e := models.Earnings{
+			Symbol:      sym,
+			Date:        date,
+			EPSEstimate: math.Round(base*0.012*100) / 100,
+			Estimated:   n%5 == 0,
+		}
+		switch n % 3 {
+		case 0:
+			e.Timing = models.EarningsBeforeOpen
+		case 1:
+			e.Timing = models.EarningsAfterClose
+		}
+		// Every other stock pays a dividend, going ex within three weeks
+		if n%2 == 0 {
+			e.ExDividend = today.AddDate(0, 0, 1+(n>>4)%21)
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// GetOrderBook makes up a book around the pair's price: a spread of a few
+// hundredths of a percent and levels that thicken away from the touch.
+func (s *Simulator) GetOrderBook(symbol string, depth int) (models.OrderBook, error) {
+	book := models.OrderBook{Symbol: symbol, Time: time.Now()}
+	if err := checkBookable(symbol); err != nil {
+		return book, err
+	}
+	base, ok := s.basePrices[symbol]
+	if !ok {
+		base = 100.0
+	}
+	mid := base * (1 + (rand.Float64()-0.5)*0.002)
+	tick := base * 0.0001
+	for i := range depth {
+		step := tick * float64(i+1)
+		size := func() float64 {
+			return math.Round((0.2+rand.Float64())*float64(i+2)*1e7/base) / 1e3
+		}
+		book.Bids = append(book.Bids, models.BookLevel{Price: mid - step, Size: size()})
+		book.Asks = append(book.Asks, models.BookLevel{Price: mid + step, Size: size()})
+	}
+	return book, nil
+}
+
+// simulatedSymbols is the catalog the simulator's symbol search covers.
+var simulatedSymbols = []models.SymbolMatch{
+	{Symbol: "AAPL", Name: "Apple Inc.", Exchange: "NASDAQ", Type: "Equity"},
+	{Symbol: "MSFT", Name: "Microsoft Corporation", Exchange: "NASDAQ", Type: "Equity"},
+	{Symbol: "GOOGL", Name: "Alphabet Inc.", Exchange: "NASDAQ", Type: "Equity"},
+	{Symbol: "AMZN", Name: "Amazon.com, Inc.", Exchange: "NASDAQ", Type: "Equity"},
+	{Symbol: "NVDA", Name: "NVIDIA Corporation", Exchange: "NASDAQ", Type: "Equity"},
+	{Symbol: "TSLA", Name: "Tesla, Inc.", Exchange: "NASDAQ", Type: "Equity"},
+	{Symbol: "META", Name: "Meta Platforms, Inc.", Exchange: "NASDAQ", Type: "Equity"},
+	{Symbol: "JPM", Name: "JPMorgan Chase & Co.", Exchange: "NYSE", Type: "Equity"},
+	{Symbol: "SPY", Name: "SPDR S&P 500 ETF Trust", Exchange: "NYSE Arca", Type: "ETF"},
+	{Symbol: "QQQ", Name: "Invesco QQQ Trust", Exchange: "NASDAQ", Type: "ETF"},
+	{Symbol: "BTC-USD", Name: "Bitcoin USD", Exchange: "CCC", Type: "Cryptocurrency"},
+	{Symbol: "ETH-USD", Name: "Ethereum USD", Exchange: "CCC", Type: "Cryptocurrency"},
+	{Symbol: "SOL-USD", Name: "Solana USD", Exchange: "CCC", Type: "Cryptocurrency"},
+	{Symbol: "US10Y", Name: "US 10-Year Treasury Yield", Exchange: "Bonds", Type: "Yield"},
+	{Symbol: "US30Y", Name: "US 30-Year Treasury Yield", Exchange: "Bonds", Type: "Yield"},
+	{Symbol: "DE10Y", Name: "German 10-Year Bund Yield", Exchange: "Bonds", Type: "Yield"},
+}
+
+// SearchSymbols fuzzy-matches query against the simulated catalog:
+// symbol prefixes rank first, then substrings of the symbol or name, then
+// names containing the query's letters in order.
+func (s *Simulator) SearchSymbols(query string) ([]models.SymbolMatch, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil, nil
+	}
+
+	type scored struct {
+		m     models.SymbolMatch
+		score int
+	}
+	var hits []scored
+	for _, m := range simulatedSymbols {
+		sym, name := strings.ToLower(m.Symbol), strings.ToLower(m.Name)
+		switch {
+		case strings.HasPrefix(sym, q):
+			hits = append(hits, scored{m, 0})
+		case strings.Contains(sym, q), strings.Contains(name, q):
+			hits = append(hits, scored{m, 1})
+		case subsequence(q, name):
+			hits = append(hits, scored{m, 2})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score < hits[j].score })
+
+	out := make([]models.SymbolMatch, len(hits))
+	for i, h := range hits {
+		out[i] = h.m
+	}
+	return out, nil
+}
+
+// subsequence reports whether the runes of sub appear in s in order.
+func subsequence(sub, s string) bool {
+	r := []rune(sub)
+	for _, c := range s {
+		if len(r) == 0 {
+			break
+		}
+		if c == r[0] {
+			r = r[1:]
+		}
+	}
+	return len(r) == 0
+}
@@ -0,0 +1,309 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+const stooqBase = "https://stooq.com/q"
+
+// errStooqIntraday is returned for the intraday ranges, which Stooq's free
+// endpoints do not serve.
+var errStooqIntraday = errors.New("stooq: daily candles only, no intraday history")
+
+// Stooq serves delayed quotes and daily history from Stooq's free CSV
+// endpoints. It needs no API key, which makes it a fallback that always
+// works for stocks, indices and currency pairs, and serves the German, UK
+// and Japanese 10-year yields Yahoo lacks. Crypto, the other yields and
+// futures are not covered.
+type Stooq struct {
+	mu   sync.Mutex
+	prev map[string]stooqClose // previous session's close per ticker
+}
+
+// stooqClose is the close before the session dated day.
+type stooqClose struct {
+	day   string
+	close float64
+}
+
+func NewStooq() *Stooq {
+	return &Stooq{prev: make(map[string]stooqClose)}
+}
+
+func (s *Stooq) Name() string { return "Stooq" }
+
+// stooqIndices maps the Yahoo-style index tickers to Stooq's.
+var stooqIndices = map[string]string{
+	"^GSPC":  "^spx",
+	"^DJI":   "^dji",
+	"^IXIC":  "^ndq",
+	"^NDX":   "^ndx",
+	"^FTSE":  "^ukx",
+	"^GDAXI": "^dax",
+	"^FCHI":  "^cac",
+	"^N225":  "^nkx",
+}
+
+// stooqMarkets maps exchange suffixes to Stooq's market suffixes.
+var stooqMarkets = map[string]string{
+	"":    ".us",
+	".DE": ".de",
+	".L":  ".uk",
+	".T":  ".jp",
+}
+
+// stooqTicker returns Stooq's ticker for symbol: "aapl.us", "sap.de",
+// "^spx" or "eurusd". It reports false for symbols Stooq does not carry.
+func stooqTicker(symbol string) (string, bool) {
+	sym := strings.ToUpper(symbol)
+	switch market.AssetClass(sym) {
+	case market.ClassIndex:
+		t, ok := stooqIndices[sym]
+		return t, ok
+	case market.ClassFX:
+		pair := strings.TrimSuffix(sym, "=X")
+		return strings.ToLower(pair), len(pair) == 6
+	case market.ClassYield:
+		return market.StooqYield(sym)
+	case market.ClassStock:
+		ex := market.ExchangeFor(sym)
+		suffix, ok := stooqMarkets[ex.Suffix]
+		if !ok {
+			return "", false
+		}
+		return strings.ToLower(strings.TrimSuffix(sym, ex.Suffix)) + suffix, true
+	}
+	return "", false
+}
+
+// stooqCurrency returns the currency symbol is quoted in.
+func stooqCurrency(symbol string) string {
+	if market.AssetClass(symbol) == market.ClassFX {
+		return strings.ToUpper(strings.TrimSuffix(symbol, "=X"))[3:]
+	}
+	return market.ExchangeFor(symbol).Currency
+}
+
+// get fetches a CSV endpoint and returns its rows keyed by the header.
+// Stooq answers errors with a plain-text line instead of a status code.
+func (s *Stooq) get(path string, params url.Values) ([]map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, stooqBase+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(string(body))
+	if strings.Contains(strings.ToLower(text), "exceeded the daily hits limit") {
+		return nil, &RateLimitError{RetryAfter: time.Hour}
+	}
+	if text == "" || strings.EqualFold(text, "no data") {
+		return nil, nil
+	}
+
+	r := csv.NewReader(bytes.NewReader(body))
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+	if len(records) == 0 || len(records[0]) < 2 {
+		return nil, fmt.Errorf("unexpected response: %s", firstLine(text))
+	}
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(rec) {
+				row[strings.ToLower(strings.TrimSpace(h))] = strings.TrimSpace(rec[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// field reads a numeric column. Stooq writes N/D for missing values.
+func field(row map[string]string, name string) float64 {
+	v, err := strconv.ParseFloat(row[name], 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// GetQuotes fetches all symbols in one request. The quote endpoint has no
+// change figure, so each symbol's previous close comes from its daily
+// history, fetched once per session.
+func (s *Stooq) GetQuotes(symbols []string) ([]models.Quote, error) {
+	requested := make(map[string]string, len(symbols))
+	var tickers []string
+	for _, sym := range symbols {
+		if t, ok := stooqTicker(sym); ok {
+			requested[strings.ToUpper(t)] = sym
+			tickers = append(tickers, t)
+		}
+	}
+	if len(tickers) == 0 {
+		return nil, nil
+	}
+
+	params := url.Values{}
+	params.Set("s", strings.Join(tickers, " "))
+	params.Set("f", "sd2t2ohlcv")
+	params.Set("h", "")
+	params.Set("e", "csv")
+	rows, err := s.get("/l/", params)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	quotes := make([]models.Quote, 0, len(rows))
+	for _, row := range rows {
+		sym, ok := requested[strings.ToUpper(row["symbol"])]
+		price := field(row, "close")
+		if !ok || !usable(price) {
+			continue
+		}
+		q := models.Quote{
+			Symbol: sym, Price: price, Currency: stooqCurrency(sym), LastUpdated: now,
+			Open: field(row, "open"), DayHigh: field(row, "high"), DayLow: field(row, "low"), Volume: field(row, "volume"),
+		}
+		if prev, err := s.prevClose(strings.ToLower(row["symbol"]), row["date"]); err == nil && usable(prev) {
+			q.ChangePct = (price/prev - 1) * 100
+			q.PreviousClose = prev
+		}
+		quotes = append(quotes, q)
+	}
+	return quotes, nil
+}
+
+// prevClose returns the last daily close before day (YYYY-MM-DD).
+func (s *Stooq) prevClose(ticker, day string) (float64, error) {
+	s.mu.Lock()
+	c, ok := s.prev[ticker]
+	s.mu.Unlock()
+	if ok && c.day == day {
+		return c.close, nil
+	}
+
+	date, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := s.daily(ticker, date.AddDate(0, 0, -14), date, "d")
+	if err != nil {
+		return 0, err
+	}
+	var last float64
+	for _, row := range rows {
+		if row["date"] < day {
+			last = field(row, "close")
+		}
+	}
+	s.mu.Lock()
+	s.prev[ticker] = stooqClose{day: day, close: last}
+	s.mu.Unlock()
+	return last, nil
+}
+
+func (s *Stooq) daily(ticker string, from, to time.Time, interval string) ([]map[string]string, error) {
+	params := url.Values{}
+	params.Set("s", ticker)
+	params.Set("i", interval)
+	params.Set("d1", from.Format("20060102"))
+	params.Set("d2", to.Format("20060102"))
+	return s.get("/d/l/", params)
+}
+
+// GetHistory serves daily candles, weekly ones past five years. The
+// intraday ranges, and intraday intervals, are an error so a provider
+// chain can move on to one that has them.
+func (s *Stooq) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	ticker, ok := stooqTicker(symbol)
+	if !ok {
+		return nil, fmt.Errorf("stooq does not carry %s", symbol)
+	}
+	base := tr.Base()
+	if d := tr.Interval().Duration(); (d > 0 && d < 24*time.Hour) || (d == 0 && (base == models.Range1H || base == models.Range24H)) {
+		return nil, errStooqIntraday
+	}
+
+	now := time.Now()
+	start, end := tr.Start(now), tr.End(now)
+	interval := "d"
+	if end.Sub(start) > 5*365*24*time.Hour {
+		interval = "w"
+	}
+	rows, err := s.daily(ticker, start, end, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]models.Candle, 0, len(rows))
+	d := Drops{Total: len(rows)}
+	for _, row := range rows {
+		// Noon UTC falls on the candle's date in nearly every time zone
+		day, err := time.Parse("2006-01-02", row["date"])
+		if err != nil {
+			d.Dropped++
+			continue
+		}
+		raw = append(raw, models.Candle{
+			Timestamp: day.Add(12 * time.Hour),
+			Open:      field(row, "open"),
+			High:      field(row, "high"),
+			Low:       field(row, "low"),
+			Close:     field(row, "close"),
+			Volume:    field(row, "volume"),
+		})
+	}
+	candles, bad := cleanCandles(raw)
+	d.Dropped += bad
+	noteDrops(symbol, tr, d)
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no data for %s", symbol)
+	}
+	return candles, nil
+}
+
+// GetFundamentals derives the 52-week range and volumes from a year of
+// daily candles; Stooq has no company statistics.
+func (s *Stooq) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	candles, err := s.GetHistory(symbol, models.Range1Y)
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
+	f := models.Fundamentals{Symbol: symbol, Currency: stooqCurrency(symbol), Low52: candles[0].Low}
+	for _, c := range candles {
+		f.High52 = max(f.High52, c.High)
+		f.Low52 = min(f.Low52, c.Low)
+	}
+	f.Volume = candles[len(candles)-1].Volume
+	// Three months of sessions, as Yahoo averages
+	recent := candles[max(0, len(candles)-63):]
+	for _, c := range recent {
+		f.AvgVolume += c.Volume / float64(len(recent))
+	}
+	return f, nil
+}
@@ -0,0 +1,194 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+type synthetic struct {
+	expr    expr
+	symbols []string // distinct symbols the expression references
+}
+
+// Synthetics resolves user-defined symbols computed from expressions over
+// other symbols, such as the ratio "AAPL / MSFT" or the spread "GLD - SLV".
+// Other symbols pass through to the base provider.
+type Synthetics struct {
+	base Provider
+	defs map[string]synthetic
+}
+
+// WithSynthetics wraps p with the configured synthetic symbols. Invalid
+// expressions are reported and skipped; p is returned unchanged when
+// nothing is defined.
+func WithSynthetics(p Provider, defs []models.SyntheticSymbol) (Provider, error) {
+	if len(defs) == 0 {
+		return p, nil
+	}
+	s := &Synthetics{base: p, defs: make(map[string]synthetic, len(defs))}
+	var errs []string
+	for _, d := range defs {
+		e, syms, err := parseExpr(d.Expr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", d.Label(), err))
+			continue
+		}
+		s.defs[strings.ToUpper(d.Label())] = synthetic{expr: e, symbols: dedupe(syms)}
+	}
+	if len(errs) > 0 {
+		return s, fmt.Errorf("invalid synthetic symbols: %s", strings.Join(errs, "; "))
+	}
+	return s, nil
+}
+
+func dedupe(symbols []string) []string {
+	seen := make(map[string]bool, len(symbols))
+	out := symbols[:0]
+	for _, s := range symbols {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (s *Synthetics) Name() string { return s.base.Name() }
+
+func (s *Synthetics) cachedHistory(symbol string, tr models.TimeRange) (time.Duration, bool, bool) {
+	if d, ok := s.def(symbol); ok {
+		return oldestCached(s.base, d.symbols, tr)
+	}
+	return CachedHistory(s.base, symbol, tr)
+}
+
+func (s *Synthetics) def(symbol string) (synthetic, bool) {
+	d, ok := s.defs[strings.ToUpper(symbol)]
+	return d, ok
+}
+
+func (s *Synthetics) GetQuotes(symbols []string) ([]models.Quote, error) {
+	seen := make(map[string]bool)
+	var fetch, synth []string
+	direct := make(map[string]bool)
+	add := func(sym string) {
+		if !seen[sym] {
+			seen[sym] = true
+			fetch = append(fetch, sym)
+		}
+	}
+	for _, sym := range symbols {
+		if d, ok := s.def(sym); ok {
+			synth = append(synth, sym)
+			for _, leg := range d.symbols {
+				add(leg)
+			}
+			continue
+		}
+		direct[sym] = true
+		add(sym)
+	}
+
+	quotes, err := s.base.GetQuotes(fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	now, prev := make(map[string]float64), make(map[string]float64)
+	out := make([]models.Quote, 0, len(symbols))
+	var updated time.Time
+	for _, q := range quotes {
+		key := strings.ToUpper(q.Symbol)
+		now[key] = q.Price
+		prev[key] = q.Price / (1 + q.ChangePct/100)
+		if q.LastUpdated.After(updated) {
+			updated = q.LastUpdated
+		}
+		if direct[q.Symbol] {
+			out = append(out, q)
+		}
+	}
+
+	for _, sym := range synth {
+		d, _ := s.def(sym)
+		price, err := d.expr.eval(now)
+		if err != nil {
+			continue
+		}
+		var pct, before float64
+		if before, err = d.expr.eval(prev); err == nil && before != 0 {
+			pct = (price/before - 1) * 100 * math.Copysign(1, before)
+		}
+		out = append(out, models.Quote{
+			Symbol:        sym,
+			Price:         price,
+			ChangePct:     pct,
+			Currency:      GroupCurrency,
+			LastUpdated:   updated,
+			PreviousClose: before,
+		})
+	}
+	return out, nil
+}
+
+// GetFundamentals passes through for plain symbols; a synthetic only has
+// the 52-week range of its computed series.
+func (s *Synthetics) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	if _, ok := s.def(symbol); !ok {
+		return s.base.GetFundamentals(symbol)
+	}
+	high, low, err := yearRange(s, symbol)
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
+	return models.Fundamentals{Symbol: symbol, High52: high, Low52: low}, nil
+}
+
+func (s *Synthetics) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
+	d, ok := s.def(symbol)
+	if !ok {
+		return s.base.GetHistory(symbol, tr)
+	}
+
+	series, errs := fetchHistories(s.base, d.symbols, tr)
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.symbols[i], err)
+		}
+		if len(series[i]) == 0 {
+			return nil, fmt.Errorf("no history for %s", d.symbols[i])
+		}
+	}
+	legs, length := alignTail(series)
+
+	// Only opens and closes combine meaningfully; the candle's range spans
+	// the two.
+	candles := make([]models.Candle, 0, length)
+	for t := 0; t < length; t++ {
+		opens, closes := make(map[string]float64), make(map[string]float64)
+		for i, sym := range d.symbols {
+			opens[sym] = legs[i][t].Open
+			closes[sym] = legs[i][t].Close
+		}
+		o, err1 := d.expr.eval(opens)
+		c, err2 := d.expr.eval(closes)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		candles = append(candles, models.Candle{
+			Timestamp: legs[0][t].Timestamp,
+			Open:      o,
+			High:      math.Max(o, c),
+			Low:       math.Min(o, c),
+			Close:     c,
+		})
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no valid candles for %s", symbol)
+	}
+	return candles, nil
+}
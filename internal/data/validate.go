@@ -0,0 +1,150 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Drops counts the points of a history response that were malformed and
+// left out of the chart.
+type Drops struct {
+	Dropped int
+	Total   int // points in the response, dropped ones included
+}
+
+func (d Drops) add(o Drops) Drops {
+	return Drops{Dropped: d.Dropped + o.Dropped, Total: d.Total + o.Total}
+}
+
+// drops holds the Drops of the last parsed history per symbol and range.
+// Parsers run deep under the caching and fallback wrappers, so they record
+// here rather than widen every GetHistory.
+var drops = struct {
+	sync.Mutex
+	m map[string]Drops
+}{m: make(map[string]Drops)}
+
+func noteDrops(symbol string, tr models.TimeRange, d Drops) {
+	drops.Lock()
+	defer drops.Unlock()
+	key := symbol + "|" + string(tr)
+	if d.Dropped == 0 {
+		delete(drops.m, key)
+		return
+	}
+	drops.m[key] = d
+}
+
+// HistoryDrops returns what the last fetch of symbol's history over tr
+// left out. A history served from the disk cache reports the fetch that
+// filled it, for as long as the app has been running.
+func HistoryDrops(symbol string, tr models.TimeRange) Drops {
+	drops.Lock()
+	defer drops.Unlock()
+	return drops.m[symbol+"|"+string(tr)]
+}
+
+// usable reports whether v can stand as a price.
+func usable(v float64) bool {
+	return v > 0 && !math.IsInf(v, 0) && !math.IsNaN(v)
+}
+
+// number reads a JSON number or a numeric string. Null, and anything else,
+// is not a number.
+func number(raw json.RawMessage) (float64, bool) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return 0, false
+	}
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// cleanCandles repairs what it can in parsed candles and drops the rest:
+// candles without a usable close or timestamp, and repeats of a timestamp
+// (the later one wins). A missing open, high or low takes the close, high
+// and low widen to cover the open and close, and a bad volume counts as
+// none. The result is in time order.
+func cleanCandles(in []models.Candle) ([]models.Candle, int) {
+	out := make([]models.Candle, 0, len(in))
+	for _, c := range in {
+		if !usable(c.Close) || c.Timestamp.IsZero() || c.Timestamp.Unix() <= 0 {
+			continue
+		}
+		if !usable(c.Open) {
+			c.Open = c.Close
+		}
+		if !usable(c.High) {
+			c.High = c.Close
+		}
+		if !usable(c.Low) {
+			c.Low = c.Close
+		}
+		c.High = max(c.High, c.Open, c.Close)
+		c.Low = min(c.Low, c.Open, c.Close)
+		if c.Volume < 0 || math.IsInf(c.Volume, 0) || math.IsNaN(c.Volume) {
+			c.Volume = 0
+		}
+		out = append(out, c)
+	}
+	dropped := len(in) - len(out)
+
+	slices.SortStableFunc(out, func(a, b models.Candle) int { return a.Timestamp.Compare(b.Timestamp) })
+	for i := len(out) - 1; i > 0; i-- {
+		if out[i-1].Timestamp.Equal(out[i].Timestamp) {
+			out = slices.Delete(out, i-1, i)
+			dropped++
+		}
+	}
+	return out, dropped
+}
+
+// expectFields checks that body is a JSON object carrying every field, so
+// a changed or error-page response is reported as such instead of as an
+// empty result.
+func expectFields(body []byte, fields ...string) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return fmt.Errorf("unexpected response: not a JSON object")
+	}
+	for _, f := range fields {
+		if _, ok := obj[f]; !ok {
+			return fmt.Errorf("unexpected response: no %q field", f)
+		}
+	}
+	return nil
+}
+
+// expectArray checks that body is a JSON array.
+func expectArray(body []byte) error {
+	if t := bytes.TrimSpace(body); len(t) == 0 || t[0] != '[' {
+		return fmt.Errorf("unexpected response: not a list")
+	}
+	return nil
+}
+
+// unixTime reads a timestamp in seconds, or milliseconds when ms is set.
+func unixTime(raw json.RawMessage, ms bool) (time.Time, bool) {
+	v, ok := number(raw)
+	if !ok || v <= 0 || math.IsInf(v, 0) {
+		return time.Time{}, false
+	}
+	if ms {
+		return time.UnixMilli(int64(v)), true
+	}
+	return time.Unix(int64(v), 0), true
+}
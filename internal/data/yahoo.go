@@ -5,25 +5,47 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ni5arga/stock-tui/internal/market"
 	"github.com/ni5arga/stock-tui/internal/models"
 )
 
-type Yahoo struct{}
+type Yahoo struct {
+	extendedHours bool // pre/post-market candles in 1H and 24H
+	coarse        bool // one interval coarser on the standard ranges
+}
 
 func NewYahoo() *Yahoo {
 	return &Yahoo{}
 }
 
+func newYahoo(opts Options) *Yahoo {
+	return &Yahoo{extendedHours: opts.ExtendedHours, coarse: opts.LowBandwidth}
+}
+
 func (y *Yahoo) Name() string { return "Yahoo Finance" }
 
 func (y *Yahoo) GetQuotes(symbols []string) ([]models.Quote, error) {
 	baseURL := "https://query1.finance.yahoo.com/v7/finance/quote"
+	// Generic yield names (US10Y) are requested by their Yahoo ticker and
+	// mapped back so quotes keep the configured symbol.
+	tickers := make([]string, len(symbols))
+	requested := make(map[string]string, len(symbols))
+	for i, s := range symbols {
+		tickers[i] = market.YahooTicker(s)
+		requested[tickers[i]] = s
+	}
+
 	params := url.Values{}
-	params.Set("symbols", strings.Join(symbols, ","))
-	params.Set("fields", "symbol,regularMarketPrice,regularMarketChangePercent")
+	params.Set("symbols", strings.Join(tickers, ","))
+	params.Set("fields", "symbol,regularMarketPrice,regularMarketChangePercent,currency,marketState,"+
+		"preMarketPrice,preMarketChangePercent,postMarketPrice,postMarketChangePercent,"+
+		"regularMarketOpen,regularMarketDayHigh,regularMarketDayLow,regularMarketVolume,marketCap,"+
+		"fiftyTwoWeekHigh,fiftyTwoWeekLow")
 
 	fullURL := baseURL + "?" + params.Encode()
 
@@ -41,6 +63,20 @@ func (y *Yahoo) GetQuotes(symbols []string) ([]models.Quote, error) {
 				Symbol                     string  `json:"symbol"`
 				RegularMarketPrice         float64 `json:"regularMarketPrice"`
 				RegularMarketChangePercent float64 `json:"regularMarketChangePercent"`
+				RegularMarketPreviousClose float64 `json:"regularMarketPreviousClose"`
+				Currency                   string  `json:"currency"`
+				MarketState                string  `json:"marketState"`
+				PreMarketPrice             float64 `json:"preMarketPrice"`
+				PreMarketChangePercent     float64 `json:"preMarketChangePercent"`
+				PostMarketPrice            float64 `json:"postMarketPrice"`
+				PostMarketChangePercent    float64 `json:"postMarketChangePercent"`
+				RegularMarketOpen          float64 `json:"regularMarketOpen"`
+				RegularMarketDayHigh       float64 `json:"regularMarketDayHigh"`
+				RegularMarketDayLow        float64 `json:"regularMarketDayLow"`
+				RegularMarketVolume        float64 `json:"regularMarketVolume"`
+				MarketCap                  float64 `json:"marketCap"`
+				FiftyTwoWeekHigh           float64 `json:"fiftyTwoWeekHigh"`
+				FiftyTwoWeekLow            float64 `json:"fiftyTwoWeekLow"`
 			} `json:"result"`
 			Error *struct {
 				Code        string `json:"code"`
@@ -49,6 +85,9 @@ func (y *Yahoo) GetQuotes(symbols []string) ([]models.Quote, error) {
 		} `json:"quoteResponse"`
 	}
 
+	if err := expectFields(body, "quoteResponse"); err != nil {
+		return nil, err
+	}
 	if err := json.Unmarshal(body, &resp); err != nil {
 		return nil, fmt.Errorf("parse error: %w", err)
 	}
@@ -60,23 +99,57 @@ func (y *Yahoo) GetQuotes(symbols []string) ([]models.Quote, error) {
 	now := time.Now()
 	quotes := make([]models.Quote, 0, len(resp.QuoteResponse.Result))
 	for _, r := range resp.QuoteResponse.Result {
-		if r.RegularMarketPrice == 0 {
+		if !usable(r.RegularMarketPrice) {
 			continue
 		}
-		quotes = append(quotes, models.Quote{
-			Symbol:      r.Symbol,
-			Price:       r.RegularMarketPrice,
-			ChangePct:   r.RegularMarketChangePercent,
-			LastUpdated: now,
-		})
+		sym := r.Symbol
+		if orig, ok := requested[sym]; ok {
+			sym = orig
+		}
+		currency := r.Currency
+		if currency == "" {
+			currency = market.ExchangeFor(sym).Currency
+		}
+		q := models.Quote{
+			Symbol:        sym,
+			Price:         r.RegularMarketPrice,
+			ChangePct:     r.RegularMarketChangePercent,
+			Currency:      currency,
+			LastUpdated:   now,
+			PreviousClose: r.RegularMarketPreviousClose,
+			Open:          r.RegularMarketOpen,
+			DayHigh:       r.RegularMarketDayHigh,
+			DayLow:        r.RegularMarketDayLow,
+			Volume:        r.RegularMarketVolume,
+			MarketCap:     r.MarketCap,
+			High52:        r.FiftyTwoWeekHigh,
+			Low52:         r.FiftyTwoWeekLow,
+		}
+		// Pre-market prices apply before the open; post-market ones from
+		// the close until the next pre-market session
+		switch r.MarketState {
+		case "PRE", "PREPRE":
+			if r.PreMarketPrice > 0 {
+				q.ExtSession, q.ExtPrice, q.ExtChangePct = models.SessionPre, r.PreMarketPrice, r.PreMarketChangePercent
+			}
+		case "POST", "POSTPOST", "CLOSED":
+			if r.PostMarketPrice > 0 {
+				q.ExtSession, q.ExtPrice, q.ExtChangePct = models.SessionPost, r.PostMarketPrice, r.PostMarketChangePercent
+			}
+		}
+		quotes = append(quotes, q)
 	}
 
 	return quotes, nil
 }
 
+// yahooCoarser steps each range's usual interval up one, for low-bandwidth
+// mode.
+var yahooCoarser = map[string]string{"2m": "5m", "5m": "15m", "15m": "1h", "1h": "1d"}
+
 func (y *Yahoo) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle, error) {
 	var interval, rangeVal string
-	switch tr {
+	switch tr.Base() {
 	case models.Range1H:
 		interval = "2m"
 		rangeVal = "1d"
@@ -89,16 +162,45 @@ func (y *Yahoo) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle,
 	case models.Range30D:
 		interval = "1h"
 		rangeVal = "1mo"
+	case models.Range90D:
+		interval = "1d"
+		rangeVal = "3mo"
+	case models.RangeYTD:
+		interval = "1d"
+		rangeVal = "ytd"
+	case models.Range1Y:
+		interval = "1d"
+		rangeVal = "1y"
+	case models.Range5Y:
+		interval = "1wk"
+		rangeVal = "5y"
 	default:
 		interval = "5m"
 		rangeVal = "1d"
 	}
+	if c, ok := yahooCoarser[interval]; y.coarse && ok {
+		interval = c
+	}
 
-	baseURL := "https://query1.finance.yahoo.com/v8/finance/chart/" + url.PathEscape(symbol)
+	baseURL := "https://query1.finance.yahoo.com/v8/finance/chart/" + url.PathEscape(market.YahooTicker(symbol))
 	params := url.Values{}
+	if _, ok := tr.Anchor(); ok {
+		// Anchored and custom ranges use explicit periods instead of a named range
+		now := time.Now()
+		start, end := tr.Start(now), tr.End(now)
+		params.Set("period1", strconv.FormatInt(start.Unix(), 10))
+		params.Set("period2", strconv.FormatInt(end.Unix(), 10))
+		interval = yahooInterval(end.Sub(start), now.Sub(start))
+	} else {
+		params.Set("range", rangeVal)
+	}
+	now := time.Now()
+	if _, ok := candleStep(tr, now); ok && yahooKeeps(tr.Interval(), now.Sub(tr.Start(now))) {
+		interval = string(tr.Interval())
+	}
 	params.Set("interval", interval)
-	params.Set("range", rangeVal)
-	params.Set("includePrePost", "false")
+	prePost := y.extendedHours && (tr.Base() == models.Range1H || tr.Base() == models.Range24H)
+	params.Set("includePrePost", strconv.FormatBool(prePost))
 
 	fullURL := baseURL + "?" + params.Encode()
 
@@ -110,17 +212,19 @@ func (y *Yahoo) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle,
 		return nil, err
 	}
 
+	// Points are decoded one by one so a single malformed value costs its
+	// candle rather than the whole chart
 	var resp struct {
 		Chart struct {
 			Result []struct {
-				Timestamp  []int64 `json:"timestamp"`
+				Timestamp  []json.RawMessage `json:"timestamp"`
 				Indicators struct {
 					Quote []struct {
-						Open   []*float64 `json:"open"`
-						High   []*float64 `json:"high"`
-						Low    []*float64 `json:"low"`
-						Close  []*float64 `json:"close"`
-						Volume []*float64 `json:"volume"`
+						Open   []json.RawMessage `json:"open"`
+						High   []json.RawMessage `json:"high"`
+						Low    []json.RawMessage `json:"low"`
+						Close  []json.RawMessage `json:"close"`
+						Volume []json.RawMessage `json:"volume"`
 					} `json:"quote"`
 				} `json:"indicators"`
 			} `json:"result"`
@@ -131,6 +235,9 @@ func (y *Yahoo) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle,
 		} `json:"chart"`
 	}
 
+	if err := expectFields(body, "chart"); err != nil {
+		return nil, err
+	}
 	if err := json.Unmarshal(body, &resp); err != nil {
 		return nil, fmt.Errorf("parse error: %w", err)
 	}
@@ -149,52 +256,449 @@ func (y *Yahoo) GetHistory(symbol string, tr models.TimeRange) ([]models.Candle,
 	}
 
 	q := result.Indicators.Quote[0]
-	candles := make([]models.Candle, 0, len(result.Timestamp))
-
-	for i, ts := range result.Timestamp {
-		// Skip null values (market closed periods)
-		if i >= len(q.Close) || q.Close[i] == nil {
+	at := func(vals []json.RawMessage, i int) float64 {
+		if i < len(vals) {
+			v, _ := number(vals[i])
+			return v
+		}
+		return 0
+	}
+	raw := make([]models.Candle, 0, len(result.Timestamp))
+	var d Drops
+	for i, rawTS := range result.Timestamp {
+		// Null closes are market-closed periods, not bad points. A close
+		// array shorter than the timestamps is.
+		if i < len(q.Close) && string(q.Close[i]) == "null" {
 			continue
 		}
-
-		closeVal := *q.Close[i]
-		if closeVal == 0 {
+		d.Total++
+		ts, ok := unixTime(rawTS, false)
+		if !ok {
+			d.Dropped++
 			continue
 		}
+		raw = append(raw, models.Candle{
+			Timestamp: ts,
+			Open:      at(q.Open, i),
+			High:      at(q.High, i),
+			Low:       at(q.Low, i),
+			Close:     at(q.Close, i),
+			Volume:    at(q.Volume, i),
+		})
+	}
+	candles, bad := cleanCandles(raw)
+	d.Dropped += bad
+	noteDrops(symbol, tr, d)
+
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no valid candles for %s", symbol)
+	}
+
+	return candles, nil
+}
+
+// yahooInterval picks the finest candle interval Yahoo serves for a span.
+// Intraday intervals are only available for recent data, so age (how far
+// back the window starts) also limits the choice.
+func yahooInterval(span, age time.Duration) string {
+	switch {
+	case age > 730*24*time.Hour:
+		return "1d"
+	case age > 60*24*time.Hour && span <= 60*24*time.Hour:
+		return "1h"
+	case span <= 24*time.Hour:
+		return "5m"
+	case span <= 7*24*time.Hour:
+		return "15m"
+	case span <= 60*24*time.Hour:
+		return "1h"
+	default:
+		return "1d"
+	}
+}
+
+// yahooKeeps reports whether Yahoo still has candles of interval iv from
+// age ago. Intraday candles are only kept for a limited time.
+func yahooKeeps(iv models.Interval, age time.Duration) bool {
+	switch iv {
+	case models.Interval1m:
+		return age <= 7*24*time.Hour
+	case models.Interval5m:
+		return age <= 60*24*time.Hour
+	case models.Interval1h:
+		return age <= 730*24*time.Hour
+	default:
+		return true
+	}
+}
+
+// GetOptionChain returns symbol's option chain for expiry, or the nearest
+// expiry when it is zero, from Yahoo's options API.
+func (y *Yahoo) GetOptionChain(symbol string, expiry time.Time) (models.OptionChain, error) {
+	chain := models.OptionChain{Symbol: symbol}
+	if err := checkOptionable(symbol); err != nil {
+		return chain, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	endpoint := "https://query1.finance.yahoo.com/v7/finance/options/" + url.PathEscape(market.YahooTicker(symbol))
+	if !expiry.IsZero() {
+		endpoint += "?date=" + strconv.FormatInt(expiry.Unix(), 10)
+	}
+	body, err := fetch(ctx, endpoint, nil)
+	if err != nil {
+		return chain, err
+	}
+
+	type contract struct {
+		Strike            float64 `json:"strike"`
+		LastPrice         float64 `json:"lastPrice"`
+		Bid               float64 `json:"bid"`
+		Ask               float64 `json:"ask"`
+		Volume            float64 `json:"volume"`
+		OpenInterest      float64 `json:"openInterest"`
+		ImpliedVolatility float64 `json:"impliedVolatility"`
+		InTheMoney        bool    `json:"inTheMoney"`
+	}
+	var resp struct {
+		OptionChain struct {
+			Result []struct {
+				ExpirationDates []int64 `json:"expirationDates"`
+				Quote           struct {
+					RegularMarketPrice float64 `json:"regularMarketPrice"`
+				} `json:"quote"`
+				Options []struct {
+					ExpirationDate int64      `json:"expirationDate"`
+					Calls          []contract `json:"calls"`
+					Puts           []contract `json:"puts"`
+				} `json:"options"`
+			} `json:"result"`
+			Error *struct {
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"optionChain"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return chain, fmt.Errorf("parse error: %w", err)
+	}
+	if resp.OptionChain.Error != nil {
+		return chain, fmt.Errorf("yahoo: %s", resp.OptionChain.Error.Description)
+	}
+	if len(resp.OptionChain.Result) == 0 || len(resp.OptionChain.Result[0].Options) == 0 {
+		return chain, fmt.Errorf("no options listed for %s", symbol)
+	}
 
-		var openVal, highVal, lowVal, volVal float64
-		if i < len(q.Open) && q.Open[i] != nil {
-			openVal = *q.Open[i]
-		} else {
-			openVal = closeVal
+	r := resp.OptionChain.Result[0]
+	convert := func(in []contract) []models.OptionContract {
+		out := make([]models.OptionContract, len(in))
+		for i, c := range in {
+			out[i] = models.OptionContract{
+				Strike:       c.Strike,
+				Last:         c.LastPrice,
+				Bid:          c.Bid,
+				Ask:          c.Ask,
+				Volume:       c.Volume,
+				OpenInterest: c.OpenInterest,
+				ImpliedVol:   c.ImpliedVolatility,
+				InTheMoney:   c.InTheMoney,
+			}
 		}
-		if i < len(q.High) && q.High[i] != nil {
-			highVal = *q.High[i]
-		} else {
-			highVal = closeVal
+		return out
+	}
+	opts := r.Options[0]
+	chain.Underlying = r.Quote.RegularMarketPrice
+	chain.Expiry = time.Unix(opts.ExpirationDate, 0).UTC()
+	for _, ts := range r.ExpirationDates {
+		chain.Expiries = append(chain.Expiries, time.Unix(ts, 0).UTC())
+	}
+	chain.Calls = convert(opts.Calls)
+	chain.Puts = convert(opts.Puts)
+	return chain, nil
+}
+
+// GetEarnings reads each stock's next report and ex-dividend date from the
+// calendar events of Yahoo's quote summary, one request per symbol.
+// Symbols that fail are left out; it errs only when every one does.
+func (y *Yahoo) GetEarnings(symbols []string) ([]models.Earnings, error) {
+	var out []models.Earnings
+	var firstErr error
+	now := time.Now()
+	stocks := reporting(symbols)
+	for _, sym := range stocks {
+		e, ok, err := y.nextEarnings(sym, now)
+		switch {
+		case err != nil:
+			if firstErr == nil {
+				firstErr = err
+			}
+		case ok:
+			out = append(out, e)
 		}
-		if i < len(q.Low) && q.Low[i] != nil {
-			lowVal = *q.Low[i]
-		} else {
-			lowVal = closeVal
+	}
+	if firstErr != nil && len(out) == 0 {
+		return nil, firstErr
+	}
+	return out, nil
+}
+
+// nextEarnings returns symbol's first report date and ex-dividend date
+// from now on, or false when neither is listed.
+func (y *Yahoo) nextEarnings(symbol string, now time.Time) (models.Earnings, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	endpoint := "https://query2.finance.yahoo.com/v10/finance/quoteSummary/" +
+		url.PathEscape(market.YahooTicker(symbol)) + "?modules=calendarEvents"
+	body, err := fetch(ctx, endpoint, nil)
+	if err != nil {
+		return models.Earnings{}, false, err
+	}
+
+	type value struct {
+		Raw float64 `json:"raw"`
+	}
+	var resp struct {
+		QuoteSummary struct {
+			Result []struct {
+				CalendarEvents struct {
+					ExDividendDate value `json:"exDividendDate"`
+					Earnings       struct {
+						EarningsDate           []value `json:"earningsDate"`
+						EarningsAverage        value   `json:"earningsAverage"`
+						IsEarningsDateEstimate bool    `json:"isEarningsDateEstimate"`
+					} `json:"earnings"`
+				} `json:"calendarEvents"`
+			} `json:"result"`
+			Error *struct {
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"quoteSummary"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return models.Earnings{}, false, fmt.Errorf("parse error: %w", err)
+	}
+	if resp.QuoteSummary.Error != nil {
+		return models.Earnings{}, false, fmt.Errorf("yahoo: %s", resp.QuoteSummary.Error.Description)
+	}
+	if len(resp.QuoteSummary.Result) == 0 {
+		return models.Earnings{}, false, nil
+	}
+
+	cal := resp.QuoteSummary.Result[0].CalendarEvents
+	today := now.Truncate(24 * time.Hour)
+	e := models.Earnings{Symbol: symbol}
+	if t := time.Unix(int64(cal.ExDividendDate.Raw), 0); cal.ExDividendDate.Raw != 0 && !t.Before(today) {
+		e.ExDividend = t
+	}
+	// A date still being settled is listed as a window; its start counts
+	ev := cal.Earnings
+	for _, d := range ev.EarningsDate {
+		t := time.Unix(int64(d.Raw), 0)
+		if d.Raw == 0 || t.Before(today) {
+			continue
 		}
-		if i < len(q.Volume) && q.Volume[i] != nil {
-			volVal = *q.Volume[i]
+		e.Date = t
+		e.Timing = reportTiming(symbol, t)
+		e.EPSEstimate = ev.EarningsAverage.Raw
+		e.Estimated = ev.IsEarningsDateEstimate || len(ev.EarningsDate) > 1
+		break
+	}
+	return e, !e.Date.IsZero() || !e.ExDividend.IsZero(), nil
+}
+
+// GetNews returns recent headlines for symbol from Yahoo's search API,
+// newest first.
+func (y *Yahoo) GetNews(symbol string) ([]models.Headline, error) {
+	params := url.Values{}
+	params.Set("q", market.YahooTicker(symbol))
+	params.Set("quotesCount", "0")
+	params.Set("newsCount", "20")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, "https://query1.finance.yahoo.com/v1/finance/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		News []struct {
+			Title               string `json:"title"`
+			Publisher           string `json:"publisher"`
+			Link                string `json:"link"`
+			ProviderPublishTime int64  `json:"providerPublishTime"`
+		} `json:"news"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+
+	headlines := make([]models.Headline, 0, len(resp.News))
+	for _, n := range resp.News {
+		if n.Title == "" {
+			continue
 		}
+		headlines = append(headlines, models.Headline{
+			Title:     n.Title,
+			Publisher: n.Publisher,
+			URL:       n.Link,
+			Published: time.Unix(n.ProviderPublishTime, 0),
+		})
+	}
+	sort.SliceStable(headlines, func(i, j int) bool {
+		return headlines[i].Published.After(headlines[j].Published)
+	})
+	return headlines, nil
+}
+
+// SearchSymbols looks up tickers matching query by symbol or company
+// name, in Yahoo's relevance order.
+func (y *Yahoo) SearchSymbols(query string) ([]models.SymbolMatch, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("quotesCount", "15")
+	params.Set("newsCount", "0")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, "https://query1.finance.yahoo.com/v1/finance/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-		candles = append(candles, models.Candle{
-			Timestamp: time.Unix(ts, 0),
-			Open:      openVal,
-			High:      highVal,
-			Low:       lowVal,
-			Close:     closeVal,
-			Volume:    volVal,
+	var resp struct {
+		Quotes []struct {
+			Symbol    string `json:"symbol"`
+			ShortName string `json:"shortname"`
+			LongName  string `json:"longname"`
+			ExchDisp  string `json:"exchDisp"`
+			TypeDisp  string `json:"typeDisp"`
+		} `json:"quotes"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+
+	matches := make([]models.SymbolMatch, 0, len(resp.Quotes))
+	for _, q := range resp.Quotes {
+		if q.Symbol == "" {
+			continue
+		}
+		name := q.LongName
+		if name == "" {
+			name = q.ShortName
+		}
+		matches = append(matches, models.SymbolMatch{
+			Symbol:   q.Symbol,
+			Name:     name,
+			Exchange: q.ExchDisp,
+			Type:     q.TypeDisp,
 		})
 	}
+	return matches, nil
+}
+
+// GetFundamentals reads the statistics fields of Yahoo's quote endpoint.
+func (y *Yahoo) GetFundamentals(symbol string) (models.Fundamentals, error) {
+	params := url.Values{}
+	params.Set("symbols", market.YahooTicker(symbol))
+	params.Set("fields", "currency,marketCap,trailingPE,epsTrailingTwelveMonths,fiftyTwoWeekHigh,fiftyTwoWeekLow,"+
+		"trailingAnnualDividendYield,regularMarketVolume,averageDailyVolume3Month")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, "https://query1.finance.yahoo.com/v7/finance/quote?"+params.Encode(), nil)
+	if err != nil {
+		return models.Fundamentals{}, err
+	}
 
-	if len(candles) == 0 {
-		return nil, fmt.Errorf("no valid candles for %s", symbol)
+	var resp struct {
+		QuoteResponse struct {
+			Result []struct {
+				Currency                    string  `json:"currency"`
+				MarketCap                   float64 `json:"marketCap"`
+				TrailingPE                  float64 `json:"trailingPE"`
+				EPS                         float64 `json:"epsTrailingTwelveMonths"`
+				FiftyTwoWeekHigh            float64 `json:"fiftyTwoWeekHigh"`
+				FiftyTwoWeekLow             float64 `json:"fiftyTwoWeekLow"`
+				TrailingAnnualDividendYield float64 `json:"trailingAnnualDividendYield"`
+				RegularMarketVolume         float64 `json:"regularMarketVolume"`
+				AverageDailyVolume3Month    float64 `json:"averageDailyVolume3Month"`
+			} `json:"result"`
+		} `json:"quoteResponse"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return models.Fundamentals{}, fmt.Errorf("parse error: %w", err)
+	}
+	if len(resp.QuoteResponse.Result) == 0 {
+		return models.Fundamentals{}, fmt.Errorf("no fundamentals for %s", symbol)
 	}
 
-	return candles, nil
+	r := resp.QuoteResponse.Result[0]
+	// The payout history is a nicety; the figures stand without it
+	dividends, _ := y.dividends(symbol)
+	return models.Fundamentals{
+		Symbol:        symbol,
+		Currency:      r.Currency,
+		MarketCap:     r.MarketCap,
+		PE:            r.TrailingPE,
+		EPS:           r.EPS,
+		High52:        r.FiftyTwoWeekHigh,
+		Low52:         r.FiftyTwoWeekLow,
+		DividendYield: r.TrailingAnnualDividendYield * 100,
+		Volume:        r.RegularMarketVolume,
+		AvgVolume:     r.AverageDailyVolume3Month,
+		Dividends:     dividends,
+	}, nil
+}
+
+// dividends returns symbol's payouts over the last five years, most
+// recent first, from the dividend events of Yahoo's chart endpoint.
+func (y *Yahoo) dividends(symbol string) ([]models.Dividend, error) {
+	if market.AssetClass(symbol) != market.ClassStock {
+		return nil, nil
+	}
+	params := url.Values{}
+	params.Set("range", "5y")
+	params.Set("interval", "1mo")
+	params.Set("events", "div")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	body, err := fetch(ctx, "https://query1.finance.yahoo.com/v8/finance/chart/"+url.PathEscape(market.YahooTicker(symbol))+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Chart struct {
+			Result []struct {
+				Events struct {
+					Dividends map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"dividends"`
+				} `json:"events"`
+			} `json:"result"`
+		} `json:"chart"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+	if len(resp.Chart.Result) == 0 {
+		return nil, nil
+	}
+
+	var out []models.Dividend
+	for _, d := range resp.Chart.Result[0].Events.Dividends {
+		out = append(out, models.Dividend{ExDate: time.Unix(d.Date, 0), Amount: d.Amount})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ExDate.After(out[j].ExDate) })
+	return out, nil
 }
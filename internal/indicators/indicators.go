@@ -0,0 +1,162 @@
+// Package indicators computes technical indicator series from closing
+// prices. Every series has one value per input sample and is NaN until
+// the indicator has enough history.
+package indicators
+
+import "math"
+
+// SMA returns the simple moving average over period samples.
+func SMA(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period <= 0 || period > len(values) {
+		return out
+	}
+	var sum float64
+	for i, v := range values {
+		sum += v
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			out[i] = sum / float64(period)
+		}
+	}
+	return out
+}
+
+// EMA returns the exponential moving average, seeded with the SMA of the
+// first period samples.
+func EMA(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period <= 0 || period > len(values) {
+		return out
+	}
+	k := 2 / float64(period+1)
+	var seed float64
+	for i := 0; i < period; i++ {
+		seed += values[i]
+	}
+	prev := seed / float64(period)
+	out[period-1] = prev
+	for i := period; i < len(values); i++ {
+		prev = values[i]*k + prev*(1-k)
+		out[i] = prev
+	}
+	return out
+}
+
+// Bollinger returns the middle, upper and lower bands using the population
+// standard deviation over period samples.
+func Bollinger(values []float64, period int, stdDev float64) (mid, upper, lower []float64) {
+	mid = SMA(values, period)
+	upper = nans(len(values))
+	lower = nans(len(values))
+	for i := range values {
+		if math.IsNaN(mid[i]) {
+			continue
+		}
+		var sq float64
+		for j := i - period + 1; j <= i; j++ {
+			d := values[j] - mid[i]
+			sq += d * d
+		}
+		sd := math.Sqrt(sq / float64(period))
+		upper[i] = mid[i] + stdDev*sd
+		lower[i] = mid[i] - stdDev*sd
+	}
+	return mid, upper, lower
+}
+
+// RSI returns Wilder's relative strength index, from 0 to 100. The first
+// value needs period changes, so it lands on sample period; averages then
+// carry forward with Wilder's smoothing. A window with no losses reads 100
+// and one with no moves at all 50.
+func RSI(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period <= 0 || period >= len(values) {
+		return out
+	}
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		d := values[i] - values[i-1]
+		gain += max(d, 0)
+		loss += max(-d, 0)
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+	out[period] = rsi(gain, loss)
+	for i := period + 1; i < len(values); i++ {
+		d := values[i] - values[i-1]
+		gain = (gain*float64(period-1) + max(d, 0)) / float64(period)
+		loss = (loss*float64(period-1) + max(-d, 0)) / float64(period)
+		out[i] = rsi(gain, loss)
+	}
+	return out
+}
+
+func rsi(gain, loss float64) float64 {
+	switch {
+	case gain == 0 && loss == 0:
+		return 50
+	case loss == 0:
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
+
+// MACD returns the moving average convergence divergence line (the fast
+// EMA less the slow one), its signal line (an EMA of the MACD line) and
+// the histogram between them.
+func MACD(values []float64, fast, slow, signal int) (line, sig, hist []float64) {
+	line = nans(len(values))
+	sig = nans(len(values))
+	hist = nans(len(values))
+	fastEMA, slowEMA := EMA(values, fast), EMA(values, slow)
+	start := -1
+	for i := range values {
+		if math.IsNaN(fastEMA[i]) || math.IsNaN(slowEMA[i]) {
+			continue
+		}
+		line[i] = fastEMA[i] - slowEMA[i]
+		if start < 0 {
+			start = i
+		}
+	}
+	if start < 0 {
+		return line, sig, hist
+	}
+	// The signal starts once the MACD line has signal values of its own
+	s := EMA(line[start:], signal)
+	for i, v := range s {
+		sig[start+i] = v
+		hist[start+i] = line[start+i] - v
+	}
+	return line, sig, hist
+}
+
+// VWAP returns the volume-weighted average price, accumulated from the
+// start of each session: starts[i] marks the first sample of one. It is
+// NaN until the session has traded some volume.
+func VWAP(prices, volumes []float64, starts []bool) []float64 {
+	out := nans(len(prices))
+	var pv, vol float64
+	for i, p := range prices {
+		if starts[i] {
+			pv, vol = 0, 0
+		}
+		pv += p * volumes[i]
+		vol += volumes[i]
+		if vol > 0 {
+			out[i] = pv / vol
+		}
+	}
+	return out
+}
+
+func nans(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+// closes is the 33-day sample from Wilder's RSI worked example as
+// published by StockCharts.
+var closes = []float64{
+	44.3389, 44.0902, 44.1497, 43.6124, 44.2779, 44.8264, 45.0955, 45.4245,
+	45.8433, 46.0826, 45.8931, 46.0328, 45.6140, 46.2820, 46.2820, 46.0028,
+	46.0328, 46.4116, 46.2222, 45.6439, 46.2122, 46.2521, 45.7137, 46.4515,
+	45.7835, 45.3548, 44.0288, 44.1783, 44.2181, 44.5672, 43.4205, 42.6628,
+	43.1314,
+}
+
+func near(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func checkWarmup(t *testing.T, name string, got []float64, first int) {
+	t.Helper()
+	for i := 0; i < first; i++ {
+		if !math.IsNaN(got[i]) {
+			t.Errorf("%s[%d] = %v, want NaN before enough history", name, i, got[i])
+		}
+	}
+	if math.IsNaN(got[first]) {
+		t.Errorf("%s[%d] is NaN, want the first value", name, first)
+	}
+}
+
+func TestSMA(t *testing.T) {
+	got := SMA([]float64{1, 2, 3, 4, 5, 6}, 3)
+	checkWarmup(t, "SMA", got, 2)
+	for i, want := range []float64{2, 3, 4, 5} {
+		if got[i+2] != want {
+			t.Errorf("SMA[%d] = %v, want %v", i+2, got[i+2], want)
+		}
+	}
+}
+
+func TestEMA(t *testing.T) {
+	// Seeded with the SMA of 2, 4, 6, then k = 0.5
+	got := EMA([]float64{2, 4, 6, 8, 12}, 3)
+	checkWarmup(t, "EMA", got, 2)
+	for i, want := range []float64{4, 6, 9} {
+		if !near(got[i+2], want, 1e-12) {
+			t.Errorf("EMA[%d] = %v, want %v", i+2, got[i+2], want)
+		}
+	}
+}
+
+func TestBollinger(t *testing.T) {
+	// Population standard deviation of 2, 4, 4, 4, 5, 5, 7, 9 is 2
+	mid, upper, lower := Bollinger([]float64{2, 4, 4, 4, 5, 5, 7, 9}, 8, 2)
+	if mid[7] != 5 || upper[7] != 9 || lower[7] != 1 {
+		t.Errorf("bands = %v/%v/%v, want 5/9/1", mid[7], upper[7], lower[7])
+	}
+}
+
+func TestRSI(t *testing.T) {
+	want := []float64{
+		70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+		54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+	}
+	got := RSI(closes, 14)
+	checkWarmup(t, "RSI", got, 14)
+	for i, w := range want {
+		if !near(got[14+i], w, 0.01) {
+			t.Errorf("RSI[%d] = %.2f, want %.2f", 14+i, got[14+i], w)
+		}
+	}
+}
+
+func TestRSIEdges(t *testing.T) {
+	up := []float64{1, 2, 3, 4, 5}
+	down := []float64{5, 4, 3, 2, 1}
+	flat := []float64{3, 3, 3, 3, 3}
+	for name, c := range map[string]struct {
+		values []float64
+		want   float64
+	}{"rising": {up, 100}, "falling": {down, 0}, "flat": {flat, 50}} {
+		if got := RSI(c.values, 3)[4]; got != c.want {
+			t.Errorf("%s: RSI = %v, want %v", name, got, c.want)
+		}
+	}
+	if got := RSI(up, 5); !math.IsNaN(got[4]) {
+		t.Errorf("RSI with period = len(values): got %v, want NaN", got[4])
+	}
+}
+
+func TestMACD(t *testing.T) {
+	// An EMA of a straight line lags it by (period-1)/2 once seeded, so
+	// on 0, 1, 2, ... the 12/26 MACD is exactly 12.5 - 5.5 = 7 and the
+	// signal matches it
+	values := make([]float64, 60)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	line, sig, hist := MACD(values, 12, 26, 9)
+	checkWarmup(t, "MACD", line, 25)
+	checkWarmup(t, "signal", sig, 33)
+	for i := 25; i < len(values); i++ {
+		if !near(line[i], 7, 1e-9) {
+			t.Errorf("MACD[%d] = %v, want 7", i, line[i])
+		}
+	}
+	for i := 33; i < len(values); i++ {
+		if !near(sig[i], 7, 1e-9) || !near(hist[i], 0, 1e-9) {
+			t.Errorf("signal/hist[%d] = %v/%v, want 7/0", i, sig[i], hist[i])
+		}
+	}
+}
+
+func TestMACDShortHistory(t *testing.T) {
+	line, sig, _ := MACD([]float64{1, 2, 3}, 12, 26, 9)
+	for i := range line {
+		if !math.IsNaN(line[i]) || !math.IsNaN(sig[i]) {
+			t.Fatalf("MACD on 3 samples: got %v/%v, want NaN", line[i], sig[i])
+		}
+	}
+}
+
+func TestVWAP(t *testing.T) {
+	prices := []float64{10, 12, 11, 20, 22}
+	volumes := []float64{0, 100, 300, 50, 150}
+	starts := []bool{true, false, false, true, false}
+	got := VWAP(prices, volumes, starts)
+	checkWarmup(t, "VWAP", got, 1)
+	// (12*100 + 11*300) / 400, then a new session from 20
+	for i, want := range map[int]float64{1: 12, 2: 11.25, 3: 20, 4: 21.5} {
+		if !near(got[i], want, 1e-12) {
+			t.Errorf("VWAP[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+}
@@ -0,0 +1,505 @@
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap holds every rebindable action. Field order matches the help
+// overlay.
+type KeyMap struct {
+	Down          key.Binding
+	Up            key.Binding
+	Search        key.Binding
+	AddSymbol     key.Binding
+	RemoveSymbol  key.Binding
+	MoveUp        key.Binding
+	MoveDown      key.Binding
+	ClearList     key.Binding
+	Undo          key.Binding
+	PrevWatchlist key.Binding
+	NextWatchlist key.Binding
+	PickWatchlist key.Binding
+	Sort          key.Binding
+	SortDirection key.Binding
+	ToggleSection key.Binding
+	SessionChange key.Binding
+	CopyTable     key.Binding
+	Export        key.Binding
+	ExportList    key.Binding
+	NextRange     key.Binding
+	Range1H       key.Binding
+	Range24H      key.Binding
+	Range7D       key.Binding
+	Range30D      key.Binding
+	RangeYTD      key.Binding
+	Range90D      key.Binding
+	Range1Y       key.Binding
+	Range5Y       key.Binding
+	AnchorRange   key.Binding
+	CustomRange   key.Binding
+	Interval      key.Binding
+	ScrubBack     key.Binding
+	ScrubForward  key.Binding
+	ChartType     key.Binding
+	Indicators    key.Binding
+	RSIPanel      key.Binding
+	MACDPanel     key.Binding
+	OrderBook     key.Binding
+	LogScale      key.Binding
+	Crosshair     key.Binding
+	ZoomIn        key.Binding
+	ZoomOut       key.Binding
+	PanLeft       key.Binding
+	PanRight      key.Binding
+	CompareMark   key.Binding
+	Stats         key.Binding
+	Portfolio     key.Binding
+	PortfolioRisk key.Binding
+	Fundamentals  key.Binding
+	Earnings      key.Binding
+	News          key.Binding
+	Options       key.Binding
+	PrevTab       key.Binding
+	NextTab       key.Binding
+	TabChart      key.Binding
+	TabNews       key.Binding
+	TabDetails    key.Binding
+	TabOptions    key.Binding
+	Heatmap       key.Binding
+	OpenNews      key.Binding
+	Narrower      key.Binding
+	Wider         key.Binding
+	Focus         key.Binding
+	PickLayout    key.Binding
+	SaveLayout    key.Binding
+	Alert         key.Binding
+	JumpAlert     key.Binding
+	AlertLog      key.Binding
+	Note          key.Binding
+	Trade         key.Binding
+	Refresh       key.Binding
+	Settings      key.Binding
+	Tour          key.Binding
+	Palette       key.Binding
+	Help          key.Binding
+	Quit          key.Binding
+}
+
+func binding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(helpKeys(keys), desc))
+}
+
+// helpKeys renders a key list for the help overlay, e.g. "j/↓".
+func helpKeys(keys []string) string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		switch k {
+		case "up":
+			out[i] = "↑"
+		case "down":
+			out[i] = "↓"
+		case "left":
+			out[i] = "←"
+		case "right":
+			out[i] = "→"
+		case " ":
+			out[i] = "space"
+		default:
+			out[i] = k
+		}
+	}
+	return strings.Join(out, "/")
+}
+
+// Default returns the built-in bindings.
+func Default() KeyMap {
+	return KeyMap{
+		Down:          binding("Move down", "j", "down"),
+		Up:            binding("Move up", "k", "up"),
+		Search:        binding("Search symbols", "/"),
+		AddSymbol:     binding("Look up and add a symbol", "a"),
+		RemoveSymbol:  binding("Remove symbol from the watchlist", "d"),
+		MoveUp:        binding("Move symbol up the list", "K"),
+		MoveDown:      binding("Move symbol down the list", "J"),
+		ClearList:     binding("Clear the watchlist", "C"),
+		Undo:          binding("Undo the last watchlist edit", "u"),
+		PrevWatchlist: binding("Previous watchlist", "{"),
+		NextWatchlist: binding("Next watchlist", "}"),
+		PickWatchlist: binding("Pick watchlist", "W"),
+		Sort:          binding("Cycle sort (Name/Price/Change%/Custom)", "s"),
+		SortDirection: binding("Toggle sort direction", "S"),
+		ToggleSection: binding("Collapse / expand section", "enter"),
+		SessionChange: binding("Toggle daily / since-start change", "%"),
+		CopyTable:     binding("Copy watchlist as a table", "y"),
+		Export:        binding("Export chart data to a file", "e"),
+		ExportList:    binding("Export the watchlist to a file", "Y"),
+		NextRange:     binding("Cycle time range", "tab"),
+		Range1H:       binding("1 hour range", "1"),
+		Range24H:      binding("24 hour range", "2"),
+		Range7D:       binding("7 day range", "3"),
+		Range30D:      binding("30 day range", "4"),
+		RangeYTD:      binding("Year to date range", "5"),
+		Range90D:      binding("90 day range", "6"),
+		Range1Y:       binding("1 year range", "7"),
+		Range5Y:       binding("5 year range", "8"),
+		AnchorRange:   binding("Chart from a date", "@"),
+		CustomRange:   binding("Custom date range", "D"),
+		Interval:      binding("Pick the candle interval", "I"),
+		ScrubBack:     binding("Previous period", "["),
+		ScrubForward:  binding("Next period", "]"),
+		ChartType:     binding("Cycle chart type", "c"),
+		Indicators:    binding("Cycle indicators (SMA/EMA/BB/VWAP)", "i"),
+		RSIPanel:      binding("Toggle the RSI panel", "#"),
+		MACDPanel:     binding("Toggle the MACD panel", "M"),
+		OrderBook:     binding("Toggle the order book (crypto pairs)", "b"),
+		LogScale:      binding("Toggle log / linear price axis", "^"),
+		Crosshair:     binding("Crosshair (←/→ to move)", "x"),
+		ZoomIn:        binding("Zoom into the chart", "+", "="),
+		ZoomOut:       binding("Zoom out of the chart", "-"),
+		PanLeft:       binding("Pan a zoomed chart back", "left"),
+		PanRight:      binding("Pan a zoomed chart forward", "right"),
+		CompareMark:   binding("Mark symbol for compare", " "),
+		Stats:         binding("Returns summary", "R"),
+		Portfolio:     binding("Portfolio vs benchmark chart", "B"),
+		PortfolioRisk: binding("Portfolio risk metrics", "P"),
+		Fundamentals:  binding("Details tab (market cap, P/E, 52W)", "f"),
+		Earnings:      binding("Earnings calendar for the watchlist", "E"),
+		News:          binding("News tab (j/k to scroll)", "n"),
+		Options:       binding("Options tab (j/k to scroll, [/] expiry)", "O"),
+		PrevTab:       binding("Previous tab", "h"),
+		NextTab:       binding("Next tab", "l"),
+		TabChart:      binding("Chart tab", "alt+1"),
+		TabNews:       binding("News tab", "alt+2"),
+		TabDetails:    binding("Details tab", "alt+3"),
+		TabOptions:    binding("Options tab (j/k to scroll, [/] expiry)", "alt+4"),
+		OpenNews:      binding("Open headline in browser", "o"),
+		Heatmap:       binding("Heatmap of the watchlist", "m"),
+		Narrower:      binding("Narrow the watchlist pane", "<"),
+		Wider:         binding("Widen the watchlist pane", ">"),
+		Focus:         binding("Switch focus between the panes", "shift+tab"),
+		PickLayout:    binding("Switch layout", "v"),
+		SaveLayout:    binding("Save the current layout", "V"),
+		Alert:         binding("Set price alert", "A"),
+		JumpAlert:     binding("Jump to the last alert", "!"),
+		AlertLog:      binding("Alert log", "L"),
+		Note:          binding("Edit the symbol's note", "N"),
+		Trade:         binding("Paper trade the symbol", "t"),
+		Refresh:       binding("Refresh data", "r"),
+		Settings:      binding("Settings", ","),
+		Tour:          binding("Guided tour", "T"),
+		Palette:       binding("Command palette", ":", "ctrl+p"),
+		Help:          binding("Toggle help", "?"),
+		Quit:          binding("Quit", "q", "ctrl+c"),
+	}
+}
+
+// named lists the bindings under their config names, in help order.
+func (k *KeyMap) named() []struct {
+	name string
+	b    *key.Binding
+} {
+	return []struct {
+		name string
+		b    *key.Binding
+	}{
+		{"down", &k.Down},
+		{"up", &k.Up},
+		{"search", &k.Search},
+		{"add_symbol", &k.AddSymbol},
+		{"remove_symbol", &k.RemoveSymbol},
+		{"move_up", &k.MoveUp},
+		{"move_down", &k.MoveDown},
+		{"clear_list", &k.ClearList},
+		{"undo", &k.Undo},
+		{"prev_watchlist", &k.PrevWatchlist},
+		{"next_watchlist", &k.NextWatchlist},
+		{"pick_watchlist", &k.PickWatchlist},
+		{"sort", &k.Sort},
+		{"sort_direction", &k.SortDirection},
+		{"toggle_section", &k.ToggleSection},
+		{"session_change", &k.SessionChange},
+		{"copy_table", &k.CopyTable},
+		{"export", &k.Export},
+		{"export_list", &k.ExportList},
+		{"next_range", &k.NextRange},
+		{"range_1h", &k.Range1H},
+		{"range_24h", &k.Range24H},
+		{"range_7d", &k.Range7D},
+		{"range_30d", &k.Range30D},
+		{"range_ytd", &k.RangeYTD},
+		{"range_90d", &k.Range90D},
+		{"range_1y", &k.Range1Y},
+		{"range_5y", &k.Range5Y},
+		{"anchor_range", &k.AnchorRange},
+		{"custom_range", &k.CustomRange},
+		{"candle_interval", &k.Interval},
+		{"scrub_back", &k.ScrubBack},
+		{"scrub_forward", &k.ScrubForward},
+		{"chart_type", &k.ChartType},
+		{"indicators", &k.Indicators},
+		{"rsi_panel", &k.RSIPanel},
+		{"macd_panel", &k.MACDPanel},
+		{"order_book", &k.OrderBook},
+		{"log_scale", &k.LogScale},
+		{"crosshair", &k.Crosshair},
+		{"zoom_in", &k.ZoomIn},
+		{"zoom_out", &k.ZoomOut},
+		{"pan_left", &k.PanLeft},
+		{"pan_right", &k.PanRight},
+		{"compare_mark", &k.CompareMark},
+		{"stats", &k.Stats},
+		{"portfolio", &k.Portfolio},
+		{"portfolio_risk", &k.PortfolioRisk},
+		{"fundamentals", &k.Fundamentals},
+		{"earnings", &k.Earnings},
+		{"news", &k.News},
+		{"options", &k.Options},
+		{"prev_tab", &k.PrevTab},
+		{"next_tab", &k.NextTab},
+		{"tab_chart", &k.TabChart},
+		{"tab_news", &k.TabNews},
+		{"tab_details", &k.TabDetails},
+		{"tab_options", &k.TabOptions},
+		{"open_news", &k.OpenNews},
+		{"heatmap", &k.Heatmap},
+		{"narrower", &k.Narrower},
+		{"wider", &k.Wider},
+		{"focus", &k.Focus},
+		{"pick_layout", &k.PickLayout},
+		{"save_layout", &k.SaveLayout},
+		{"alert", &k.Alert},
+		{"jump_alert", &k.JumpAlert},
+		{"alert_log", &k.AlertLog},
+		{"note", &k.Note},
+		{"trade", &k.Trade},
+		{"refresh", &k.Refresh},
+		{"settings", &k.Settings},
+		{"tour", &k.Tour},
+		{"palette", &k.Palette},
+		{"help", &k.Help},
+		{"quit", &k.Quit},
+	}
+}
+
+// Apply rebinds actions from the config's [keys] table, e.g.
+// refresh = ["F5", "r"]. An empty list unbinds the action. Unknown action
+// names are reported.
+func (k *KeyMap) Apply(overrides map[string][]string) error {
+	byName := make(map[string]*key.Binding)
+	for _, n := range k.named() {
+		byName[n.name] = n.b
+	}
+
+	var unknown []string
+	for name, keys := range overrides {
+		b, ok := byName[strings.ToLower(name)]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if len(keys) == 0 {
+			b.Unbind()
+			continue
+		}
+		desc := b.Help().Desc
+		*b = binding(desc, keys...)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown key actions: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Action is a bound action as listed in the command palette.
+type Action struct {
+	Name string // config name, e.g. "log_scale"
+	Desc string
+	Keys string // as shown in help, e.g. "q/ctrl+c"
+	Msg  tea.KeyMsg
+}
+
+// Actions returns every bound action, in help order, with the key press
+// that runs it. List movement and the palette itself are left out.
+func (k KeyMap) Actions() []Action {
+	var out []Action
+	for _, n := range k.named() {
+		switch n.b {
+		case &k.Down, &k.Up, &k.Palette:
+			continue
+		}
+		if !n.b.Enabled() || len(n.b.Keys()) == 0 {
+			continue
+		}
+		h := n.b.Help()
+		out = append(out, Action{Name: n.name, Desc: h.Desc, Keys: h.Key, Msg: keyMsg(n.b.Keys()[0])})
+	}
+	return out
+}
+
+// keyTypes maps the names bubbletea gives special keys back to their type.
+var keyTypes = func() map[string]tea.KeyType {
+	out := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if s := t.String(); s != "" && t != tea.KeyRunes {
+			out[s] = t
+		}
+	}
+	return out
+}()
+
+// keyMsg builds the key press that a binding key such as "alt+1", "tab"
+// or "K" matches.
+func keyMsg(s string) tea.KeyMsg {
+	if t, ok := keyTypes[s]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	var alt bool
+	if rest, ok := strings.CutPrefix(s, "alt+"); ok && rest != "" {
+		alt, s = true, rest
+		if t, ok := keyTypes[s]; ok {
+			return tea.KeyMsg{Type: t, Alt: true}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s), Alt: alt}
+}
+
+// Help pages, in the order the help overlay shows them.
+const (
+	PageWatchlist = "Watchlist"
+	PageChart     = "Chart"
+	PageTabs      = "Tabs"
+	PageGeneral   = "General"
+	PageDialogs   = "Dialogs"
+)
+
+// helpPage files each action under a help page. Actions not listed here
+// land on the General page, so a new binding always shows somewhere.
+var helpPage = map[string]string{
+	"down": PageWatchlist, "up": PageWatchlist, "search": PageWatchlist,
+	"add_symbol": PageWatchlist, "remove_symbol": PageWatchlist,
+	"move_up": PageWatchlist, "move_down": PageWatchlist,
+	"clear_list": PageWatchlist, "undo": PageWatchlist,
+	"prev_watchlist": PageWatchlist, "next_watchlist": PageWatchlist,
+	"pick_watchlist": PageWatchlist, "sort": PageWatchlist,
+	"sort_direction": PageWatchlist, "toggle_section": PageWatchlist,
+	"session_change": PageWatchlist, "copy_table": PageWatchlist,
+	"compare_mark": PageWatchlist, "heatmap": PageWatchlist, "export_list": PageWatchlist,
+	"alert": PageWatchlist, "jump_alert": PageWatchlist, "note": PageWatchlist,
+	"alert_log": PageWatchlist, "trade": PageWatchlist,
+
+	"next_range": PageChart, "range_1h": PageChart, "anchor_range": PageChart,
+	"custom_range": PageChart, "candle_interval": PageChart,
+	"scrub_back": PageChart, "scrub_forward": PageChart,
+	"chart_type": PageChart, "indicators": PageChart,
+	"rsi_panel": PageChart, "macd_panel": PageChart,
+	"order_book": PageChart, "log_scale": PageChart, "crosshair": PageChart,
+	"zoom_in": PageChart, "zoom_out": PageChart,
+	"pan_left": PageChart, "pan_right": PageChart, "export": PageChart,
+	"stats": PageChart, "portfolio": PageChart, "portfolio_risk": PageChart,
+
+	"fundamentals": PageTabs, "earnings": PageTabs, "news": PageTabs,
+	"options": PageTabs, "prev_tab": PageTabs, "next_tab": PageTabs,
+	"tab_chart": PageTabs, "open_news": PageTabs,
+}
+
+// dialogKeys are the fixed keys every dialog shares. They are not
+// rebindable, so they have no action name.
+var dialogKeys = []key.Binding{
+	binding("Close the dialog", "esc"),
+	binding("Move through a list", "j", "k"),
+	binding("Pick or confirm", "enter"),
+	binding("Move between fields", "tab", "shift+tab"),
+}
+
+// HelpPage is one page of the help overlay: the bindings for one part of
+// the app.
+type HelpPage struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// HelpPages returns the help overlay's pages, built from the active
+// bindings. The numbered range and tab keys are folded into one row each.
+func (k KeyMap) HelpPages() []HelpPage {
+	ranges := []key.Binding{k.Range1H, k.Range24H, k.Range7D, k.Range30D, k.RangeYTD, k.Range90D, k.Range1Y, k.Range5Y}
+	tabs := []key.Binding{k.TabChart, k.TabNews, k.TabDetails, k.TabOptions}
+	pages := []HelpPage{{Title: PageWatchlist}, {Title: PageChart}, {Title: PageTabs}, {Title: PageGeneral}, {Title: PageDialogs, Bindings: dialogKeys}}
+	add := func(name string, b key.Binding) {
+		title, ok := helpPage[name]
+		if !ok {
+			title = PageGeneral
+		}
+		for i := range pages {
+			if pages[i].Title == title {
+				pages[i].Bindings = append(pages[i].Bindings, b)
+			}
+		}
+	}
+	for _, n := range k.named() {
+		switch n.b {
+		case &k.Range1H:
+			add(n.name, fold(ranges, "Range 1H/24H/7D/30D/YTD/90D/1Y/5Y"))
+		case &k.TabChart:
+			add(n.name, fold(tabs, "Tab Chart/News/Details/Options"))
+		case &k.Range24H, &k.Range7D, &k.Range30D, &k.RangeYTD, &k.Range90D, &k.Range1Y, &k.Range5Y,
+			&k.TabNews, &k.TabDetails, &k.TabOptions:
+		default:
+			if n.b.Enabled() {
+				add(n.name, *n.b)
+			}
+		}
+	}
+	return pages
+}
+
+// fold joins the first key of each binding into one help row. A modifier
+// shared by all of them is shown once, e.g. "alt+1/2/3".
+func fold(bindings []key.Binding, desc string) key.Binding {
+	var first []string
+	for _, b := range bindings {
+		if ks := b.Keys(); len(ks) > 0 {
+			first = append(first, ks[0])
+		}
+	}
+	shown := helpKeys(first)
+	if len(first) > 1 {
+		if i := strings.LastIndex(first[0], "+"); i > 0 {
+			mod := first[0][:i+1]
+			short := []string{first[0]}
+			for _, k := range first[1:] {
+				if !strings.HasPrefix(k, mod) {
+					short = nil
+					break
+				}
+				short = append(short, strings.TrimPrefix(k, mod))
+			}
+			if short != nil {
+				shown = strings.Join(short, "/")
+			}
+		}
+	}
+	if span, ok := digitSpan(first); ok {
+		shown = span
+	}
+	return key.NewBinding(key.WithKeys(first...), key.WithHelp(shown, desc))
+}
+
+// digitSpan writes a run of consecutive number keys as one span, e.g.
+// "1-8", which fits the help column where the list would not.
+func digitSpan(ks []string) (string, bool) {
+	if len(ks) < 3 {
+		return "", false
+	}
+	for i, k := range ks {
+		if len(k) != 1 || k[0] < '0' || k[0] > '9' || (i > 0 && k[0] != ks[i-1][0]+1) {
+			return "", false
+		}
+	}
+	return ks[0] + "-" + ks[len(ks)-1], true
+}
@@ -0,0 +1,276 @@
+package market
+
+import (
+	"strings"
+	"time"
+
+	// The calendars need exchange time zones even where the system has no
+	// zoneinfo database
+	_ "time/tzdata"
+)
+
+// State is where a market stands in its trading day.
+type State string
+
+const (
+	StatePre    State = "pre"
+	StateOpen   State = "open"
+	StatePost   State = "post"
+	StateClosed State = "closed"
+)
+
+// Calendar holds an exchange's trading hours and holidays. Hours are
+// minutes after midnight in the exchange's zone; a session that opens
+// later in the day than it closes starts the evening before, as FX and
+// futures do.
+type Calendar struct {
+	Name     string
+	loc      *time.Location
+	pre      int    // start of the pre-market session, or 0 for none
+	open     int    // regular session
+	close    int    // end of the regular session
+	post     int    // end of the after-hours session, or 0 for none
+	lunch    [2]int // midday break, or zero
+	always   bool   // trades around the clock, every day
+	holidays func(year int) []time.Time
+}
+
+// Status is a market's state at some moment and when it next changes.
+type Status struct {
+	Market string
+	State  State
+	Next   State     // the state after Until
+	Until  time.Time // zero when the market never closes
+}
+
+func zone(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err) // tzdata is embedded, so only a misspelt zone fails
+	}
+	return loc
+}
+
+func hm(h, m int) int { return h*60 + m }
+
+var (
+	usCalendar = &Calendar{Name: "US", loc: zone("America/New_York"),
+		pre: hm(4, 0), open: hm(9, 30), close: hm(16, 0), post: hm(20, 0), holidays: usHolidays}
+	cryptoCalendar = &Calendar{Name: "Crypto", always: true}
+	// FX trades from Sunday 17:00 to Friday 17:00 New York time
+	fxCalendar = &Calendar{Name: "FX", loc: zone("America/New_York"), open: hm(17, 0), close: hm(17, 0)}
+	// CME Globex, with its daily hour's break
+	futuresCalendar = &Calendar{Name: "CME", loc: zone("America/New_York"), open: hm(18, 0), close: hm(17, 0)}
+)
+
+// calendars maps Exchange names to their calendars. Holidays are built in
+// for the US, London, XETRA, Euronext, Toronto, ASX and Tokyo; the other
+// exchanges know their hours and weekends only.
+var calendars = map[string]*Calendar{
+	"US":                 usCalendar,
+	"XETRA":              {Name: "XETRA", loc: zone("Europe/Berlin"), open: hm(9, 0), close: hm(17, 30), holidays: xetraHolidays},
+	"Frankfurt":          {Name: "Frankfurt", loc: zone("Europe/Berlin"), open: hm(8, 0), close: hm(22, 0), holidays: xetraHolidays},
+	"Euronext Paris":     {Name: "Paris", loc: zone("Europe/Paris"), open: hm(9, 0), close: hm(17, 30), holidays: euronextHolidays},
+	"Euronext Amsterdam": {Name: "Amsterdam", loc: zone("Europe/Amsterdam"), open: hm(9, 0), close: hm(17, 30), holidays: euronextHolidays},
+	"Euronext Brussels":  {Name: "Brussels", loc: zone("Europe/Brussels"), open: hm(9, 0), close: hm(17, 30), holidays: euronextHolidays},
+	"Borsa Italiana":     {Name: "Milan", loc: zone("Europe/Rome"), open: hm(9, 0), close: hm(17, 30)},
+	"Madrid":             {Name: "Madrid", loc: zone("Europe/Madrid"), open: hm(9, 0), close: hm(17, 30)},
+	"London":             {Name: "London", loc: zone("Europe/London"), open: hm(8, 0), close: hm(16, 30), holidays: ukHolidays},
+	"SIX Swiss":          {Name: "SIX", loc: zone("Europe/Zurich"), open: hm(9, 0), close: hm(17, 30)},
+	"Stockholm":          {Name: "Stockholm", loc: zone("Europe/Stockholm"), open: hm(9, 0), close: hm(17, 30)},
+	"Oslo":               {Name: "Oslo", loc: zone("Europe/Oslo"), open: hm(9, 0), close: hm(16, 20)},
+	"Copenhagen":         {Name: "Copenhagen", loc: zone("Europe/Copenhagen"), open: hm(9, 0), close: hm(17, 0)},
+	"Tokyo":              tokyoCalendar,
+	"Hong Kong":          hongKongCalendar,
+	"Shanghai":           shanghaiCalendar,
+	"Shenzhen":           {Name: "Shenzhen", loc: zone("Asia/Shanghai"), open: hm(9, 30), close: hm(15, 0), lunch: [2]int{hm(11, 30), hm(13, 0)}},
+	"Korea":              {Name: "Korea", loc: zone("Asia/Seoul"), open: hm(9, 0), close: hm(15, 30)},
+	"NSE India":          indiaCalendar,
+	"BSE India":          {Name: "BSE", loc: zone("Asia/Kolkata"), open: hm(9, 15), close: hm(15, 30)},
+	"ASX":                {Name: "ASX", loc: zone("Australia/Sydney"), open: hm(10, 0), close: hm(16, 0), holidays: asxHolidays},
+	"Toronto":            torontoCalendar,
+	"TSX Venture":        torontoCalendar,
+	"B3 São Paulo":       {Name: "B3", loc: zone("America/Sao_Paulo"), open: hm(10, 0), close: hm(17, 0)},
+	"Singapore":          {Name: "SGX", loc: zone("Asia/Singapore"), open: hm(9, 0), close: hm(17, 0), lunch: [2]int{hm(12, 0), hm(13, 0)}},
+}
+
+var (
+	tokyoCalendar = &Calendar{Name: "Tokyo", loc: zone("Asia/Tokyo"), open: hm(9, 0), close: hm(15, 30),
+		lunch: [2]int{hm(11, 30), hm(12, 30)}, holidays: tokyoHolidays}
+	hongKongCalendar = &Calendar{Name: "Hong Kong", loc: zone("Asia/Hong_Kong"), open: hm(9, 30), close: hm(16, 0),
+		lunch: [2]int{hm(12, 0), hm(13, 0)}}
+	shanghaiCalendar = &Calendar{Name: "Shanghai", loc: zone("Asia/Shanghai"), open: hm(9, 30), close: hm(15, 0),
+		lunch: [2]int{hm(11, 30), hm(13, 0)}}
+	indiaCalendar   = &Calendar{Name: "NSE", loc: zone("Asia/Kolkata"), open: hm(9, 15), close: hm(15, 30)}
+	torontoCalendar = &Calendar{Name: "TSX", loc: zone("America/Toronto"), open: hm(9, 30), close: hm(16, 0), holidays: torontoHolidays}
+)
+
+// indexCalendars covers the "^" indices that follow a market outside the US.
+var indexCalendars = map[string]*Calendar{
+	"^NSEI":  indiaCalendar,
+	"^BSESN": calendars["BSE India"],
+	"^N225":  tokyoCalendar,
+	"^FTSE":  calendars["London"],
+	"^GDAXI": calendars["XETRA"],
+	"^FCHI":  calendars["Euronext Paris"],
+	"^HSI":   hongKongCalendar,
+}
+
+// CalendarFor returns the calendar of the market symbol trades in. Crypto
+// never closes, FX and futures trade around the clock on weekdays, and
+// yields and other indices follow the US market.
+func CalendarFor(symbol string) *Calendar {
+	switch AssetClass(symbol) {
+	case ClassCrypto:
+		return cryptoCalendar
+	case ClassFX:
+		return fxCalendar
+	case ClassFuture:
+		return futuresCalendar
+	case ClassYield:
+		return usCalendar
+	case ClassIndex:
+		if c, ok := indexCalendars[strings.ToUpper(symbol)]; ok {
+			return c
+		}
+		return usCalendar
+	}
+	if c, ok := calendars[ExchangeFor(symbol).Name]; ok {
+		return c
+	}
+	return usCalendar
+}
+
+// session is one trading day's boundaries. Without a break, brk and resume
+// both equal close.
+type session struct {
+	pre, open, brk, resume, close, post time.Time
+}
+
+// clock returns the given minutes after midnight on day, in day's zone.
+func clock(day time.Time, minutes int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, minutes, 0, 0, day.Location())
+}
+
+// session returns the trading session of day, a midnight in the exchange's
+// zone, or false on weekends and holidays.
+func (c *Calendar) session(day time.Time) (session, bool) {
+	if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday || c.holiday(day) {
+		return session{}, false
+	}
+	s := session{open: clock(day, c.open), close: clock(day, c.close)}
+	if c.open >= c.close {
+		s.open = clock(day.AddDate(0, 0, -1), c.open)
+	}
+	s.pre, s.post = s.open, s.close
+	if c.pre > 0 {
+		s.pre = clock(day, c.pre)
+	}
+	if c.post > 0 {
+		s.post = clock(day, c.post)
+	}
+	s.brk, s.resume = s.close, s.close
+	if c.lunch[1] > 0 {
+		s.brk, s.resume = clock(day, c.lunch[0]), clock(day, c.lunch[1])
+	}
+	return s, true
+}
+
+// state reports the state at t, or false when t is outside the session.
+func (s session) state(t time.Time) (State, bool) {
+	switch {
+	case t.Before(s.pre) || !t.Before(s.post):
+		return "", false
+	case t.Before(s.open):
+		return StatePre, true
+	case t.Before(s.brk):
+		return StateOpen, true
+	case t.Before(s.resume):
+		return StateClosed, true
+	case t.Before(s.close):
+		return StateOpen, true
+	default:
+		return StatePost, true
+	}
+}
+
+func (c *Calendar) holiday(day time.Time) bool {
+	if c.holidays == nil {
+		return false
+	}
+	y, m, d := day.Date()
+	for _, h := range c.holidays(y) {
+		if hy, hm, hd := h.Date(); hy == y && hm == m && hd == d {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Calendar) midnight(t time.Time) time.Time {
+	y, m, d := t.In(c.loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, c.loc)
+}
+
+// State returns the market's state at t.
+func (c *Calendar) State(t time.Time) State {
+	if c.always {
+		return StateOpen
+	}
+	// An overnight session belongs to the next day
+	day := c.midnight(t)
+	for _, d := range []time.Time{day, day.AddDate(0, 0, 1)} {
+		if s, ok := c.session(d); ok {
+			if st, ok := s.state(t); ok {
+				return st
+			}
+		}
+	}
+	return StateClosed
+}
+
+// TradingDay returns the midnight, in the exchange's zone, of the trading
+// day t falls in. An overnight session belongs to the day it closes on;
+// markets that never close run midnight to midnight UTC.
+func (c *Calendar) TradingDay(t time.Time) time.Time {
+	if c.always {
+		y, m, d := t.UTC().Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	day := c.midnight(t)
+	if c.open >= c.close && !t.Before(clock(day, c.open)) {
+		return day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// Trading reports whether quotes move at now: any state but closed.
+func (c *Calendar) Trading(now time.Time) bool {
+	return c.State(now) != StateClosed
+}
+
+// Status returns the market's state at now and when it next changes,
+// looking a couple of weeks ahead.
+func (c *Calendar) Status(now time.Time) Status {
+	st := Status{Market: c.Name, State: c.State(now)}
+	if c.always {
+		return st
+	}
+	day := c.midnight(now)
+	for i := -1; i <= 14; i++ {
+		s, ok := c.session(day.AddDate(0, 0, i))
+		if !ok {
+			continue
+		}
+		for _, b := range []time.Time{s.pre, s.open, s.brk, s.resume, s.close, s.post} {
+			if !b.After(now) {
+				continue
+			}
+			if next := c.State(b); next != st.State {
+				st.Next, st.Until = next, b
+				return st
+			}
+		}
+	}
+	return st
+}
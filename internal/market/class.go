@@ -0,0 +1,45 @@
+package market
+
+import "strings"
+
+// Asset classes recognised from a symbol's shape.
+const (
+	ClassStock  = "stock"
+	ClassCrypto = "crypto"
+	ClassYield  = "yield"
+	ClassIndex  = "index"
+	ClassFuture = "future"
+	ClassFX     = "fx"
+)
+
+// Classes lists the asset classes in the order they are documented.
+var Classes = []string{ClassStock, ClassCrypto, ClassYield, ClassIndex, ClassFuture, ClassFX}
+
+// stablecoins are the quote assets that mark an exchange pair (BTCUSDT).
+var stablecoins = []string{"USDT", "USDC", "BUSD", "FDUSD"}
+
+// AssetClass guesses what kind of asset a symbol is from its form:
+// treasury yields, "^" indices, "=F" futures, "=X" currency pairs, crypto
+// pairs like BTC-USD or BTCUSDT, and stocks for everything else.
+func AssetClass(symbol string) string {
+	sym := strings.ToUpper(symbol)
+	switch {
+	case IsYield(sym):
+		return ClassYield
+	case strings.HasPrefix(sym, "^"):
+		return ClassIndex
+	case strings.HasSuffix(sym, "=F"):
+		return ClassFuture
+	case strings.HasSuffix(sym, "=X"):
+		return ClassFX
+	}
+	if i := strings.LastIndexByte(sym, '-'); i > 0 && fiat[sym[i+1:]] {
+		return ClassCrypto
+	}
+	for _, q := range stablecoins {
+		if strings.HasSuffix(sym, q) && len(sym) > len(q) {
+			return ClassCrypto
+		}
+	}
+	return ClassStock
+}
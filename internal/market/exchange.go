@@ -0,0 +1,142 @@
+package market
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Exchange describes a listing venue identified by its Yahoo-style ticker
+// suffix (e.g. ".DE" for XETRA).
+type Exchange struct {
+	Suffix   string
+	Name     string
+	Currency string
+}
+
+// US is used for tickers without a suffix.
+var US = Exchange{Suffix: "", Name: "US", Currency: "USD"}
+
+var exchanges = map[string]Exchange{
+	"DE": {".DE", "XETRA", "EUR"},
+	"F":  {".F", "Frankfurt", "EUR"},
+	"PA": {".PA", "Euronext Paris", "EUR"},
+	"AS": {".AS", "Euronext Amsterdam", "EUR"},
+	"BR": {".BR", "Euronext Brussels", "EUR"},
+	"MI": {".MI", "Borsa Italiana", "EUR"},
+	"MC": {".MC", "Madrid", "EUR"},
+	"L":  {".L", "London", "GBp"},
+	"SW": {".SW", "SIX Swiss", "CHF"},
+	"ST": {".ST", "Stockholm", "SEK"},
+	"OL": {".OL", "Oslo", "NOK"},
+	"CO": {".CO", "Copenhagen", "DKK"},
+	"T":  {".T", "Tokyo", "JPY"},
+	"HK": {".HK", "Hong Kong", "HKD"},
+	"SS": {".SS", "Shanghai", "CNY"},
+	"SZ": {".SZ", "Shenzhen", "CNY"},
+	"KS": {".KS", "Korea", "KRW"},
+	"NS": {".NS", "NSE India", "INR"},
+	"BO": {".BO", "BSE India", "INR"},
+	"AX": {".AX", "ASX", "AUD"},
+	"TO": {".TO", "Toronto", "CAD"},
+	"V":  {".V", "TSX Venture", "CAD"},
+	"SA": {".SA", "B3 São Paulo", "BRL"},
+	"SI": {".SI", "Singapore", "SGD"},
+}
+
+// indexCurrencies covers the common "^" index tickers that have no suffix.
+var indexCurrencies = map[string]string{
+	"^NSEI":  "INR",
+	"^BSESN": "INR",
+	"^N225":  "JPY",
+	"^FTSE":  "GBP",
+	"^GDAXI": "EUR",
+	"^FCHI":  "EUR",
+	"^HSI":   "HKD",
+}
+
+// ExchangeFor returns the exchange a ticker trades on, based on its suffix.
+func ExchangeFor(symbol string) Exchange {
+	sym := strings.ToUpper(symbol)
+	if cur, ok := indexCurrencies[sym]; ok {
+		return Exchange{Name: "Index", Currency: cur}
+	}
+	if i := strings.LastIndexByte(sym, '.'); i >= 0 && i < len(sym)-1 {
+		if ex, ok := exchanges[sym[i+1:]]; ok {
+			return ex
+		}
+	}
+	return US
+}
+
+// fiat lists quote currencies accepted in crypto pairs such as BTC-EUR.
+var fiat = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "JPY": true, "INR": true,
+	"AUD": true, "CAD": true, "CHF": true, "KRW": true, "BRL": true,
+}
+
+// IsFiat reports whether code is a fiat currency accepted as a pair quote.
+func IsFiat(code string) bool {
+	return fiat[strings.ToUpper(code)]
+}
+
+// SplitPair splits a crypto pair like "ETH-EUR" into base and quote
+// currency. Bare symbols default to USD.
+func SplitPair(symbol string) (base, quote string) {
+	sym := strings.ToUpper(symbol)
+	if i := strings.LastIndexByte(sym, '-'); i > 0 {
+		if q := sym[i+1:]; fiat[q] {
+			return sym[:i], q
+		}
+	}
+	return sym, "USD"
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"GBp": "p",
+	"JPY": "¥",
+	"CNY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"HKD": "HK$",
+	"CAD": "C$",
+	"AUD": "A$",
+	"SGD": "S$",
+	"BRL": "R$",
+	"CHF": "CHF ",
+	"SEK": "kr ",
+	"NOK": "kr ",
+	"DKK": "kr ",
+	"PTS": "", // index points, e.g. symbol groups
+}
+
+// CurrencySymbol returns the display symbol for an ISO currency code.
+func CurrencySymbol(code string) string {
+	if s, ok := currencySymbols[code]; ok {
+		return s
+	}
+	if s, ok := currencySymbols[strings.ToUpper(code)]; ok {
+		return s
+	}
+	if code == "" {
+		return "$"
+	}
+	return code + " "
+}
+
+// IsSuffixSymbol reports whether the currency symbol goes after the
+// amount (pence).
+func IsSuffixSymbol(code string) bool {
+	return code == "GBp"
+}
+
+// FormatPrice renders an amount with its native currency symbol.
+func FormatPrice(v float64, code string, format string) string {
+	amount := fmt.Sprintf(format, v)
+	if IsSuffixSymbol(code) {
+		return amount + CurrencySymbol(code)
+	}
+	return CurrencySymbol(code) + amount
+}
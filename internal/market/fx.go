@@ -0,0 +1,82 @@
+package market
+
+import "strings"
+
+// minorUnits maps currencies quoted in fractional units to their major
+// currency and the factor converting into it.
+var minorUnits = map[string]struct {
+	code   string
+	factor float64
+}{
+	"GBp": {"GBP", 0.01},
+	"GBX": {"GBP", 0.01},
+	"ILA": {"ILS", 0.01},
+	"ZAc": {"ZAR", 0.01},
+}
+
+// MajorCurrency returns the ISO currency an amount in code converts
+// through and the factor to apply first: 1 GBp (pence) is 0.01 GBP. An
+// empty code is USD, as elsewhere.
+func MajorCurrency(code string) (string, float64) {
+	if m, ok := minorUnits[code]; ok {
+		return m.code, m.factor
+	}
+	if code == "" {
+		return "USD", 1
+	}
+	return strings.ToUpper(code), 1
+}
+
+// FXSymbol returns the Yahoo Finance pair quoting one unit of from in to,
+// e.g. "EURUSD=X".
+func FXSymbol(from, to string) string {
+	return from + to + "=X"
+}
+
+// Rates converts amounts into a single display currency.
+type Rates struct {
+	To    string
+	rates map[string]float64 // units of To per unit of a major currency
+}
+
+// NewRates returns conversions into to, which is empty to disable them.
+func NewRates(to string) Rates {
+	return Rates{To: strings.ToUpper(to), rates: make(map[string]float64)}
+}
+
+// Enabled reports whether a display currency is set.
+func (r Rates) Enabled() bool {
+	return r.To != ""
+}
+
+// With returns r updated with fresh rates, keyed by major currency.
+func (r Rates) With(rates map[string]float64) Rates {
+	merged := make(map[string]float64, len(r.rates)+len(rates))
+	for k, v := range r.rates {
+		merged[k] = v
+	}
+	for k, v := range rates {
+		if v > 0 {
+			merged[k] = v
+		}
+	}
+	return Rates{To: r.To, rates: merged}
+}
+
+// Convert returns v, an amount in code, in the display currency. It
+// reports false when conversion is off, v is already in the display
+// currency, the code is not a currency (index points) or no rate is known.
+func (r Rates) Convert(v float64, code string) (float64, bool) {
+	if !r.Enabled() || code == "PTS" {
+		return v, false
+	}
+	major, factor := MajorCurrency(code)
+	if major == r.To {
+		return v, false
+	}
+	rate, ok := r.rates[major]
+	if !ok {
+		return v, false
+	}
+	return v * factor * rate, true
+}
@@ -0,0 +1,208 @@
+package market
+
+import "time"
+
+// Exchange holidays, computed per year. Only full-day closures are listed;
+// early closes count as regular days.
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday returns the nth wd of the month, or the last one for n < 0.
+func nthWeekday(y int, m time.Month, wd time.Weekday, n int) time.Time {
+	if n < 0 {
+		t := date(y, m+1, 0)
+		return t.AddDate(0, 0, -((int(t.Weekday()) - int(wd) + 7) % 7))
+	}
+	t := date(y, m, 1)
+	return t.AddDate(0, 0, (int(wd)-int(t.Weekday())+7)%7+7*(n-1))
+}
+
+// easter returns Easter Sunday (anonymous Gregorian algorithm).
+func easter(y int) time.Time {
+	a, b, c := y%19, y/100, y%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(y, time.Month(month), day)
+}
+
+// nearestWeekday moves a Saturday holiday to Friday and a Sunday one to
+// Monday, as US exchanges observe them.
+func nearestWeekday(t time.Time) time.Time {
+	switch t.Weekday() {
+	case time.Saturday:
+		return t.AddDate(0, 0, -1)
+	case time.Sunday:
+		return t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// nextMonday moves a weekend holiday to the Monday after.
+func nextMonday(t time.Time) time.Time {
+	switch t.Weekday() {
+	case time.Saturday:
+		return t.AddDate(0, 0, 2)
+	case time.Sunday:
+		return t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// christmas returns Christmas and Boxing Day with weekend substitutes, as
+// observed in the UK, Canada and Australia.
+func christmas(y int) []time.Time {
+	c, b := date(y, 12, 25), date(y, 12, 26)
+	switch c.Weekday() {
+	case time.Friday:
+		b = date(y, 12, 28)
+	case time.Saturday:
+		c, b = date(y, 12, 27), date(y, 12, 28)
+	case time.Sunday:
+		c = date(y, 12, 27)
+	}
+	return []time.Time{c, b}
+}
+
+func usHolidays(y int) []time.Time {
+	newYear := date(y, 1, 1)
+	if newYear.Weekday() == time.Sunday {
+		// Not moved to Friday when it falls on a Saturday
+		newYear = newYear.AddDate(0, 0, 1)
+	}
+	out := []time.Time{
+		newYear,
+		nthWeekday(y, time.January, time.Monday, 3),  // Martin Luther King Jr. Day
+		nthWeekday(y, time.February, time.Monday, 3), // Presidents' Day
+		easter(y).AddDate(0, 0, -2),                  // Good Friday
+		nthWeekday(y, time.May, time.Monday, -1),     // Memorial Day
+		nearestWeekday(date(y, 7, 4)),
+		nthWeekday(y, time.September, time.Monday, 1),  // Labor Day
+		nthWeekday(y, time.November, time.Thursday, 4), // Thanksgiving
+		nearestWeekday(date(y, 12, 25)),
+	}
+	if y >= 2022 {
+		out = append(out, nearestWeekday(date(y, 6, 19))) // Juneteenth
+	}
+	return out
+}
+
+func ukHolidays(y int) []time.Time {
+	return append([]time.Time{
+		nextMonday(date(y, 1, 1)),
+		easter(y).AddDate(0, 0, -2),
+		easter(y).AddDate(0, 0, 1),
+		nthWeekday(y, time.May, time.Monday, 1),
+		nthWeekday(y, time.May, time.Monday, -1),
+		nthWeekday(y, time.August, time.Monday, -1),
+	}, christmas(y)...)
+}
+
+func xetraHolidays(y int) []time.Time {
+	return []time.Time{
+		date(y, 1, 1),
+		easter(y).AddDate(0, 0, -2),
+		easter(y).AddDate(0, 0, 1),
+		date(y, 5, 1),
+		date(y, 12, 24),
+		date(y, 12, 25),
+		date(y, 12, 26),
+		date(y, 12, 31),
+	}
+}
+
+func euronextHolidays(y int) []time.Time {
+	return []time.Time{
+		date(y, 1, 1),
+		easter(y).AddDate(0, 0, -2),
+		easter(y).AddDate(0, 0, 1),
+		date(y, 5, 1),
+		date(y, 12, 25),
+		date(y, 12, 26),
+	}
+}
+
+func torontoHolidays(y int) []time.Time {
+	victoria := date(y, 5, 24)
+	victoria = victoria.AddDate(0, 0, -((int(victoria.Weekday()) + 6) % 7)) // the Monday on or before
+	return append([]time.Time{
+		nextMonday(date(y, 1, 1)),
+		nthWeekday(y, time.February, time.Monday, 3), // Family Day
+		easter(y).AddDate(0, 0, -2),
+		victoria,
+		nextMonday(date(y, 7, 1)),                     // Canada Day
+		nthWeekday(y, time.August, time.Monday, 1),    // Civic Holiday
+		nthWeekday(y, time.September, time.Monday, 1), // Labour Day
+		nthWeekday(y, time.October, time.Monday, 2),   // Thanksgiving
+	}, christmas(y)...)
+}
+
+func asxHolidays(y int) []time.Time {
+	return append([]time.Time{
+		nextMonday(date(y, 1, 1)),
+		nextMonday(date(y, 1, 26)), // Australia Day
+		easter(y).AddDate(0, 0, -2),
+		easter(y).AddDate(0, 0, 1),
+		date(y, 4, 25),                           // Anzac Day
+		nthWeekday(y, time.June, time.Monday, 2), // King's Birthday
+	}, christmas(y)...)
+}
+
+// tokyoHolidays are Japan's national holidays plus the exchange's year-end
+// closure. A holiday on a Sunday moves to the next day that is not one.
+func tokyoHolidays(y int) []time.Time {
+	// Equinox days by the usual approximation, good until 2099
+	n := y - 1980
+	vernal := int(20.8431+0.242194*float64(n)) - n/4
+	autumnal := int(23.2488+0.242194*float64(n)) - n/4
+	out := []time.Time{
+		date(y, 1, 1), date(y, 1, 2), date(y, 1, 3),
+		nthWeekday(y, time.January, time.Monday, 2), // Coming of Age Day
+		date(y, 2, 11),
+		date(y, 2, 23),
+		date(y, 3, vernal),
+		date(y, 4, 29),
+		date(y, 5, 3), date(y, 5, 4), date(y, 5, 5),
+		nthWeekday(y, time.July, time.Monday, 3), // Marine Day
+		date(y, 8, 11),
+		nthWeekday(y, time.September, time.Monday, 3), // Respect for the Aged Day
+		date(y, 9, autumnal),
+		nthWeekday(y, time.October, time.Monday, 2), // Sports Day
+		date(y, 11, 3),
+		date(y, 11, 23),
+		date(y, 12, 31),
+	}
+	isHoliday := func(t time.Time) bool {
+		for _, h := range out {
+			if h.Equal(t) {
+				return true
+			}
+		}
+		return false
+	}
+	// A day between two holidays is one too
+	for _, h := range out {
+		if between := h.AddDate(0, 0, 1); !isHoliday(between) && isHoliday(h.AddDate(0, 0, 2)) {
+			out = append(out, between)
+		}
+	}
+	for _, h := range out {
+		if h.Weekday() != time.Sunday {
+			continue
+		}
+		sub := h.AddDate(0, 0, 1)
+		for isHoliday(sub) {
+			sub = sub.AddDate(0, 0, 1)
+		}
+		out = append(out, sub)
+	}
+	return out
+}
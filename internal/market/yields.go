@@ -0,0 +1,81 @@
+package market
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Yield instruments are quoted in percent, so they are displayed with a
+// % suffix and their changes in basis points rather than relative %.
+type yieldInfo struct {
+	Name  string
+	Yahoo string // Yahoo ticker, empty when Yahoo has no feed
+	Stooq string // Stooq ticker for those Yahoo lacks
+}
+
+var yields = map[string]yieldInfo{
+	"US3M":  {"US 13-Week T-Bill", "^IRX", ""},
+	"US5Y":  {"US 5-Year Treasury", "^FVX", ""},
+	"US10Y": {"US 10-Year Treasury", "^TNX", ""},
+	"US30Y": {"US 30-Year Treasury", "^TYX", ""},
+	"DE10Y": {"German 10-Year Bund", "", "10dey.b"},
+	"GB10Y": {"UK 10-Year Gilt", "", "10uky.b"},
+	"JP10Y": {"Japan 10-Year JGB", "", "10jpy.b"},
+}
+
+var yahooYields = func() map[string]string {
+	m := make(map[string]string)
+	for sym, y := range yields {
+		if y.Yahoo != "" {
+			m[y.Yahoo] = sym
+		}
+	}
+	return m
+}()
+
+// IsYield reports whether symbol is a yield instrument, either by its
+// generic name (US10Y) or its Yahoo ticker (^TNX).
+func IsYield(symbol string) bool {
+	sym := strings.ToUpper(symbol)
+	if _, ok := yields[sym]; ok {
+		return true
+	}
+	_, ok := yahooYields[sym]
+	return ok
+}
+
+// YahooTicker maps a generic yield name to its Yahoo ticker. Other symbols
+// are returned unchanged.
+func YahooTicker(symbol string) string {
+	if y, ok := yields[strings.ToUpper(symbol)]; ok && y.Yahoo != "" {
+		return y.Yahoo
+	}
+	return symbol
+}
+
+// StooqYield returns the Stooq ticker of a generic yield name Yahoo has
+// no feed for, such as DE10Y, and reports false for other symbols.
+func StooqYield(symbol string) (string, bool) {
+	y, ok := yields[strings.ToUpper(symbol)]
+	return y.Stooq, ok && y.Stooq != ""
+}
+
+// YieldChangeBP converts a relative % change of a yield into the absolute
+// change in basis points.
+func YieldChangeBP(yield, changePct float64) float64 {
+	if changePct <= -100 {
+		return 0
+	}
+	prev := yield / (1 + changePct/100)
+	return (yield - prev) * 100
+}
+
+// FormatYield renders a yield value, e.g. "4.253%".
+func FormatYield(v float64) string {
+	return fmt.Sprintf("%.3f%%", v)
+}
+
+// FormatBP renders a basis-point change, e.g. "+5.2bp".
+func FormatBP(bp float64) string {
+	return fmt.Sprintf("%+.1fbp", bp)
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // TimeRange represents the chart history range.
 type TimeRange string
@@ -10,14 +14,403 @@ const (
 	Range24H TimeRange = "24H"
 	Range7D  TimeRange = "7D"
 	Range30D TimeRange = "30D"
+	Range90D TimeRange = "90D"
+	RangeYTD TimeRange = "YTD"
+	Range1Y  TimeRange = "1Y"
+	Range5Y  TimeRange = "5Y"
+)
+
+// Ranges is the order used when cycling through the standard ranges.
+var Ranges = []TimeRange{Range1H, Range24H, Range7D, Range30D, Range90D, RangeYTD, Range1Y, Range5Y}
+
+const (
+	anchorPrefix = "@"
+	windowSep    = "~"
+	anchorLayout = "2006-01-02"
+	windowLayout = "2006-01-02T15:04"
+	intervalSep  = "/"
 )
 
+// Interval is a candle width chosen independently of the range. The zero
+// value, IntervalAuto, leaves the width to the provider, which picks one
+// to suit the range.
+type Interval string
+
+const (
+	IntervalAuto Interval = ""
+	Interval1m   Interval = "1m"
+	Interval5m   Interval = "5m"
+	Interval1h   Interval = "1h"
+	Interval1d   Interval = "1d"
+)
+
+// Intervals lists the selectable intervals, finest last but Auto first.
+var Intervals = []Interval{IntervalAuto, Interval1m, Interval5m, Interval1h, Interval1d}
+
+// Duration returns the candle width, or 0 for IntervalAuto.
+func (iv Interval) Duration() time.Duration {
+	switch iv {
+	case Interval1m:
+		return time.Minute
+	case Interval5m:
+		return 5 * time.Minute
+	case Interval1h:
+		return time.Hour
+	case Interval1d:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// Label returns the interval's display name.
+func (iv Interval) Label() string {
+	if iv == IntervalAuto {
+		return "Auto"
+	}
+	return string(iv)
+}
+
+// ParseInterval parses an interval name; "" and "auto" mean IntervalAuto.
+func ParseInterval(s string) (Interval, bool) {
+	iv := Interval(strings.ToLower(strings.TrimSpace(s)))
+	if iv == "auto" {
+		return IntervalAuto, true
+	}
+	for _, known := range Intervals {
+		if iv == known {
+			return iv, true
+		}
+	}
+	return "", false
+}
+
+// WithInterval returns the range drawn with candles of width iv. The
+// interval travels with the range, so caches and providers see it without
+// a separate parameter; IntervalAuto gives the plain range.
+func (tr TimeRange) WithInterval(iv Interval) TimeRange {
+	base := tr.Base()
+	if iv == IntervalAuto {
+		return base
+	}
+	return base + intervalSep + TimeRange(iv)
+}
+
+// Interval returns the range's chosen candle interval.
+func (tr TimeRange) Interval() Interval {
+	_, iv, _ := strings.Cut(string(tr), intervalSep)
+	return Interval(iv)
+}
+
+// Base returns the range without its candle interval.
+func (tr TimeRange) Base() TimeRange {
+	base, _, _ := strings.Cut(string(tr), intervalSep)
+	return TimeRange(base)
+}
+
+// AnchoredRange returns a range running from the given date to now.
+func AnchoredRange(from time.Time) TimeRange {
+	return TimeRange(anchorPrefix + from.Format(anchorLayout))
+}
+
+// CustomRange returns a fixed window covering the from and to dates
+// inclusively.
+func CustomRange(from, to time.Time) TimeRange {
+	return TimeRange(anchorPrefix + from.Format(anchorLayout) + windowSep + to.Format(anchorLayout))
+}
+
+// WindowRange returns a fixed window between two instants, to the minute.
+// Scrubbing through past periods uses it.
+func WindowRange(from, to time.Time) TimeRange {
+	return TimeRange(anchorPrefix + from.Format(windowLayout) + windowSep + to.Format(windowLayout))
+}
+
+// parseBound parses a range bound, either a date or a date and time. exact
+// reports the latter.
+func parseBound(s string) (t time.Time, exact bool, ok bool) {
+	if t, err := time.ParseInLocation(windowLayout, s, time.Local); err == nil {
+		return t, true, true
+	}
+	if t, err := time.ParseInLocation(anchorLayout, s, time.Local); err == nil {
+		return t, false, true
+	}
+	return time.Time{}, false, false
+}
+
+// Anchor returns the start of an anchored, custom or window range.
+func (tr TimeRange) Anchor() (time.Time, bool) {
+	s, ok := strings.CutPrefix(string(tr.Base()), anchorPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	s, _, _ = strings.Cut(s, windowSep)
+	t, _, ok := parseBound(s)
+	return t, ok
+}
+
+// IsCustom reports whether the range has a fixed end.
+func (tr TimeRange) IsCustom() bool {
+	_, _, ok := tr.customEnd()
+	return ok
+}
+
+func (tr TimeRange) customEnd() (time.Time, bool, bool) {
+	s, ok := strings.CutPrefix(string(tr.Base()), anchorPrefix)
+	if !ok {
+		return time.Time{}, false, false
+	}
+	_, end, ok := strings.Cut(s, windowSep)
+	if !ok {
+		return time.Time{}, false, false
+	}
+	return parseBound(end)
+}
+
+// Start returns the beginning of the range relative to now.
+func (tr TimeRange) Start(now time.Time) time.Time {
+	if t, ok := tr.Anchor(); ok {
+		return t
+	}
+	switch tr.Base() {
+	case Range1H:
+		return now.Add(-time.Hour)
+	case Range7D:
+		return now.AddDate(0, 0, -7)
+	case Range30D:
+		return now.AddDate(0, 0, -30)
+	case Range90D:
+		return now.AddDate(0, 0, -90)
+	case RangeYTD:
+		return time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+	case Range1Y:
+		return now.AddDate(-1, 0, 0)
+	case Range5Y:
+		return now.AddDate(-5, 0, 0)
+	default:
+		return now.Add(-24 * time.Hour)
+	}
+}
+
+// End returns the end of the range: the close of the last day for custom
+// windows, the exact end for time windows, otherwise now.
+func (tr TimeRange) End(now time.Time) time.Time {
+	if t, exact, ok := tr.customEnd(); ok {
+		end := t
+		if !exact {
+			end = t.AddDate(0, 0, 1)
+		}
+		if end.Before(now) {
+			return end
+		}
+	}
+	return now
+}
+
+// Label returns a human-readable name for the range, followed by its
+// candle interval when one is chosen.
+func (tr TimeRange) Label() string {
+	if iv := tr.Interval(); iv != IntervalAuto {
+		return tr.Base().Label() + " · " + string(iv)
+	}
+	if t, exact, ok := tr.customEnd(); ok {
+		from, _ := tr.Anchor()
+		if exact {
+			layout := "Jan 2 15:04"
+			if from.Year() != time.Now().Year() {
+				layout = "Jan 2 2006 15:04"
+			}
+			return from.Format(layout) + " – " + t.Format(layout)
+		}
+		return from.Format("Jan 2 2006") + " – " + t.Format("Jan 2 2006")
+	}
+	if t, ok := tr.Anchor(); ok {
+		return "Since " + t.Format("Jan 2 2006")
+	}
+	return string(tr)
+}
+
+// Short returns a compact name for the footer, without the interval.
+func (tr TimeRange) Short() string {
+	if tr.IsCustom() {
+		return "CUSTOM"
+	}
+	return string(tr.Base())
+}
+
+// ParseTimeRange parses a range name or an anchored "@YYYY-MM-DD" range.
+func ParseTimeRange(s string) (TimeRange, bool) {
+	tr := TimeRange(strings.ToUpper(strings.TrimSpace(s)))
+	if tr.Interval() != IntervalAuto {
+		return "", false
+	}
+	if _, ok := tr.Anchor(); ok {
+		return tr, true
+	}
+	switch tr {
+	case Range1H, Range24H, Range7D, Range30D, Range90D, RangeYTD, Range1Y, Range5Y:
+		return tr, true
+	}
+	return "", false
+}
+
 // Quote represents a snapshot of an asset's price.
 type Quote struct {
 	Symbol      string
 	Price       float64
 	ChangePct   float64
+	Currency    string // ISO code, e.g. "USD"; "GBp" for pence
 	LastUpdated time.Time
+
+	// PreviousClose is the close of the previous regular session, which
+	// ChangePct is measured from; zero when the source does not report it.
+	PreviousClose float64
+
+	// Session and range figures for the watchlist's optional columns,
+	// zero when the source does not report them.
+	Open, DayHigh, DayLow float64
+	Volume                float64 // in shares or coins
+	MarketCap             float64
+	High52, Low52         float64
+
+	// Extended-hours trading, when the source reports it. ExtChangePct is
+	// relative to the regular-session close in Price.
+	ExtSession   ExtSession
+	ExtPrice     float64
+	ExtChangePct float64
+}
+
+// ExtSession identifies an extended-hours trading session.
+type ExtSession string
+
+const (
+	SessionPre  ExtSession = "PRE" // pre-market
+	SessionPost ExtSession = "AH"  // after hours
+)
+
+// Fundamentals are per-symbol statistics that change slowly. Zero fields
+// are unknown; crypto sources have no P/E, EPS or dividend, for example.
+type Fundamentals struct {
+	Symbol        string
+	Currency      string
+	MarketCap     float64
+	PE            float64
+	EPS           float64
+	High52        float64
+	Low52         float64
+	DividendYield float64 // percent
+	Volume        float64
+	AvgVolume     float64
+	Dividends     []Dividend // past payouts, most recent first
+}
+
+// Dividend is one payout, dated by its ex-dividend date.
+type Dividend struct {
+	ExDate time.Time
+	Amount float64 // per share, in the listing currency
+}
+
+// OptionContract is one strike of an option chain. ImpliedVol is a
+// fraction (0.25 for 25%).
+type OptionContract struct {
+	Strike       float64
+	Last         float64
+	Bid          float64
+	Ask          float64
+	Volume       float64
+	OpenInterest float64
+	ImpliedVol   float64
+	InTheMoney   bool
+}
+
+// OptionChain holds a symbol's calls and puts for one expiry, by ascending
+// strike, along with the other listed expiries.
+type OptionChain struct {
+	Symbol     string
+	Underlying float64 // price of the underlying when fetched
+	Expiry     time.Time
+	Expiries   []time.Time
+	Calls      []OptionContract
+	Puts       []OptionContract
+}
+
+// Times of day a company reports earnings at, when known.
+const (
+	EarningsBeforeOpen = "BMO"
+	EarningsAfterClose = "AMC"
+)
+
+// Earnings is a company's next earnings report, with its next
+// ex-dividend date from the same calendar. Either date is zero when none
+// is listed. Timing is EarningsBeforeOpen, EarningsAfterClose or empty
+// when not announced.
+type Earnings struct {
+	Symbol      string
+	Date        time.Time
+	Timing      string
+	EPSEstimate float64 // consensus per share; 0 when there is none
+	Estimated   bool    // date projected rather than confirmed
+	ExDividend  time.Time
+}
+
+// EarningsSoonDays is how many days ahead a report counts as coming up.
+const EarningsSoonDays = 7
+
+// DaysUntil returns the calendar days from now's date to the report's,
+// in local time: 0 on the day, negative once past.
+func (e Earnings) DaysUntil(now time.Time) int {
+	return calendarDays(now, e.Date)
+}
+
+// ExDividendTomorrow reports whether the ex-dividend date is the day
+// after now's, the last day to buy in time for the payout.
+func (e Earnings) ExDividendTomorrow(now time.Time) bool {
+	return !e.ExDividend.IsZero() && calendarDays(now, e.ExDividend) == 1
+}
+
+// calendarDays counts the days from from's local date to to's.
+func calendarDays(from, to time.Time) int {
+	day := func(t time.Time) time.Time {
+		t = t.Local()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return int(day(to).Sub(day(from)).Hours() / 24)
+}
+
+// Soon reports whether the report is due within EarningsSoonDays.
+func (e Earnings) Soon(now time.Time) bool {
+	d := e.DaysUntil(now)
+	return !e.Date.IsZero() && d >= 0 && d <= EarningsSoonDays
+}
+
+// BookLevel is one price level of an order book.
+type BookLevel struct {
+	Price float64
+	Size  float64 // in the base asset
+}
+
+// OrderBook holds the best bids, highest first, and the best asks, lowest
+// first, of a trading pair.
+type OrderBook struct {
+	Symbol string
+	Bids   []BookLevel
+	Asks   []BookLevel
+	Time   time.Time
+}
+
+// Headline is a news story about a symbol.
+type Headline struct {
+	Title     string
+	Publisher string
+	URL       string
+	Published time.Time
+}
+
+// SymbolMatch is a result of a symbol lookup.
+type SymbolMatch struct {
+	Symbol   string
+	Name     string
+	Exchange string
+	Type     string // e.g. "Equity", "ETF", "Cryptocurrency"
 }
 
 // Candle represents a single data point in a historical chart.
@@ -30,10 +423,368 @@ type Candle struct {
 	Volume    float64
 }
 
-// AppConfig holds the complete run configuration.
-type AppConfig struct {
+// IndicatorConfig holds the chart overlay and oscillator panel
+// parameters.
+type IndicatorConfig struct {
+	SMAFast         int     `mapstructure:"sma_fast"`
+	SMASlow         int     `mapstructure:"sma_slow"`
+	EMA             int     `mapstructure:"ema"`
+	BollingerPeriod int     `mapstructure:"bollinger_period"`
+	BollingerStdDev float64 `mapstructure:"bollinger_stddev"`
+	RSIPeriod       int     `mapstructure:"rsi_period"`
+	RSIOverbought   float64 `mapstructure:"rsi_overbought"`
+	RSIOversold     float64 `mapstructure:"rsi_oversold"`
+	MACDFast        int     `mapstructure:"macd_fast"`
+	MACDSlow        int     `mapstructure:"macd_slow"`
+	MACDSignal      int     `mapstructure:"macd_signal"`
+	RSIPanel        bool    `mapstructure:"rsi_panel"`  // shown at startup
+	MACDPanel       bool    `mapstructure:"macd_panel"` // shown at startup
+}
+
+// TickerConfig controls the scrolling quote strip above the footer.
+type TickerConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Speed   time.Duration `mapstructure:"speed"`
+	Fields  []string      `mapstructure:"fields"`
+}
+
+// ExportConfig controls where and how the export key writes chart data.
+type ExportConfig struct {
+	Dir             string `mapstructure:"dir"`
+	Format          string `mapstructure:"format"`           // csv or json
+	Snapshot        string `mapstructure:"snapshot"`         // "", text or ansi
+	WatchlistFormat string `mapstructure:"watchlist_format"` // csv or text
+	Width           int    `mapstructure:"width"`            // snapshot size; 0 is the pane's
+	Height          int    `mapstructure:"height"`
+}
+
+// AlertRule configures price thresholds for a symbol. Zero values are
+// ignored, so a rule can set any combination of thresholds.
+type AlertRule struct {
+	Symbol  string  `mapstructure:"symbol"`
+	Above   float64 `mapstructure:"above"`
+	Below   float64 `mapstructure:"below"`
+	MovePct float64 `mapstructure:"move_pct"`
+}
+
+// AlertSchedule limits when alerts are evaluated: at most once per
+// Interval, not between QuietStart and QuietEnd (local "22:00"), and with
+// MarketHours only while the symbol's market is open.
+type AlertSchedule struct {
+	Interval    time.Duration `mapstructure:"interval"`
+	QuietStart  string        `mapstructure:"quiet_start"`
+	QuietEnd    string        `mapstructure:"quiet_end"`
+	MarketHours bool          `mapstructure:"market_hours"`
+}
+
+// SymbolAlias maps a ticker to a friendlier display name.
+type SymbolAlias struct {
+	Symbol string `mapstructure:"symbol"`
+	Name   string `mapstructure:"name"`
+}
+
+// Aliases resolves display names for symbols.
+type Aliases map[string]string
+
+// Name returns the alias for symbol, or the symbol itself.
+func (a Aliases) Name(symbol string) string {
+	if name, ok := a[symbol]; ok && name != "" {
+		return name
+	}
+	return symbol
+}
+
+// Watchlist is a named set of symbols. RefreshInterval, Provider and
+// Pinned override the top-level settings for this list when set.
+type Watchlist struct {
+	Name            string        `mapstructure:"name"`
 	Symbols         []string      `mapstructure:"symbols"`
 	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 	Provider        string        `mapstructure:"provider"`
-	DefaultRange    string        `mapstructure:"default_range"`
+	Pinned          string        `mapstructure:"pinned"`
+}
+
+// Layout is a named snapshot of the screen: the active watchlist, main
+// view ("split" or "heatmap"), open panes and the watchlist pane's width
+// as a percent of the terminal (0 for the automatic width).
+type Layout struct {
+	Name           string `mapstructure:"name"`
+	Watchlist      string `mapstructure:"watchlist"`
+	View           string `mapstructure:"view"`
+	Tab            string `mapstructure:"tab"`     // chart, news, details or options
+	News           bool   `mapstructure:"news"`    // older layouts: news tab
+	Details        bool   `mapstructure:"details"` // older layouts: details tab
+	WatchlistWidth int    `mapstructure:"watchlist_width"`
+}
+
+// Section is a titled run of watchlist rows that folds under its header.
+// A symbol listed in several sections goes in the first.
+type Section struct {
+	Name      string   `mapstructure:"name"`
+	Symbols   []string `mapstructure:"symbols"`
+	Collapsed bool     `mapstructure:"collapsed"` // folded at startup
+}
+
+// SymbolGroup is a basket of symbols shown as one synthetic row. Weights
+// pair with Symbols by position; missing weights count as 1, so an
+// unweighted group is an equal-weight basket.
+type SymbolGroup struct {
+	Name    string    `mapstructure:"name"`
+	Symbols []string  `mapstructure:"symbols"`
+	Weights []float64 `mapstructure:"weights"`
+}
+
+// Weight returns the weight of the i-th member.
+func (g SymbolGroup) Weight(i int) float64 {
+	if i < len(g.Weights) && g.Weights[i] > 0 {
+		return g.Weights[i]
+	}
+	return 1
+}
+
+// Portfolio is a set of holdings built up from transactions, charted
+// against a benchmark symbol. RiskFreeRate is a yearly percent, for the
+// Sharpe ratio; Sectors maps symbols to the sector they are counted in.
+// PaperCash is the cash a new paper-trading account starts with.
+type Portfolio struct {
+	Benchmark    string            `mapstructure:"benchmark"`
+	RiskFreeRate float64           `mapstructure:"risk_free_rate"`
+	Sectors      map[string]string `mapstructure:"sectors"`
+	Transactions []Transaction     `mapstructure:"transactions"`
+	PaperCash    float64           `mapstructure:"paper_cash"`
+}
+
+// Sector returns the configured sector of symbol. Config keys arrive
+// lowercased, so the lookup ignores case.
+func (p Portfolio) Sector(symbol string) string {
+	for sym, sector := range p.Sectors {
+		if strings.EqualFold(sym, symbol) {
+			return sector
+		}
+	}
+	return ""
+}
+
+// Transaction buys (positive shares) or sells (negative) a symbol on a
+// date, YYYY-MM-DD.
+type Transaction struct {
+	Symbol string  `mapstructure:"symbol"`
+	Date   string  `mapstructure:"date"`
+	Shares float64 `mapstructure:"shares"`
+}
+
+// Day returns the start of the transaction's date in local time.
+func (t Transaction) Day() (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", t.Date, time.Local)
+}
+
+// SyntheticSymbol is a symbol computed from an expression over others,
+// e.g. "AAPL / MSFT". Name is what goes in a symbols list; it defaults to
+// the expression itself.
+type SyntheticSymbol struct {
+	Name string `mapstructure:"name"`
+	Expr string `mapstructure:"expr"`
+}
+
+// Label returns the symbol used for the synthetic.
+func (s SyntheticSymbol) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Expr
+}
+
+// ThemeColors overrides individual colors of the selected theme. Values
+// are hex codes or ANSI color numbers; empty fields keep the theme's color.
+type ThemeColors struct {
+	Primary   string   `mapstructure:"primary"`
+	Secondary string   `mapstructure:"secondary"`
+	Success   string   `mapstructure:"success"`
+	Warning   string   `mapstructure:"warning"`
+	Error     string   `mapstructure:"error"`
+	Text      string   `mapstructure:"text"`
+	Subtext   string   `mapstructure:"subtext"`
+	Highlight string   `mapstructure:"highlight"`
+	Surface   string   `mapstructure:"surface"`
+	Inverse   string   `mapstructure:"inverse"`
+	Overlay   []string `mapstructure:"overlay"`
+}
+
+// ChartColors overrides the chart's up and down colors for one symbol or
+// for an asset class ("stock", "crypto", "yield", "index", "future",
+// "fx"). A symbol rule wins over a class rule; empty colors keep the
+// theme's.
+type ChartColors struct {
+	Symbol string `mapstructure:"symbol"`
+	Class  string `mapstructure:"class"`
+	Up     string `mapstructure:"up"`
+	Down   string `mapstructure:"down"`
+}
+
+// RefreshRule sets the quote refresh interval for a symbol (including a
+// group or synthetic name) or for an asset class. A symbol rule wins over
+// a class rule, and either wins over the watchlist's own interval.
+type RefreshRule struct {
+	Symbol   string        `mapstructure:"symbol"`
+	Class    string        `mapstructure:"class"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// RateLimit is a provider's outbound request budget: PerMinute requests on
+// average, with bursts of up to Burst at once.
+type RateLimit struct {
+	PerMinute float64 `mapstructure:"per_minute"`
+	Burst     int     `mapstructure:"burst"`
+}
+
+// GenericProvider describes a JSON API for the "generic" provider: URL
+// templates for quotes and history, and the paths to the fields in their
+// responses.
+type GenericProvider struct {
+	Name       string            `mapstructure:"name"`
+	QuoteURL   string            `mapstructure:"quote_url"`
+	HistoryURL string            `mapstructure:"history_url"`
+	Headers    map[string]string `mapstructure:"headers"`
+	Intervals  map[string]string `mapstructure:"intervals"` // candle width → the API's name for it
+	Quote      GenericQuote      `mapstructure:"quote"`
+	History    GenericHistory    `mapstructure:"history"`
+}
+
+// GenericQuote holds the paths to quote fields. Price is required; the
+// change comes from ChangePct, Change or PrevClose, whichever is set.
+type GenericQuote struct {
+	Price     string `mapstructure:"price"`
+	ChangePct string `mapstructure:"change_pct"`
+	Change    string `mapstructure:"change"`
+	PrevClose string `mapstructure:"prev_close"`
+	Currency  string `mapstructure:"currency"`
+}
+
+// GenericHistory holds the paths to candle fields. With Candles set the
+// other paths are relative to each element of that array; without it
+// they lead to parallel arrays, one value per candle. TimeFormat is
+// "unix" (the default), "unix_ms", "rfc3339" or a Go time layout.
+type GenericHistory struct {
+	Candles    string `mapstructure:"candles"`
+	Time       string `mapstructure:"time"`
+	Open       string `mapstructure:"open"`
+	High       string `mapstructure:"high"`
+	Low        string `mapstructure:"low"`
+	Close      string `mapstructure:"close"`
+	Volume     string `mapstructure:"volume"`
+	TimeFormat string `mapstructure:"time_format"`
+}
+
+// PluginProvider is an external program run as the "plugin" provider. It
+// answers JSON requests on stdin with JSON responses on stdout.
+type PluginProvider struct {
+	Name    string   `mapstructure:"name"`
+	Command []string `mapstructure:"command"` // program and its arguments
+}
+
+// AppConfig holds the complete run configuration.
+type AppConfig struct {
+	Symbols         []string             `mapstructure:"symbols"`
+	Pinned          string               `mapstructure:"pinned"`
+	RefreshInterval time.Duration        `mapstructure:"refresh_interval"`
+	RefreshRules    []RefreshRule        `mapstructure:"refresh_rules"`
+	PauseWhenClosed bool                 `mapstructure:"pause_when_closed"`
+	Provider        string               `mapstructure:"provider"`
+	Providers       []string             `mapstructure:"providers"`
+	FinnhubAPIKey   string               `mapstructure:"finnhub_api_key"`
+	RateLimits      map[string]RateLimit `mapstructure:"rate_limits"`
+	Generic         GenericProvider      `mapstructure:"generic"`
+	Plugin          PluginProvider       `mapstructure:"plugin"`
+	DefaultRange    string               `mapstructure:"default_range"`
+	CandleInterval  string               `mapstructure:"candle_interval"`
+	Indicators      IndicatorConfig      `mapstructure:"indicators"`
+	ChartResolution string               `mapstructure:"chart_resolution"`
+	ChartScale      string               `mapstructure:"chart_scale"`
+	SessionChange   bool                 `mapstructure:"session_change"`
+	ReducedMotion   bool                 `mapstructure:"reduced_motion"`
+	LowBandwidth    bool                 `mapstructure:"low_bandwidth"`
+	ExtendedHours   bool                 `mapstructure:"extended_hours"`
+	CopyFormat      string               `mapstructure:"copy_format"`
+	DisplayCurrency string               `mapstructure:"display_currency"`
+	AlertRange      string               `mapstructure:"alert_range"`
+	Ticker          TickerConfig         `mapstructure:"ticker"`
+	Export          ExportConfig         `mapstructure:"export"`
+	ShowVolume      bool                 `mapstructure:"show_volume"`
+	ChartGrid       bool                 `mapstructure:"chart_grid"`
+	ChartGridRows   int                  `mapstructure:"chart_grid_rows"`
+	PrevCloseLine   bool                 `mapstructure:"previous_close_line"`
+	Benchmark       string               `mapstructure:"benchmark"`
+	VolumeMinHeight int                  `mapstructure:"volume_min_height"`
+	Alerts          []AlertRule          `mapstructure:"alerts"`
+	AlertSchedule   AlertSchedule        `mapstructure:"alert_schedule"`
+	AlertLogMax     int                  `mapstructure:"alert_log_max"`
+	Notifications   bool                 `mapstructure:"notifications"`
+	Aliases         []SymbolAlias        `mapstructure:"aliases"`
+	Watchlists      []Watchlist          `mapstructure:"watchlists"`
+	Layouts         []Layout             `mapstructure:"layouts"`
+	Groups          []SymbolGroup        `mapstructure:"groups"`
+	Portfolio       Portfolio            `mapstructure:"portfolio"`
+	Sections        []Section            `mapstructure:"sections"`
+	Columns         []string             `mapstructure:"watchlist_columns"`
+	WatchlistWidth  int                  `mapstructure:"watchlist_width"` // percent; 0 is automatic
+	PaneLayout      string               `mapstructure:"pane_layout"`
+	Synthetics      []SyntheticSymbol    `mapstructure:"synthetics"`
+	Keys            map[string][]string  `mapstructure:"keys"`
+	OnStart         []string             `mapstructure:"on_start"`
+	NoCache         bool                 `mapstructure:"no_cache"`
+	ReadOnly        bool                 `mapstructure:"read_only"`
+	RestoreSession  bool                 `mapstructure:"restore_session"`
+	Theme           string               `mapstructure:"theme"`
+	Colors          ThemeColors          `mapstructure:"colors"`
+	ChartColors     []ChartColors        `mapstructure:"chart_colors"`
+}
+
+// Lists returns the configured watchlists, falling back to a single list
+// built from Symbols when none are defined. Unset per-list refresh
+// intervals and pins inherit the top-level values; an empty Provider means
+// the default chain from ProviderChain.
+func (c *AppConfig) Lists() []Watchlist {
+	var lists []Watchlist
+	for i, wl := range c.Watchlists {
+		if len(wl.Symbols) == 0 {
+			continue
+		}
+		if wl.Name == "" {
+			wl.Name = fmt.Sprintf("List %d", i+1)
+		}
+		if wl.RefreshInterval <= 0 {
+			wl.RefreshInterval = c.RefreshInterval
+		}
+		if wl.Pinned == "" {
+			wl.Pinned = c.Pinned
+		}
+		lists = append(lists, wl)
+	}
+	if len(lists) == 0 {
+		lists = []Watchlist{{
+			Name:            "Watchlist",
+			Symbols:         c.Symbols,
+			RefreshInterval: c.RefreshInterval,
+			Pinned:          c.Pinned,
+		}}
+	}
+	return lists
+}
+
+// ProviderChain returns the default providers in failover order: the
+// providers list when set, otherwise the single provider.
+func (c *AppConfig) ProviderChain() []string {
+	if len(c.Providers) > 0 {
+		return c.Providers
+	}
+	return []string{c.Provider}
+}
+
+// AliasMap indexes the configured aliases by symbol.
+func (c *AppConfig) AliasMap() Aliases {
+	a := make(Aliases, len(c.Aliases))
+	for _, al := range c.Aliases {
+		a[al.Symbol] = al.Name
+	}
+	return a
 }
@@ -0,0 +1,296 @@
+package paper
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Side is whether an order buys or sells.
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+// Order is a simulated order. A market order has no Limit and fills at
+// the next quote; a limit order waits for a price at its limit or better.
+type Order struct {
+	ID     int       `json:"id"`
+	Symbol string    `json:"symbol"`
+	Side   Side      `json:"side"`
+	Shares float64   `json:"shares"`
+	Limit  float64   `json:"limit,omitempty"`
+	Placed time.Time `json:"placed"`
+}
+
+func (o Order) String() string {
+	s := fmt.Sprintf("%s %s %s", o.Side, strconv.FormatFloat(o.Shares, 'f', -1, 64), o.Symbol)
+	if o.Limit > 0 {
+		return s + fmt.Sprintf(" @ %.2f", o.Limit)
+	}
+	return s + " at market"
+}
+
+// Fill records an order executed against a quote. Realized is the profit
+// or loss a sale locked in against the average cost.
+type Fill struct {
+	Order
+	Price    float64   `json:"price"`
+	At       time.Time `json:"at"`
+	Realized float64   `json:"realized,omitempty"`
+}
+
+// Message describes the fill, using name for the symbol.
+func (f Fill) Message(name string) string {
+	s := fmt.Sprintf("Filled: %s %s %s at %.2f", f.Side, strconv.FormatFloat(f.Shares, 'f', -1, 64), name, f.Price)
+	if f.Side == Sell {
+		s += fmt.Sprintf(" (P&L %+.2f)", f.Realized)
+	}
+	return s
+}
+
+// Position is the shares held of a symbol and their average cost.
+type Position struct {
+	Symbol string  `json:"symbol"`
+	Shares float64 `json:"shares"`
+	Cost   float64 `json:"cost"` // average price paid per share
+}
+
+// Account is the simulated cash balance, positions, open orders and past
+// fills. It is saved whole as JSON. Positions cannot go short, and cash
+// cannot go below zero.
+type Account struct {
+	Cash      float64    `json:"cash"`
+	Positions []Position `json:"positions"`
+	Orders    []Order    `json:"orders"` // open, oldest first
+	Fills     []Fill     `json:"fills"`
+	Realized  float64    `json:"realized"`
+	NextID    int        `json:"next_id"`
+}
+
+// New returns an account holding cash and nothing else.
+func New(cash float64) *Account {
+	return &Account{Cash: cash, NextID: 1}
+}
+
+// Clone returns a copy sharing nothing with a, for saving in the
+// background.
+func (a *Account) Clone() Account {
+	c := *a
+	c.Positions = slices.Clone(a.Positions)
+	c.Orders = slices.Clone(a.Orders)
+	c.Fills = slices.Clone(a.Fills)
+	return c
+}
+
+// Position returns the shares held of symbol; zero when none.
+func (a *Account) Position(symbol string) Position {
+	if i := a.position(symbol); i >= 0 {
+		return a.Positions[i]
+	}
+	return Position{Symbol: symbol}
+}
+
+func (a *Account) position(symbol string) int {
+	return slices.IndexFunc(a.Positions, func(p Position) bool { return p.Symbol == symbol })
+}
+
+// OrdersFor returns the open orders on symbol.
+func (a *Account) OrdersFor(symbol string) []Order {
+	var out []Order
+	for _, o := range a.Orders {
+		if o.Symbol == symbol {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// Cancel removes the open orders on symbol and returns how many there were.
+func (a *Account) Cancel(symbol string) int {
+	n := len(a.Orders)
+	a.Orders = slices.DeleteFunc(a.Orders, func(o Order) bool { return o.Symbol == symbol })
+	return n - len(a.Orders)
+}
+
+// Place queues o after checking the account can cover it: a buy's cost at
+// its limit, or at the quoted price for a market order, against the cash
+// not already committed to open buys; a sale against the shares not
+// already committed to open sales. prices holds the latest quotes; a
+// market order on a symbol without one is refused, as nothing bounds what
+// it would fill at.
+func (a *Account) Place(o Order, prices map[string]float64) (Order, error) {
+	price := prices[o.Symbol]
+	if o.Limit == 0 && price <= 0 {
+		return o, fmt.Errorf("no quote for %s yet; give a limit price", o.Symbol)
+	}
+	committed := 0.0
+	for _, open := range a.Orders {
+		switch {
+		case open.Side == Buy && o.Side == Buy:
+			committed += open.Shares * open.reserve(prices)
+		case open.Side == Sell && o.Side == Sell && open.Symbol == o.Symbol:
+			committed += open.Shares
+		}
+	}
+	switch o.Side {
+	case Buy:
+		cost := o.Shares * o.reserve(prices)
+		if cost > a.Cash-committed {
+			return o, fmt.Errorf("not enough cash: %.2f needed, %.2f free", cost, max(0, a.Cash-committed))
+		}
+	case Sell:
+		if free := a.Position(o.Symbol).Shares - committed; o.Shares > free {
+			return o, fmt.Errorf("only %s shares free to sell", strconv.FormatFloat(max(0, free), 'f', -1, 64))
+		}
+	}
+	o.ID = a.NextID
+	a.NextID++
+	o.Placed = time.Now()
+	a.Orders = append(a.Orders, o)
+	return o, nil
+}
+
+// reserve returns the price a buy holds cash at while open: its limit, or
+// the latest quote for a market order.
+func (o Order) reserve(prices map[string]float64) float64 {
+	if o.Limit > 0 {
+		return o.Limit
+	}
+	return prices[o.Symbol]
+}
+
+// Evaluate fills the open orders the latest quotes reach, at the quoted
+// price, and returns the fills. Orders the account can no longer cover
+// when they fill, as when the price ran up under a market buy, are
+// dropped and returned as rejected.
+func (a *Account) Evaluate(quotes []models.Quote) (fills []Fill, rejected []Order) {
+	qmap := make(map[string]models.Quote, len(quotes))
+	for _, q := range quotes {
+		qmap[q.Symbol] = q
+	}
+	kept := a.Orders[:0]
+	for _, o := range a.Orders {
+		q, ok := qmap[o.Symbol]
+		if !ok || q.Price <= 0 || !reaches(o, q.Price) {
+			kept = append(kept, o)
+			continue
+		}
+		f, err := a.fill(o, q.Price)
+		if err != nil {
+			rejected = append(rejected, o)
+			continue
+		}
+		fills = append(fills, f)
+	}
+	a.Orders = kept
+	a.Fills = append(a.Fills, fills...)
+	return fills, rejected
+}
+
+// reaches reports whether price fills o.
+func reaches(o Order, price float64) bool {
+	switch {
+	case o.Limit == 0:
+		return true
+	case o.Side == Buy:
+		return price <= o.Limit
+	default:
+		return price >= o.Limit
+	}
+}
+
+// fill executes o at price, moving cash and shares.
+func (a *Account) fill(o Order, price float64) (Fill, error) {
+	f := Fill{Order: o, Price: price, At: time.Now()}
+	i := a.position(o.Symbol)
+	switch o.Side {
+	case Buy:
+		cost := o.Shares * price
+		if cost > a.Cash {
+			return f, errors.New("not enough cash")
+		}
+		a.Cash -= cost
+		if i < 0 {
+			a.Positions = append(a.Positions, Position{Symbol: o.Symbol})
+			i = len(a.Positions) - 1
+		}
+		p := &a.Positions[i]
+		p.Cost = (p.Cost*p.Shares + cost) / (p.Shares + o.Shares)
+		p.Shares += o.Shares
+	case Sell:
+		if i < 0 || a.Positions[i].Shares < o.Shares {
+			return f, errors.New("not enough shares")
+		}
+		p := &a.Positions[i]
+		f.Realized = (price - p.Cost) * o.Shares
+		a.Realized += f.Realized
+		a.Cash += o.Shares * price
+		p.Shares -= o.Shares
+		// Sold out, allowing for float error on fractional shares
+		if p.Shares < 1e-9 {
+			a.Positions = slices.Delete(a.Positions, i, i+1)
+		}
+	}
+	return f, nil
+}
+
+// Value returns the market value of the positions at prices, and their
+// unrealized profit or loss. Positions without a price count at cost.
+func (a *Account) Value(prices map[string]float64) (value, unrealized float64) {
+	for _, p := range a.Positions {
+		price := prices[p.Symbol]
+		if price <= 0 {
+			price = p.Cost
+		}
+		value += p.Shares * price
+		unrealized += p.Shares * (price - p.Cost)
+	}
+	return value, unrealized
+}
+
+// Parse reads an interactive order: "buy 10" or "sell 5" at market, with
+// "@ 180" or "at 180" after it for a limit order. "b" and "s" are short
+// for buy and sell.
+func Parse(symbol, spec string) (Order, error) {
+	o := Order{Symbol: symbol}
+	f := strings.Fields(strings.ToLower(strings.ReplaceAll(spec, "@", " @ ")))
+	if len(f) == 0 {
+		return o, errors.New("use buy N or sell N, with @ price for a limit")
+	}
+	switch f[0] {
+	case "buy", "b":
+		o.Side = Buy
+	case "sell", "s":
+		o.Side = Sell
+	default:
+		return o, errors.New("use buy N or sell N, with @ price for a limit")
+	}
+	if len(f) < 2 {
+		return o, errors.New("how many shares?")
+	}
+	n, err := strconv.ParseFloat(f[1], 64)
+	if err != nil || n <= 0 {
+		return o, fmt.Errorf("invalid share count %q", f[1])
+	}
+	o.Shares = n
+	switch {
+	case len(f) == 2:
+	case len(f) == 4 && (f[2] == "@" || f[2] == "at"):
+		v, err := strconv.ParseFloat(f[3], 64)
+		if err != nil || v <= 0 {
+			return o, fmt.Errorf("invalid limit %q", f[3])
+		}
+		o.Limit = v
+	default:
+		return o, errors.New("use buy N or sell N, with @ price for a limit")
+	}
+	return o, nil
+}
@@ -0,0 +1,48 @@
+package paper
+
+import "testing"
+
+func TestPlaceMarketWithoutQuote(t *testing.T) {
+	a := New(1000)
+	for _, o := range []Order{
+		{Symbol: "AAPL", Side: Buy, Shares: 1},
+		{Symbol: "AAPL", Side: Sell, Shares: 1},
+	} {
+		if _, err := a.Place(o, map[string]float64{"MSFT": 100}); err == nil {
+			t.Errorf("Place(%s) with no quote succeeded, want an error", o)
+		}
+	}
+	if len(a.Orders) != 0 {
+		t.Errorf("%d orders queued, want none", len(a.Orders))
+	}
+
+	// A limit order bounds its own price, so it needs no quote
+	if _, err := a.Place(Order{Symbol: "AAPL", Side: Buy, Shares: 1, Limit: 90}, nil); err != nil {
+		t.Errorf("Place(limit buy) with no quote: %v", err)
+	}
+}
+
+func TestPlaceReservesMarketBuys(t *testing.T) {
+	prices := map[string]float64{"AAPL": 100, "MSFT": 50}
+	a := New(1000)
+	if _, err := a.Place(Order{Symbol: "AAPL", Side: Buy, Shares: 6}, prices); err != nil {
+		t.Fatalf("Place(buy 6 AAPL): %v", err)
+	}
+
+	// 600 is held for the open market buy, leaving 400
+	for _, o := range []Order{
+		{Symbol: "AAPL", Side: Buy, Shares: 5},
+		{Symbol: "MSFT", Side: Buy, Shares: 9},
+		{Symbol: "MSFT", Side: Buy, Shares: 5, Limit: 90},
+	} {
+		if _, err := a.Place(o, prices); err == nil {
+			t.Errorf("Place(%s) over the free cash succeeded, want an error", o)
+		}
+	}
+	if _, err := a.Place(Order{Symbol: "MSFT", Side: Buy, Shares: 8}, prices); err != nil {
+		t.Errorf("Place(buy 8 MSFT) within the free cash: %v", err)
+	}
+	if len(a.Orders) != 2 {
+		t.Errorf("%d orders queued, want 2", len(a.Orders))
+	}
+}
@@ -0,0 +1,175 @@
+package alertlog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/alerts"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// ClearMsg asks for the alert log to be emptied, after the user confirmed.
+type ClearMsg struct{}
+
+// Model is the alert log modal: the alerts that fired, newest first.
+type Model struct {
+	toggle  key.Binding
+	visible bool
+	width   int
+	height  int
+
+	rows     []alerts.LogEntry // newest first
+	offset   int
+	clearing bool // c pressed once, waiting for the second to confirm
+}
+
+// New builds the alert log modal; toggle also closes it.
+func New(toggle key.Binding) Model {
+	return Model{toggle: toggle}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	k, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	clearing := m.clearing
+	m.clearing = false
+	page := m.pageRows()
+	last := max(0, len(m.rows)-page)
+	switch {
+	case key.Matches(k, m.toggle) || k.String() == "esc" || k.String() == "q":
+		m.visible = false
+	case k.String() == "j" || k.String() == "down":
+		m.offset = min(m.offset+1, last)
+	case k.String() == "k" || k.String() == "up":
+		m.offset = max(m.offset-1, 0)
+	case k.String() == "pgdown" || k.String() == "ctrl+d":
+		m.offset = min(m.offset+page, last)
+	case k.String() == "pgup" || k.String() == "ctrl+u":
+		m.offset = max(m.offset-page, 0)
+	case k.String() == "g" || k.String() == "home":
+		m.offset = 0
+	case k.String() == "G" || k.String() == "end":
+		m.offset = last
+	case k.String() == "c" && len(m.rows) > 0:
+		if clearing {
+			return m, func() tea.Msg { return ClearMsg{} }
+		}
+		m.clearing = true
+	}
+	return m, nil
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Open shows the modal from the newest entry.
+func (m *Model) Open() {
+	m.visible = true
+	m.offset = 0
+	m.clearing = false
+}
+
+// SetEntries shows log, which is oldest first as it is kept.
+func (m *Model) SetEntries(log []alerts.LogEntry) {
+	m.rows = slices.Clone(log)
+	slices.Reverse(m.rows)
+	m.offset = min(m.offset, max(0, len(m.rows)-m.pageRows()))
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// pageRows is the number of entries that fit in the modal.
+func (m Model) pageRows() int {
+	return max(3, m.height-12)
+}
+
+// stamp formats when an alert fired: the time alone today, the date
+// within the year, the full date before that.
+func stamp(t, now time.Time) string {
+	t = t.Local()
+	switch {
+	case t.YearDay() == now.YearDay() && t.Year() == now.Year():
+		return t.Format("15:04:05")
+	case t.Year() == now.Year():
+		return t.Format("Jan 02 15:04")
+	}
+	return t.Format("2006-01-02")
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Alert Log"))
+	sb.WriteString("\n\n")
+
+	if len(m.rows) == 0 {
+		sb.WriteString(dimStyle.Render("No alerts have fired yet"))
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("%-12s  %-10s %-14s %10s %8s", "Time", "Symbol", "Condition", "Price", "Change")))
+		sb.WriteString("\n")
+		now := time.Now()
+		end := min(len(m.rows), m.offset+m.pageRows())
+		for _, e := range m.rows[m.offset:end] {
+			change := lipgloss.NewStyle().Foreground(styles.Trend(e.Symbol).For(e.ChangePct >= 0)).
+				Render(fmt.Sprintf("%+7.2f%%", e.ChangePct))
+			sb.WriteString(fmt.Sprintf("%-12s  %-10s %-14s %10.2f ", stamp(e.At, now), truncate(e.Symbol, 10),
+				truncate(e.Condition, 14), e.Price))
+			sb.WriteString(change)
+			sb.WriteString("\n")
+		}
+		if len(m.rows) > m.pageRows() {
+			sb.WriteString(dimStyle.Render(fmt.Sprintf("%d–%d of %d, j/k to scroll", m.offset+1, end, len(m.rows))))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	switch {
+	case m.clearing:
+		sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorWarning).Render("Press c again to clear the log"))
+	case len(m.rows) > 0:
+		sb.WriteString(dimStyle.Render("c to clear • Esc to close"))
+	default:
+		sb.WriteString(dimStyle.Render("Esc to close"))
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
+
+func truncate(s string, w int) string {
+	r := []rune(s)
+	if len(r) <= w {
+		return s
+	}
+	return string(r[:w-1]) + "…"
+}
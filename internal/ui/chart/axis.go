@@ -0,0 +1,66 @@
+package chart
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// axisGap is the least number of blank columns between two time labels.
+const axisGap = 4
+
+// axisLayout picks how the time axis writes a series' times: clock times
+// within about a day, dates beyond, and month and year over more than a
+// year.
+func axisLayout(data []models.Candle) string {
+	if len(data) < 2 {
+		return "15:04"
+	}
+	switch span := data[len(data)-1].Timestamp.Sub(data[0].Timestamp); {
+	case span <= 36*time.Hour:
+		return "15:04"
+	case span > 400*24*time.Hour:
+		return "Jan 2006"
+	}
+	return "Jan 02"
+}
+
+// timeAxis renders the row of time labels under a canvas width columns
+// wide, behind the y-axis gutter. Each label starts at the column whose
+// time it gives; at reports the time drawn in a column, or false past the
+// end of the data.
+func timeAxis(width int, layout string, at func(col int) (time.Time, bool)) string {
+	w := len(layout) // the layouts used format to their own width
+	if width < w {
+		return ""
+	}
+	row := []rune(strings.Repeat(" ", width))
+	// Spread the labels evenly, the last one ending at the right edge
+	span := width - w
+	k := span / (w + axisGap)
+	for i := 0; i <= k; i++ {
+		col := 0
+		if k > 0 {
+			col = i * span / k
+		}
+		t, ok := at(col)
+		if !ok {
+			break
+		}
+		copy(row[col:], []rune(t.Format(layout)))
+	}
+	return lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render("         " + string(row))
+}
+
+// columnTime returns the time of the first candle drawn in col.
+func (m Model) columnTime(col, width int) (time.Time, bool) {
+	n := len(m.data)
+	start, end := m.columnRange(col, n, width)
+	if start >= end {
+		return time.Time{}, false
+	}
+	return m.data[start].Timestamp, true
+}
@@ -0,0 +1,62 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// SetBenchmark sets the series drawn behind every chart as the return of
+// a benchmark over the same range. An empty series draws none.
+func (m *Model) SetBenchmark(s Series) {
+	m.benchmark = s
+}
+
+// benchmarkOverlay rebases the benchmark onto the symbol's first close, so
+// the line sits wherever the symbol would be had it moved with the
+// benchmark. Values follow the full history and are NaN where the
+// benchmark has no candle at or shortly before the symbol's.
+func (m Model) benchmarkOverlay() (overlay, bool) {
+	b := m.benchmark.Data
+	if len(b) < 2 || len(m.full) < 2 || m.benchmark.Symbol == m.symbol || market.IsYield(m.symbol) {
+		return overlay{}, false
+	}
+	// A benchmark whose history stops early ends its line there rather
+	// than running flat to the right edge
+	step := b[len(b)-1].Timestamp.Sub(b[len(b)-2].Timestamp)
+	end := b[len(b)-1].Timestamp.Add(step)
+
+	at := func(i int) float64 {
+		t := m.full[i].Timestamp
+		j := sort.Search(len(b), func(j int) bool { return b[j].Timestamp.After(t) }) - 1
+		if j < 0 || t.After(end) || b[j].Close == 0 {
+			return math.NaN()
+		}
+		return b[j].Close
+	}
+	base := at(0)
+	if math.IsNaN(base) {
+		base = b[0].Close
+	}
+	if base == 0 || m.full[0].Close == 0 {
+		return overlay{}, false
+	}
+
+	values := make([]float64, len(m.full))
+	last := math.NaN()
+	for i := range m.full {
+		v := at(i)
+		if !math.IsNaN(v) {
+			last = v
+		}
+		values[i] = m.full[0].Close * v / base
+	}
+	label := m.aliases.Name(m.benchmark.Symbol)
+	if !math.IsNaN(last) {
+		label += fmt.Sprintf(" %+.2f%%", (last/base-1)*100)
+	}
+	return overlay{label: label, color: styles.ColorSubtext, values: values}, true
+}
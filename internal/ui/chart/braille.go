@@ -0,0 +1,88 @@
+package chart
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// brailleBits maps a dot's (x, y) position inside a cell to its bit in the
+// U+2800 Braille block. Each cell holds a 2x4 dot grid.
+var brailleBits = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// drawBraille plots closes onto the canvas at Braille resolution, giving
+// four vertical and two horizontal steps per cell. In area mode the cells
+// beneath the line are shaded the same way as the normal-resolution area
+// chart, so overlays and the crosshair still draw over them. Segments take
+// tc's up or down color.
+func drawBraille(canvas [][]rune, colors [][]lipgloss.Color, closes []float64, scale yScale, area bool, tc styles.TrendColors) {
+	rows, cols := len(canvas), len(canvas[0])
+	dotH, dotW := rows*4, cols*2
+	n := len(closes)
+
+	toDot := func(price float64) int {
+		return scale.row(price, dotH)
+	}
+
+	dots := make([][]rune, rows)
+	for i := range dots {
+		dots[i] = make([]rune, cols)
+	}
+	lowest := make([]int, cols) // lowest line row per column, for shading
+	for i := range lowest {
+		lowest[i] = -1
+	}
+
+	// Interpolate between closes so short series draw slopes rather than
+	// steps once there are more dot columns than points
+	priceAt := func(x int) (float64, int) {
+		if n == 1 || dotW == 1 {
+			return closes[0], 0
+		}
+		pos := float64(x) * float64(n-1) / float64(dotW-1)
+		i := min(int(pos), n-2)
+		frac := pos - float64(i)
+		return closes[i] + (closes[i+1]-closes[i])*frac, i + 1
+	}
+
+	prev := -1
+	for x := 0; x < dotW; x++ {
+		price, idx := priceAt(x)
+		y := toDot(price)
+		lo, hi := y, y
+		if prev >= 0 {
+			lo, hi = min(prev, y), max(prev, y)
+		}
+		col := x / 2
+		isUp := idx == 0 || closes[idx] >= closes[idx-1]
+		for yy := lo; yy <= hi; yy++ {
+			dots[yy/4][col] |= brailleBits[x%2][yy%4]
+			colors[yy/4][col] = tc.For(isUp)
+		}
+		lowest[col] = max(lowest[col], y/4)
+		prev = y
+	}
+
+	for r := range dots {
+		for c, d := range dots[r] {
+			if d != 0 {
+				canvas[r][c] = 0x2800 + d
+			}
+		}
+	}
+
+	if !area {
+		return
+	}
+	for c, low := range lowest {
+		if low < 0 {
+			continue
+		}
+		for r := low + 1; r < rows; r++ {
+			canvas[r][c] = '░'
+			colors[r][c] = colors[low][c]
+		}
+	}
+}
@@ -2,11 +2,14 @@ package chart
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ni5arga/stock-tui/internal/market"
 	"github.com/ni5arga/stock-tui/internal/models"
 	"github.com/ni5arga/stock-tui/internal/ui/styles"
 )
@@ -25,19 +28,70 @@ type Model struct {
 	width      int
 	height     int
 	symbol     string
+	aliases    models.Aliases
+	currency   string
+	ext        models.Quote // latest quote, for its extended-hours fields
+	fx         market.Rates // display-currency conversion for the header
 	timeRange  models.TimeRange
 	chartType  ChartType
-	data       []models.Candle
+	data       []models.Candle // the zoomed window into full
+	full       []models.Candle
 	loading    bool
 	err        error
 	stale      bool
 	retryAfter time.Duration
+	dropped    [2]int // malformed points left out, of the points received
+	note       string // the user's note on the symbol, for the header
+
+	indicators      IndicatorSet
+	indicatorParams models.IndicatorConfig
+	rsiPanel        bool // RSI panel under the price chart
+	macdPanel       bool // MACD panel under the price chart
+
+	showVolume      bool
+	volumeMinHeight int
+
+	gridLines     bool // dim lines across the canvas at the price labels
+	gridRows      int  // rows between price labels, about
+	prevCloseLine bool // dashed line at the previous close, intraday
+
+	highRes  bool // Braille rendering for line and area charts
+	logScale bool // log y-axis for the price chart
+	sessions bool // dim and separate extended-hours candles
+
+	compare []Series // extra symbols in compare mode
+
+	benchmark Series // drawn behind every chart, see SetBenchmark
+
+	portfolio    bool     // portfolio against its benchmark instead of the symbol
+	portfolioSet []Series // the portfolio, then the benchmark
+	portfolioErr error
+	paper        *PaperSummary // paper-trading account under the portfolio
+
+	focused bool // has the keyboard focus
+
+	winLen   int // candles in the zoomed window, 0 for the whole series
+	winEnd   int // candles after the window
+	panning  bool
+	panX     int // where a right-button drag started
+	panStart int // winEnd at the start of the drag
+
+	originX     int
+	originY     int
+	crosshair   bool
+	hovering    bool
+	headerHover bool // pointer over the header, showing the data quality details
+	cursorCol   int
+	dragging    bool
+	dragStart   int
 }
 
 func New() Model {
 	return Model{
-		timeRange: models.Range24H,
-		chartType: ChartLine,
+		timeRange:       models.Range24H,
+		chartType:       ChartLine,
+		indicatorParams: defaultIndicatorParams(),
+		showVolume:      true,
 	}
 }
 
@@ -48,22 +102,83 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		// Decrement retry timer if we were doing real-time updates,
 		// but since we rely on app.go to drive updates, we'll just display what we have.
 	}
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		m.handleMouse(msg)
+	}
 	return m, nil
 }
 
+// SetFocused highlights the pane's border while it has the keyboard focus.
+func (m *Model) SetFocused(on bool) {
+	m.focused = on
+}
+
 func (m *Model) SetSize(w, h int) {
 	m.width = w
 	m.height = h
 }
 
 func (m *Model) SetData(symbol string, tr models.TimeRange, data []models.Candle) {
+	if symbol != m.symbol {
+		m.currency = market.ExchangeFor(symbol).Currency
+		m.ext = models.Quote{}
+	}
+	if symbol != m.symbol || tr != m.timeRange {
+		m.winLen, m.winEnd = 0, 0
+	}
 	m.symbol = symbol
 	m.timeRange = tr
-	m.data = data
+	m.full = data
+	m.applyWindow()
 	m.loading = false
 	m.err = nil
 	m.stale = false
 	m.retryAfter = 0
+	m.dropped = [2]int{}
+}
+
+// SetDropped notes that dropped of total points in the charted history
+// were malformed and left out.
+func (m *Model) SetDropped(dropped, total int) {
+	m.dropped = [2]int{dropped, total}
+}
+
+// Series returns the symbol, range and candles currently charted, the
+// whole series when zoomed.
+func (m Model) Series() (string, models.TimeRange, []models.Candle) {
+	return m.symbol, m.timeRange, m.full
+}
+
+// SetCurrency overrides the currency shown for the current symbol, e.g.
+// with the code reported by the quote provider.
+func (m *Model) SetCurrency(symbol, code string) {
+	if symbol == m.symbol && code != "" {
+		m.currency = code
+	}
+}
+
+// SetExtended records the current symbol's quote so the header can show
+// its pre-market or after-hours price next to the regular session.
+func (m *Model) SetExtended(q models.Quote) {
+	if q.Symbol == m.symbol {
+		m.ext = q
+	}
+}
+
+// SetRates adds the last price in the rates' display currency to the
+// header. The chart itself stays in the symbol's own currency.
+func (m *Model) SetRates(r market.Rates) {
+	m.fx = r
+}
+
+// SetNote sets the note shown under the header when nothing else is.
+func (m *Model) SetNote(text string) {
+	m.note = text
+}
+
+// SetAliases sets the display names used in the chart header.
+func (m *Model) SetAliases(a models.Aliases) {
+	m.aliases = a
 }
 
 func (m *Model) SetStale(retryAfter time.Duration) {
@@ -89,20 +204,118 @@ func (m Model) ChartTypeName() string {
 	return chartTypeNames[m.chartType]
 }
 
+// SetChartTypeName switches to the chart type called name, as
+// ChartTypeName gives it. It reports false for an unknown name.
+func (m *Model) SetChartTypeName(name string) bool {
+	for i, n := range chartTypeNames {
+		if strings.EqualFold(n, name) {
+			m.chartType = ChartType(i)
+			return true
+		}
+	}
+	return false
+}
+
+// SetHighRes switches line and area charts to Braille rendering, which
+// plots four price levels per row. Candles are unaffected.
+func (m *Model) SetHighRes(on bool) {
+	m.highRes = on
+}
+
+// SetLogScale switches the price axis between linear and log scaling.
+// Compare mode always plots % change linearly.
+func (m *Model) SetLogScale(on bool) {
+	m.logScale = on
+}
+
+// ToggleLogScale flips the price axis between linear and log scaling.
+func (m *Model) ToggleLogScale() {
+	m.logScale = !m.logScale
+}
+
+func (m Model) LogScale() bool {
+	return m.logScale
+}
+
+// SetIndicatorParams overrides the indicator periods, falling back to the
+// defaults for any non-positive value.
+func (m *Model) SetIndicatorParams(p models.IndicatorConfig) {
+	d := defaultIndicatorParams()
+	if p.SMAFast <= 0 {
+		p.SMAFast = d.SMAFast
+	}
+	if p.SMASlow <= 0 {
+		p.SMASlow = d.SMASlow
+	}
+	if p.EMA <= 0 {
+		p.EMA = d.EMA
+	}
+	if p.BollingerPeriod <= 0 {
+		p.BollingerPeriod = d.BollingerPeriod
+	}
+	if p.BollingerStdDev <= 0 {
+		p.BollingerStdDev = d.BollingerStdDev
+	}
+	if p.RSIPeriod <= 0 {
+		p.RSIPeriod = d.RSIPeriod
+	}
+	if p.RSIOverbought <= 0 || p.RSIOverbought >= 100 {
+		p.RSIOverbought = d.RSIOverbought
+	}
+	if p.RSIOversold <= 0 || p.RSIOversold >= p.RSIOverbought {
+		p.RSIOversold = min(d.RSIOversold, p.RSIOverbought/2)
+	}
+	if p.MACDFast <= 0 {
+		p.MACDFast = d.MACDFast
+	}
+	if p.MACDSlow <= 0 {
+		p.MACDSlow = d.MACDSlow
+	}
+	if p.MACDSignal <= 0 {
+		p.MACDSignal = d.MACDSignal
+	}
+	m.indicatorParams = p
+	m.rsiPanel = p.RSIPanel
+	m.macdPanel = p.MACDPanel
+}
+
+// CycleIndicators steps to the next indicator set, passing over VWAP
+// outside the intraday ranges.
+func (m *Model) CycleIndicators() {
+	m.indicators = (m.indicators + 1) % IndicatorSet(len(indicatorSetNames))
+	if m.indicators == IndicatorsVWAP && !m.intraday() {
+		m.indicators++
+	}
+}
+
+func (m Model) Indicators() IndicatorSet {
+	return m.indicators
+}
+
 func (m Model) View() string {
 	var content string
 	switch {
+	case m.portfolio:
+		content = m.renderPortfolio()
 	case m.loading:
 		content = lipgloss.Place(m.width-4, m.height-4, lipgloss.Center, lipgloss.Center, "Loading...")
 	case m.err != nil:
 		content = lipgloss.Place(m.width-4, m.height-4, lipgloss.Center, lipgloss.Center, m.err.Error())
 	case len(m.data) == 0:
 		content = lipgloss.Place(m.width-4, m.height-4, lipgloss.Center, lipgloss.Center, "No data")
+	case m.Comparing():
+		content = m.renderCompare()
 	default:
 		content = m.render()
 	}
 
-	return styles.ActivePane.Width(m.width).Height(m.height).Render(content)
+	return styles.PaneFor(m.focused).Width(m.width).Height(m.height).Render(content)
+}
+
+// noteLine is a note on one line of width w, its line breaks folded.
+func noteLine(note string, w int) string {
+	text := "✎ " + strings.Join(strings.Fields(note), " ")
+	return lipgloss.NewStyle().Foreground(styles.ColorSubtext).Italic(true).Render(ansi.Truncate(text, w, "…"))
 }
 
 func (m Model) render() string {
@@ -111,6 +324,15 @@ func (m Model) render() string {
 	if chartW < 10 || chartH < 4 {
 		return "Too small"
 	}
+	volRows := m.volumeRows(chartH)
+	if chartH-volRows < 4 {
+		volRows = 0
+	}
+	panels, panelRows := m.oscillatorPanels(chartH)
+	if chartH-volRows-panels*panelRows < 4 {
+		panels = 0
+	}
+	chartH -= volRows + panels*panelRows
 
 	// Get price data
 	n := len(m.data)
@@ -119,6 +341,16 @@ func (m Model) render() string {
 		closes[i] = c.Close
 	}
 
+	// Indicators need the history before the window
+	all := m.fullCloses()
+	overlays := m.overlays(all)
+	if o, ok := m.benchmarkOverlay(); ok {
+		overlays = append(overlays, o)
+	}
+	for i := range overlays {
+		overlays[i].values = m.visible(overlays[i].values)
+	}
+
 	// Find min/max
 	minP, maxP := closes[0], closes[0]
 	for _, p := range closes {
@@ -129,70 +361,137 @@ func (m Model) render() string {
 			maxP = p
 		}
 	}
-	spread := maxP - minP
-	if spread == 0 {
-		spread = maxP * 0.01
+	for _, o := range overlays {
+		for _, v := range o.values {
+			if math.IsNaN(v) {
+				continue
+			}
+			minP = math.Min(minP, v)
+			maxP = math.Max(maxP, v)
+		}
+	}
+	prevClose, hasPrev := m.prevClose()
+	if hasPrev {
+		minP = math.Min(minP, prevClose)
+		maxP = math.Max(maxP, prevClose)
+	}
+	// The high and low markers need a row beyond the series on each side
+	ext, marked := m.findExtremes(chartW)
+	marked = marked && chartH >= 5
+	if marked {
+		minP = math.Min(minP, ext.low)
+		maxP = math.Max(maxP, ext.high)
+		pad := (maxP - minP) / float64(chartH-3)
+		maxP += pad
+		if minP > pad || !m.logScale {
+			minP -= pad
+		}
 	}
-	minP -= spread * 0.05
-	maxP += spread * 0.05
-	spread = maxP - minP
+	scale := newYScale(minP, maxP, m.logScale)
 
-	// Header
+	// Header: intraday the change is the day's, from the previous close,
+	// otherwise it is over the range
 	lastP := closes[n-1]
-	change := lastP - closes[0]
-	pct := change / closes[0] * 100
+	from := closes[0]
+	if pc, ok := m.dayBase(); ok {
+		from = pc
+	}
+	change := lastP - from
+	pct := change / from * 100
 
 	up := change >= 0
-	trendColor := styles.ColorSuccess
-	if !up {
-		trendColor = styles.ColorError
-	}
+	tc := styles.Trend(m.symbol)
+	trendColor := tc.For(up)
 
 	var b strings.Builder
-	b.WriteString(lipgloss.NewStyle().Bold(true).Render(m.symbol))
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(m.aliases.Name(m.symbol)))
+	if m.aliases.Name(m.symbol) != m.symbol {
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render(m.symbol))
+	}
 	b.WriteString("  ")
-	b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render(string(m.timeRange)))
+	b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render(m.timeRange.Label()))
+	if m.Zoomed() {
+		first, last := m.windowSpan()
+		layout := m.timeLayout()
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorPrimary).Render(
+			" [" + first.Timestamp.Format(layout) + " – " + last.Timestamp.Format(layout) + "]"))
+	}
 	b.WriteString("  ")
-	b.WriteString(lipgloss.NewStyle().Foreground(trendColor).Bold(true).Render(
-		fmt.Sprintf("$%.2f (%+.2f%%)", lastP, pct)))
+	headline := fmt.Sprintf("%s (%+.2f%%)", market.FormatPrice(lastP, m.currency, "%.2f"), pct)
+	if market.IsYield(m.symbol) {
+		headline = fmt.Sprintf("%s (%s)", market.FormatYield(lastP), market.FormatBP(change*100))
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(trendColor).Bold(true).Render(headline))
+	if v, ok := m.fx.Convert(lastP, m.currency); ok && !market.IsYield(m.symbol) {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render(" ≈ " + market.FormatPrice(v, m.fx.To, "%.2f")))
+	}
+	if m.ext.ExtSession != "" && m.ext.ExtPrice > 0 && !market.IsYield(m.symbol) {
+		b.WriteString("  ")
+		b.WriteString(styles.ExtendedBadge.Render(string(m.ext.ExtSession)))
+		b.WriteString(" ")
+		b.WriteString(styles.ExtendedHours.Render(market.FormatPrice(m.ext.ExtPrice, m.currency, "%.2f")))
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().Foreground(tc.For(m.ext.ExtChangePct >= 0)).Italic(true).
+			Render(fmt.Sprintf("(%+.2f%%)", m.ext.ExtChangePct)))
+	}
 	b.WriteString("  ")
 	b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render("[" + m.ChartTypeName() + "]"))
+	if scale.log {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render(" [Log]"))
+	}
+	for _, o := range overlays {
+		if o.label == "" {
+			continue
+		}
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().Foreground(o.color).Render(o.label))
+	}
 
 	if m.stale {
 		warnStyle := lipgloss.NewStyle().Foreground(styles.ColorWarning).Bold(true)
 		b.WriteString("  ")
 		b.WriteString(warnStyle.Render(fmt.Sprintf("⚠ RATE LIMITED (Refreshing in %s)", m.retryAfter.Round(time.Second))))
 	}
-	b.WriteString("\n\n")
+	quality := m.assess(m.symbol, m.full, m.dropped, time.Now())
+	b.WriteString("  ")
+	b.WriteString(quality.badge())
+	b.WriteString("\n")
+	switch {
+	case m.measuring():
+		b.WriteString(m.measureLine(chartW))
+	case m.headerHover:
+		b.WriteString(m.qualityLine(quality, chartW))
+	case m.inspecting():
+		b.WriteString(m.tooltip(chartW, quality))
+	case m.note != "":
+		b.WriteString(noteLine(m.note, chartW))
+	}
+	b.WriteString("\n")
 
 	// Build canvas (plain runes, style later per-row)
 	canvas := make([][]rune, chartH)
-	colors := make([][]bool, chartH) // true = green, false = red
+	colors := make([][]lipgloss.Color, chartH)
 	for i := range canvas {
 		canvas[i] = make([]rune, chartW)
-		colors[i] = make([]bool, chartW)
+		colors[i] = make([]lipgloss.Color, chartW)
 		for j := range canvas[i] {
 			canvas[i][j] = ' '
-			colors[i][j] = true
+			colors[i][j] = tc.Up
 		}
 	}
-
 	toRow := func(price float64) int {
-		r := int((maxP - price) / spread * float64(chartH-1))
-		if r < 0 {
-			r = 0
-		}
-		if r >= chartH {
-			r = chartH - 1
-		}
-		return r
+		return scale.row(price, chartH)
 	}
 
 	// Sample prices to chart width
 	step := float64(n) / float64(chartW)
 
-	switch m.chartType {
-	case ChartLine:
+	switch {
+	case m.highRes && m.chartType != ChartCandle:
+		drawBraille(canvas, colors, closes, scale, m.chartType == ChartArea, tc)
+
+	case m.chartType == ChartLine:
 		prevRow := -1
 		for col := 0; col < chartW; col++ {
 			idx := int(float64(col) * step)
@@ -206,15 +505,15 @@ func (m Model) render() string {
 				lo, hi := min(prevRow, row), max(prevRow, row)
 				for r := lo; r <= hi; r++ {
 					canvas[r][col] = '│'
-					colors[r][col] = isUp
+					colors[r][col] = tc.For(isUp)
 				}
 			}
 			canvas[row][col] = '━'
-			colors[row][col] = isUp
+			colors[row][col] = tc.For(isUp)
 			prevRow = row
 		}
 
-	case ChartArea:
+	case m.chartType == ChartArea:
 		for col := 0; col < chartW; col++ {
 			idx := int(float64(col) * step)
 			if idx >= n {
@@ -229,11 +528,11 @@ func (m Model) render() string {
 				} else {
 					canvas[r][col] = '░'
 				}
-				colors[r][col] = isUp
+				colors[r][col] = tc.For(isUp)
 			}
 		}
 
-	case ChartCandle:
+	case m.chartType == ChartCandle:
 		// Aggregate candles to fit width
 		candlesPerCol := max(1, n/chartW)
 		for col := 0; col < chartW; col++ {
@@ -268,7 +567,7 @@ func (m Model) render() string {
 			// Wick
 			for r := rowHigh; r <= rowLow; r++ {
 				canvas[r][col] = '│'
-				colors[r][col] = isUp
+				colors[r][col] = tc.For(isUp)
 			}
 			// Body
 			for r := bodyTop; r <= bodyBot; r++ {
@@ -277,52 +576,130 @@ func (m Model) render() string {
 				} else {
 					canvas[r][col] = '▓'
 				}
-				colors[r][col] = isUp
+				colors[r][col] = tc.For(isUp)
 			}
 		}
 	}
 
+	markSessions(canvas, colors, m.extendedColumns(chartW))
+	levels := m.gridLevels(scale, chartH)
+	prevRow := -1
+	if hasPrev {
+		prevRow = toRow(prevClose)
+	}
+	m.drawGrid(canvas, colors, levels, prevRow)
+
+	// Indicator overlays only fill empty or area-shaded cells so the price
+	// series stays readable underneath.
+	for _, o := range overlays {
+		for col := 0; col < chartW; col++ {
+			idx := m.columnIndex(col, n, chartW)
+			if idx < 0 {
+				break
+			}
+			v := o.values[idx]
+			if math.IsNaN(v) {
+				continue
+			}
+			row := toRow(v)
+			if behind(canvas[row][col]) {
+				canvas[row][col] = '·'
+				colors[row][col] = o.color
+			}
+		}
+	}
+
+	if marked {
+		m.markExtremes(canvas, colors, ext, toRow)
+	}
+
+	if m.inspecting() && m.cursorCol < chartW {
+		for row := 0; row < chartH; row++ {
+			if behind(canvas[row][m.cursorCol]) {
+				canvas[row][m.cursorCol] = '┊'
+				colors[row][m.cursorCol] = styles.ColorSubtext
+			}
+		}
+	}
+	if m.measuring() && m.dragStart < chartW {
+		m.shadeMeasure(canvas, colors, chartW)
+	}
+
 	// Render canvas with colors
-	greenS := lipgloss.NewStyle().Foreground(styles.ColorSuccess)
-	redS := lipgloss.NewStyle().Foreground(styles.ColorError)
 	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	cellStyles := make(map[lipgloss.Color]lipgloss.Style)
 
 	for row := 0; row < chartH; row++ {
-		// Y-axis label
-		var label string
-		switch row {
-		case 0:
-			label = fmt.Sprintf("%8.2f ", maxP)
-		case chartH - 1:
-			label = fmt.Sprintf("%8.2f ", minP)
-		case chartH / 2:
-			label = fmt.Sprintf("%8.2f ", (maxP+minP)/2)
-		default:
-			label = "         "
+		// Y-axis label, the previous close where no level is labelled
+		label := "         "
+		if v, ok := levels[row]; ok {
+			label = m.axisLabel(v)
+		} else if row == prevRow {
+			label = m.axisLabel(prevClose)
 		}
 		b.WriteString(dimS.Render(label))
 
 		// Chart row - batch same-color runs for cleaner output
 		var rowStr strings.Builder
 		for col := 0; col < chartW; col++ {
-			ch := canvas[row][col]
-			if colors[row][col] {
-				rowStr.WriteString(greenS.Render(string(ch)))
-			} else {
-				rowStr.WriteString(redS.Render(string(ch)))
+			c := colors[row][col]
+			st, ok := cellStyles[c]
+			if !ok {
+				st = lipgloss.NewStyle().Foreground(c)
+				cellStyles[c] = st
 			}
+			rowStr.WriteString(st.Render(string(canvas[row][col])))
 		}
 		b.WriteString(rowStr.String())
 		b.WriteString("\n")
 	}
 
-	// Sparkline
+	if volRows > 0 {
+		b.WriteString(m.renderVolume(volRows, chartW))
+	}
+	if panels > 0 && m.rsiPanel {
+		b.WriteString(m.renderRSI(panelRows, chartW, all))
+	}
+	if panels > 0 && m.macdPanel {
+		b.WriteString(m.renderMACD(panelRows, chartW, all))
+	}
+
+	b.WriteString(timeAxis(chartW, axisLayout(m.data), func(col int) (time.Time, bool) {
+		return m.columnTime(col, chartW)
+	}))
 	b.WriteString("\n")
+
+	// Sparkline
 	b.WriteString(m.sparkline(closes, chartW))
 
 	return b.String()
 }
 
+// axisLabel formats a y-axis value into the 9-column label gutter.
+func (m Model) axisLabel(v float64) string {
+	if market.IsYield(m.symbol) {
+		return fmt.Sprintf("%7.3f%% ", v)
+	}
+	return fmt.Sprintf("%8.2f ", v)
+}
+
+// columnIndex maps a canvas column to the data index drawn there, matching
+// the sampling used by each chart type. It returns -1 for candle columns
+// past the end of the data.
+func (m Model) columnIndex(col, n, width int) int {
+	var idx int
+	if m.chartType == ChartCandle {
+		perCol := max(1, n/width)
+		if col*perCol >= n {
+			return -1
+		}
+		idx = col*perCol + perCol - 1
+	} else {
+		idx = int(float64(col) * float64(n) / float64(width))
+	}
+	return min(idx, n-1)
+}
+
 func (m Model) sparkline(prices []float64, width int) string {
 	blocks := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 	n := len(prices)
@@ -345,8 +722,9 @@ func (m Model) sparkline(prices []float64, width int) string {
 	}
 
 	step := float64(n) / float64(width)
-	greenS := lipgloss.NewStyle().Foreground(styles.ColorSuccess)
-	redS := lipgloss.NewStyle().Foreground(styles.ColorError)
+	tc := styles.Trend(m.symbol)
+	greenS := lipgloss.NewStyle().Foreground(tc.Up)
+	redS := lipgloss.NewStyle().Foreground(tc.Down)
 
 	var out strings.Builder
 	out.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render("   Trend "))
@@ -0,0 +1,202 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Series is an extra symbol drawn alongside the main one in compare mode.
+type Series struct {
+	Symbol string
+	Data   []models.Candle
+}
+
+// SetCompare sets the symbols overlaid on the chart. Each series, including
+// the main one, is normalised to % change from the start of the range.
+// An empty slice returns to the normal single-symbol chart.
+func (m *Model) SetCompare(series []Series) {
+	m.compare = series
+}
+
+// Comparing reports whether compare mode is active.
+func (m Model) Comparing() bool {
+	return len(m.compare) > 0
+}
+
+// seriesColor gives the main series the text color and the compared
+// series the overlay palette.
+func seriesColor(i int) lipgloss.Color {
+	if i == 0 {
+		return styles.ColorText
+	}
+	return styles.ColorOverlay[(i-1)%len(styles.ColorOverlay)]
+}
+
+func (m Model) renderCompare() string {
+	// Compared series are not zoomed, so neither is the symbol
+	all := []Series{{Symbol: m.symbol, Data: m.full}}
+	for _, s := range m.compare {
+		if s.Symbol != m.symbol {
+			all = append(all, s)
+		}
+	}
+	return m.renderNormalized("Compare", all)
+}
+
+// renderNormalized draws each series as % change from its first close,
+// the first one on top.
+func (m Model) renderNormalized(title string, all []Series) string {
+	chartH := m.height - 8
+	chartW := m.width - 14
+	if chartW < 10 || chartH < 4 {
+		return "Too small"
+	}
+
+	var series []Series
+	var pcts [][]float64
+	for _, s := range all {
+		if len(s.Data) == 0 || s.Data[0].Close == 0 {
+			continue
+		}
+		base := s.Data[0].Close
+		p := make([]float64, len(s.Data))
+		for j, c := range s.Data {
+			p[j] = (c.Close/base - 1) * 100
+		}
+		series = append(series, s)
+		pcts = append(pcts, p)
+	}
+
+	minP, maxP := 0.0, 0.0
+	for _, p := range pcts {
+		for _, v := range p {
+			minP = math.Min(minP, v)
+			maxP = math.Max(maxP, v)
+		}
+	}
+	spread := maxP - minP
+	if spread == 0 {
+		spread = 1
+	}
+	minP -= spread * 0.05
+	maxP += spread * 0.05
+	spread = maxP - minP
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(title))
+	b.WriteString("  ")
+	b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render(m.timeRange.Label()))
+	b.WriteString("  ")
+	b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render("[% change]"))
+	b.WriteString("\n")
+
+	// Legend
+	now := time.Now()
+	for i, s := range series {
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		last := pcts[i][len(pcts[i])-1]
+		b.WriteString(lipgloss.NewStyle().Foreground(seriesColor(i)).Render(
+			fmt.Sprintf("━ %s %+.2f%%", m.aliases.Name(s.Symbol), last)))
+		// Only series worth a second look carry their quality badge
+		var dropped [2]int
+		if s.Symbol == m.symbol {
+			dropped = m.dropped
+		}
+		if s.Symbol == PortfolioSymbol {
+			continue
+		}
+		if q := m.assess(s.Symbol, s.Data, dropped, now); q.Score < 95 {
+			b.WriteString(" " + q.badge())
+		}
+	}
+	b.WriteString("\n")
+
+	canvas := make([][]rune, chartH)
+	colors := make([][]lipgloss.Color, chartH)
+	for i := range canvas {
+		canvas[i] = make([]rune, chartW)
+		colors[i] = make([]lipgloss.Color, chartW)
+		for j := range canvas[i] {
+			canvas[i][j] = ' '
+		}
+	}
+
+	toRow := func(v float64) int {
+		r := int((maxP - v) / spread * float64(chartH-1))
+		return max(0, min(r, chartH-1))
+	}
+
+	// Zero line marks the start-of-range level
+	zero := toRow(0)
+	for col := 0; col < chartW; col++ {
+		canvas[zero][col] = '┄'
+		colors[zero][col] = styles.ColorSecondary
+	}
+
+	// Draw compared series first so the main symbol ends up on top
+	for i := len(series) - 1; i >= 0; i-- {
+		p := pcts[i]
+		n := len(p)
+		prevRow := -1
+		for col := 0; col < chartW; col++ {
+			idx := min(col*n/chartW, n-1)
+			row := toRow(p[idx])
+			if prevRow >= 0 && prevRow != row {
+				lo, hi := min(prevRow, row), max(prevRow, row)
+				for r := lo; r <= hi; r++ {
+					canvas[r][col] = '│'
+					colors[r][col] = seriesColor(i)
+				}
+			}
+			canvas[row][col] = '━'
+			colors[row][col] = seriesColor(i)
+			prevRow = row
+		}
+	}
+
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	cellStyles := make(map[lipgloss.Color]lipgloss.Style)
+	for row := 0; row < chartH; row++ {
+		var label string
+		switch row {
+		case 0:
+			label = fmt.Sprintf("%+7.2f%% ", maxP)
+		case chartH - 1:
+			label = fmt.Sprintf("%+7.2f%% ", minP)
+		case zero:
+			label = fmt.Sprintf("%+7.2f%% ", 0.0)
+		default:
+			label = "         "
+		}
+		b.WriteString(dimS.Render(label))
+
+		for col := 0; col < chartW; col++ {
+			c := colors[row][col]
+			st, ok := cellStyles[c]
+			if !ok {
+				st = lipgloss.NewStyle().Foreground(c)
+				cellStyles[c] = st
+			}
+			b.WriteString(st.Render(string(canvas[row][col])))
+		}
+		b.WriteString("\n")
+	}
+
+	// The series share the range, so the first one's times stand for all
+	if len(series) > 0 {
+		first := series[0].Data
+		b.WriteString(timeAxis(chartW, axisLayout(first), func(col int) (time.Time, bool) {
+			return first[min(col*len(first)/chartW, len(first)-1)].Timestamp, true
+		}))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,239 @@
+package chart
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Canvas placement inside the pane: border and padding on the left plus the
+// y-axis label column, and border, header and spacer rows on top.
+const (
+	canvasOffsetX = 2 + 9
+	canvasOffsetY = 3
+)
+
+// SetOrigin records the pane's screen position so mouse events can be
+// mapped back to canvas columns.
+func (m *Model) SetOrigin(x, y int) {
+	m.originX = x
+	m.originY = y
+}
+
+// ToggleCrosshair enables keyboard inspect mode, starting at the latest
+// candle.
+func (m *Model) ToggleCrosshair() {
+	m.crosshair = !m.crosshair
+	if m.crosshair && !m.hovering {
+		m.cursorCol = m.lastColumn()
+	}
+}
+
+func (m Model) Crosshair() bool {
+	return m.crosshair
+}
+
+// MoveCrosshair shifts the inspected column by delta, clamped to the data.
+func (m *Model) MoveCrosshair(delta int) {
+	m.cursorCol = max(0, min(m.cursorCol+delta, m.lastColumn()))
+}
+
+func (m Model) inspecting() bool {
+	return (m.crosshair || m.hovering) && len(m.data) > 0
+}
+
+// lastColumn is the rightmost canvas column that maps to data.
+func (m Model) lastColumn() int {
+	w := m.width - 14
+	n := len(m.data)
+	if w <= 0 || n == 0 {
+		return 0
+	}
+	if m.chartType == ChartCandle {
+		perCol := max(1, n/w)
+		return min(w, (n+perCol-1)/perCol) - 1
+	}
+	return w - 1
+}
+
+// handleMouse tracks hover over the canvas and left-button drags, which
+// measure the move between the press column and the cursor. The wheel
+// zooms anywhere over the pane, and sideways or shifted scrolls and
+// right-button drags pan.
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	col := msg.X - m.originX - canvasOffsetX
+	row := msg.Y - m.originY - canvasOffsetY
+	chartH := m.height - 8
+	inside := col >= 0 && col <= m.lastColumn() && row >= 0 && row < chartH
+	// The header sits two rows above the canvas
+	m.headerHover = row == -2 && msg.X >= m.originX && msg.X < m.originX+m.width && !m.dragging
+
+	if m.panning {
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			m.dragPan(msg.X-m.panX, m.width-14)
+		case tea.MouseActionRelease:
+			m.panning = false
+		}
+		return
+	}
+	// Shift turns the wheel sideways for mice without a horizontal wheel
+	overPane := msg.X >= m.originX && msg.X < m.originX+m.width && msg.Y >= m.originY && msg.Y < m.originY+m.height
+	if msg.Action == tea.MouseActionPress && overPane && m.canZoom() {
+		switch {
+		case msg.Button == tea.MouseButtonWheelUp && msg.Shift, msg.Button == tea.MouseButtonWheelLeft:
+			m.Pan(-1)
+			return
+		case msg.Button == tea.MouseButtonWheelDown && msg.Shift, msg.Button == tea.MouseButtonWheelRight:
+			m.Pan(1)
+			return
+		case msg.Button == tea.MouseButtonWheelUp:
+			m.ZoomIn()
+			return
+		case msg.Button == tea.MouseButtonWheelDown:
+			m.ZoomOut()
+			return
+		}
+	}
+	if msg.Action == tea.MouseActionPress && inside && m.canZoom() {
+		switch msg.Button {
+		case tea.MouseButtonRight:
+			m.panning, m.panX, m.panStart = true, msg.X, m.winEnd
+			return
+		}
+	}
+
+	switch msg.Action {
+	case tea.MouseActionPress:
+		if msg.Button != tea.MouseButtonLeft || !inside || len(m.data) == 0 {
+			return
+		}
+		m.dragging = true
+		m.dragStart = col
+		m.hovering = true
+		m.cursorCol = col
+	case tea.MouseActionRelease:
+		m.dragging = false
+	case tea.MouseActionMotion:
+		if m.dragging {
+			// Keep measuring when the pointer leaves the canvas mid-drag
+			m.cursorCol = max(0, min(col, m.lastColumn()))
+			return
+		}
+		if !inside {
+			m.hovering = false
+			return
+		}
+		m.hovering = true
+		m.cursorCol = col
+	}
+}
+
+// inspectedCandle returns the candle (aggregated for candle charts) under
+// the cursor and the close of the preceding candle.
+func (m Model) inspectedCandle(width int) (models.Candle, float64) {
+	n := len(m.data)
+	start, end := m.columnRange(m.cursorCol, n, width)
+	if m.chartType != ChartCandle {
+		end = start + 1
+	}
+	c := m.data[start]
+	for i := start + 1; i < end; i++ {
+		c.High = max(c.High, m.data[i].High)
+		if m.data[i].Low > 0 {
+			c.Low = min(c.Low, m.data[i].Low)
+		}
+		c.Close = m.data[i].Close
+		c.Volume += m.data[i].Volume
+	}
+	prev := c.Open
+	if start > 0 {
+		prev = m.data[start-1].Close
+	}
+	return c, prev
+}
+
+// tooltip renders the inspect line shown above the canvas, positioned near
+// the cursor column where it fits.
+func (m Model) tooltip(width int, q Quality) string {
+	c, prev := m.inspectedCandle(width)
+
+	layout := m.timeLayout()
+
+	var pct float64
+	if prev > 0 {
+		pct = (c.Close - prev) / prev * 100
+	}
+	pctStyle := styles.PositiveChange
+	if pct < 0 {
+		pctStyle = styles.NegativeChange
+	}
+
+	text := fmt.Sprintf("%s  O %.2f  H %.2f  L %.2f  C %.2f  ",
+		c.Timestamp.Format(layout), c.Open, c.High, c.Low, c.Close)
+	pctText := fmt.Sprintf("%+.2f%%", pct)
+	if market.IsYield(m.symbol) {
+		pctText = market.FormatBP((c.Close - prev) * 100)
+	}
+
+	tipStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorText).
+		Background(styles.ColorHighlight)
+
+	tip := tipStyle.Render(" "+text) + pctStyle.Background(styles.ColorHighlight).Render(pctText+" ")
+	if s := m.candleSession(c); s != "" {
+		tip += styles.ExtendedBadge.Background(styles.ColorHighlight).Render(string(s) + " ")
+	}
+	if start, end := m.columnRange(m.cursorCol, len(m.data), width); end > start {
+		// Candle charts aggregate a column, so take any gap inside it. The
+		// gaps are found over the whole series, not the zoomed window
+		lo, _ := m.window()
+		var gap time.Duration
+		for i := start; i < end; i++ {
+			gap += q.Gaps[lo+i]
+		}
+		if gap > 0 {
+			tip += lipgloss.NewStyle().Foreground(styles.ColorWarning).Background(styles.ColorHighlight).
+				Render("after " + formatElapsed(gap) + " gap ")
+		}
+	}
+
+	// Align the tooltip under the cursor, shifting left if it would overflow
+	indent := 9 + m.cursorCol
+	if maxIndent := width + 9 - lipgloss.Width(tip); indent > maxIndent {
+		indent = max(0, maxIndent)
+	}
+	return fmt.Sprintf("%*s", indent, "") + tip
+}
+
+// timeLayout picks how candle times are written for the charted series:
+// clock times for the intraday ranges, dates alone for daily candles and
+// longer, and the year as well once the series spans more than one.
+func (m Model) timeLayout() string {
+	if base := m.timeRange.Base(); base == models.Range1H || base == models.Range24H {
+		return "15:04:05"
+	}
+	n := len(m.data)
+	if n < 2 {
+		return "Jan 02 15:04"
+	}
+	first, last := m.data[0].Timestamp, m.data[n-1].Timestamp
+	// Weekends and holidays stretch the average gap of daily candles, so
+	// anything near a day apart counts
+	daily := last.Sub(first)/time.Duration(n-1) >= 20*time.Hour
+	years := first.Year() != last.Year()
+	switch {
+	case daily && years:
+		return "Jan 02 2006"
+	case daily:
+		return "Jan 02"
+	case years:
+		return "Jan 02 2006 15:04"
+	}
+	return "Jan 02 15:04"
+}
@@ -0,0 +1,99 @@
+package chart
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// extremes is where the period high and low sit in the window.
+type extremes struct {
+	hi, lo    int // data indexes
+	high, low float64
+}
+
+// findExtremes returns the highest and lowest prices drawn across width
+// columns: the wicks for candles, otherwise the closes drawn by the line.
+func (m Model) findExtremes(width int) (extremes, bool) {
+	n := len(m.data)
+	if n < 2 {
+		return extremes{}, false
+	}
+	_, end := m.columnRange(width-1, n, width)
+	high := func(i int) float64 { return m.data[i].Close }
+	low := high
+	if m.chartType == ChartCandle {
+		high = func(i int) float64 { return m.data[i].High }
+		low = func(i int) float64 {
+			if m.data[i].Low > 0 {
+				return m.data[i].Low
+			}
+			return m.data[i].Close
+		}
+	}
+	var e extremes
+	for i := 0; i < min(end, n); i++ {
+		if high(i) > high(e.hi) {
+			e.hi = i
+		}
+		if low(i) < low(e.lo) {
+			e.lo = i
+		}
+	}
+	e.high, e.low = high(e.hi), low(e.lo)
+	return e, e.high > e.low
+}
+
+// markExtremes marks the period high with ▲ above it and the low with ▼
+// below, each with its price beside the glyph, to the right where it fits.
+func (m Model) markExtremes(canvas [][]rune, colors [][]lipgloss.Color, e extremes, toRow func(float64) int) {
+	rows := len(canvas)
+	width := len(canvas[0])
+	tc := styles.Trend(m.symbol)
+	mark := func(idx int, price float64, glyph rune, row int, c lipgloss.Color) {
+		col := m.indexColumn(idx, width)
+		if col < 0 {
+			return
+		}
+		row = max(0, min(row, rows-1))
+		text := []rune(m.markLabel(price))
+		at := col + 1
+		if at+len(text) > width {
+			at = col - len(text)
+		}
+		canvas[row][col] = glyph
+		colors[row][col] = c
+		if at < 0 {
+			return
+		}
+		for i, r := range text {
+			canvas[row][at+i] = r
+			colors[row][at+i] = styles.ColorText
+		}
+	}
+	mark(e.hi, e.high, '▲', toRow(e.high)-1, tc.Up)
+	mark(e.lo, e.low, '▼', toRow(e.low)+1, tc.Down)
+}
+
+// indexColumn returns the canvas column drawn from data index idx, or -1.
+func (m Model) indexColumn(idx, width int) int {
+	n := len(m.data)
+	for col := 0; col < width; col++ {
+		start, end := m.columnRange(col, n, width)
+		if start >= n {
+			break
+		}
+		if idx >= start && idx < end {
+			return col
+		}
+	}
+	return -1
+}
+
+// markLabel formats a marked price, spaced off the glyph on either side.
+func (m Model) markLabel(v float64) string {
+	if market.IsYield(m.symbol) {
+		return " " + market.FormatYield(v) + " "
+	}
+	return " " + market.FormatPrice(v, m.currency, "%.2f") + " "
+}
@@ -0,0 +1,127 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Runes of the grid lines and the previous close line. Overlays and the
+// crosshair draw over both.
+const (
+	gridRune      = '┈'
+	prevCloseRune = '╌'
+)
+
+// SetGrid labels the price axis about every rows rows, at round prices,
+// and with lines on draws a dim line across the canvas at each label.
+func (m *Model) SetGrid(lines bool, rows int) {
+	m.gridLines = lines
+	m.gridRows = rows
+}
+
+// SetPrevCloseLine turns on a dashed line at the previous session's close
+// on the intraday ranges.
+func (m *Model) SetPrevCloseLine(on bool) {
+	m.prevCloseLine = on
+}
+
+// prevClose returns the previous session's close when its line is shown.
+func (m Model) prevClose() (float64, bool) {
+	if !m.prevCloseLine {
+		return 0, false
+	}
+	return m.dayBase()
+}
+
+// dayBase returns the previous session's close of the charted symbol on
+// the intraday ranges, where the day's change is measured from it. Sources
+// that do not report it have it worked back from the daily change.
+func (m Model) dayBase() (float64, bool) {
+	if base := m.timeRange.Base(); base != models.Range1H && base != models.Range24H {
+		return 0, false
+	}
+	q := m.ext
+	switch {
+	case q.Symbol != m.symbol:
+		return 0, false
+	case q.PreviousClose > 0:
+		return q.PreviousClose, true
+	case q.Price <= 0 || q.ChangePct <= -100:
+		return 0, false
+	}
+	return q.Price / (1 + q.ChangePct/100), true
+}
+
+// gridLevels returns the labelled prices of a canvas rows high by the row
+// each sits on: multiples of a round step, at least two rows apart.
+func (m Model) gridLevels(scale yScale, rows int) map[int]float64 {
+	every := m.gridRows
+	if every <= 0 {
+		every = 5
+	}
+	lo, hi := scale.at(1), scale.at(0)
+	step := niceStep((hi - lo) / float64(max(2, rows/every)))
+	levels := make(map[int]float64)
+	last := -2
+	// Bottom up, so the lowest label wins a crowded log scale
+	for k := math.Ceil(lo / step); k*step <= hi; k++ {
+		v := k * step
+		row := scale.row(v, rows)
+		if abs(row-last) < 2 {
+			continue
+		}
+		levels[row] = v
+		last = row
+	}
+	return levels
+}
+
+// niceStep rounds raw up to 1, 2, 2.5 or 5 times a power of ten.
+func niceStep(raw float64) float64 {
+	if raw <= 0 || math.IsNaN(raw) || math.IsInf(raw, 0) {
+		return 1
+	}
+	pow := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, f := range []float64{1, 2, 2.5, 5} {
+		if raw <= f*pow {
+			return f * pow
+		}
+	}
+	return 10 * pow
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// drawGrid fills the empty cells of the grid rows and the previous close
+// row, behind the series.
+func (m Model) drawGrid(canvas [][]rune, colors [][]lipgloss.Color, levels map[int]float64, prevRow int) {
+	fill := func(row int, r rune, c lipgloss.Color) {
+		for col := range canvas[row] {
+			if canvas[row][col] == ' ' {
+				canvas[row][col] = r
+				colors[row][col] = c
+			}
+		}
+	}
+	if m.gridLines {
+		for row := range levels {
+			fill(row, gridRune, styles.Dim(styles.ColorSubtext))
+		}
+	}
+	if prevRow >= 0 {
+		fill(prevRow, prevCloseRune, styles.ColorSecondary)
+	}
+}
+
+// behind reports whether r is background an overlay may draw over.
+func behind(r rune) bool {
+	return r == ' ' || r == '░' || r == gridRune || r == prevCloseRune
+}
@@ -0,0 +1,124 @@
+package chart
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/indicators"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// IndicatorSet selects which overlays are drawn on the price canvas.
+type IndicatorSet int
+
+const (
+	IndicatorsOff IndicatorSet = iota
+	IndicatorsSMA
+	IndicatorsEMA
+	IndicatorsBollinger
+	IndicatorsVWAP // intraday ranges only
+	IndicatorsAll
+)
+
+var indicatorSetNames = []string{"Off", "SMA", "EMA", "BB", "VWAP", "All"}
+
+func (s IndicatorSet) String() string {
+	return indicatorSetNames[s]
+}
+
+// overlay is a computed series drawn on top of the price data.
+// Values are NaN until the indicator has enough history.
+type overlay struct {
+	label  string
+	color  lipgloss.Color
+	values []float64
+}
+
+// overlays computes the series for the active indicator set.
+func (m Model) overlays(closes []float64) []overlay {
+	p := m.indicatorParams
+	var out []overlay
+
+	withSMA := m.indicators == IndicatorsSMA || m.indicators == IndicatorsAll
+	withEMA := m.indicators == IndicatorsEMA || m.indicators == IndicatorsAll
+	withBB := m.indicators == IndicatorsBollinger || m.indicators == IndicatorsAll
+
+	if withSMA {
+		out = append(out,
+			overlay{fmt.Sprintf("SMA%d", p.SMAFast), styles.ColorOverlay[0], indicators.SMA(closes, p.SMAFast)},
+			overlay{fmt.Sprintf("SMA%d", p.SMASlow), styles.ColorOverlay[1], indicators.SMA(closes, p.SMASlow)},
+		)
+	}
+	if withEMA {
+		out = append(out, overlay{fmt.Sprintf("EMA%d", p.EMA), styles.ColorOverlay[2], indicators.EMA(closes, p.EMA)})
+	}
+	if withBB {
+		mid, upper, lower := indicators.Bollinger(closes, p.BollingerPeriod, p.BollingerStdDev)
+		label := fmt.Sprintf("BB%d", p.BollingerPeriod)
+		out = append(out,
+			overlay{label, styles.ColorOverlay[3], upper},
+			overlay{"", styles.ColorOverlay[3], mid},
+			overlay{"", styles.ColorOverlay[3], lower},
+		)
+	}
+	if (m.indicators == IndicatorsVWAP || m.indicators == IndicatorsAll) && m.intraday() {
+		if o, ok := m.vwapOverlay(); ok {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// intraday reports whether the range is one VWAP is drawn on.
+func (m Model) intraday() bool {
+	base := m.timeRange.Base()
+	return base == models.Range1H || base == models.Range24H
+}
+
+// vwapOverlay computes VWAP over the full history from each candle's
+// typical price, starting afresh with every trading day of the symbol's
+// exchange. Sources without volume have no VWAP.
+func (m Model) vwapOverlay() (overlay, bool) {
+	n := len(m.full)
+	prices := make([]float64, n)
+	volumes := make([]float64, n)
+	starts := make([]bool, n)
+	cal := market.CalendarFor(m.symbol)
+	var day time.Time
+	traded := false
+	for i, c := range m.full {
+		prices[i] = (c.High + c.Low + c.Close) / 3
+		volumes[i] = c.Volume
+		if d := cal.TradingDay(c.Timestamp); i == 0 || !d.Equal(day) {
+			starts[i] = true
+			day = d
+		}
+		traded = traded || c.Volume > 0
+	}
+	if !traded {
+		return overlay{}, false
+	}
+	color := styles.ColorOverlay[4%len(styles.ColorOverlay)]
+	return overlay{"VWAP", color, indicators.VWAP(prices, volumes, starts)}, true
+}
+
+// defaultIndicatorParams mirrors the config defaults so a chart created
+// without SetIndicatorParams still renders sensible overlays.
+func defaultIndicatorParams() models.IndicatorConfig {
+	return models.IndicatorConfig{
+		SMAFast:         20,
+		SMASlow:         50,
+		EMA:             21,
+		BollingerPeriod: 20,
+		BollingerStdDev: 2,
+		RSIPeriod:       14,
+		RSIOverbought:   70,
+		RSIOversold:     30,
+		MACDFast:        12,
+		MACDSlow:        26,
+		MACDSignal:      9,
+	}
+}
@@ -0,0 +1,112 @@
+package chart
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// measuring reports whether a mouse drag spans more than one column.
+func (m Model) measuring() bool {
+	return m.dragging && m.dragStart != m.cursorCol && len(m.data) > 0
+}
+
+// measureSpan returns the data indices at the earlier and later ends of the
+// drag, whichever direction it was made in.
+func (m Model) measureSpan(width int) (int, int) {
+	n := len(m.data)
+	from, to := min(m.dragStart, m.cursorCol), max(m.dragStart, m.cursorCol)
+	i := m.columnIndex(from, n, width)
+	j := m.columnIndex(to, n, width)
+	if j < 0 {
+		j = n - 1
+	}
+	return max(0, i), j
+}
+
+// measureLine renders the drag measurement: the change between the two
+// ends and the time elapsed.
+func (m Model) measureLine(width int) string {
+	i, j := m.measureSpan(width)
+	a, b := m.data[i], m.data[j]
+
+	var pct float64
+	if a.Close > 0 {
+		pct = (b.Close - a.Close) / a.Close * 100
+	}
+	pctStyle := styles.PositiveChange
+	if pct < 0 {
+		pctStyle = styles.NegativeChange
+	}
+
+	change := fmt.Sprintf("%+.2f (%+.2f%%)", b.Close-a.Close, pct)
+	if market.IsYield(m.symbol) {
+		change = market.FormatBP((b.Close - a.Close) * 100)
+	}
+	text := fmt.Sprintf("%.2f → %.2f  ", a.Close, b.Close)
+	elapsed := fmt.Sprintf("  over %s, %d bars", formatElapsed(b.Timestamp.Sub(a.Timestamp)), j-i)
+
+	tipStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorText).
+		Background(styles.ColorHighlight)
+	tip := tipStyle.Render(" "+text) +
+		pctStyle.Background(styles.ColorHighlight).Render(change) +
+		tipStyle.Render(elapsed+" ")
+
+	// Start the line at the left end of the drag where it fits
+	indent := 9 + min(m.dragStart, m.cursorCol)
+	if maxIndent := width + 9 - lipgloss.Width(tip); indent > maxIndent {
+		indent = max(0, maxIndent)
+	}
+	return fmt.Sprintf("%*s", indent, "") + tip
+}
+
+// shadeMeasure marks the drag anchor column and dots the empty cells
+// between it and the cursor.
+func (m Model) shadeMeasure(canvas [][]rune, colors [][]lipgloss.Color, width int) {
+	from, to := min(m.dragStart, m.cursorCol), max(m.dragStart, m.cursorCol)
+	to = min(to, width-1)
+	for row := range canvas {
+		for col := from; col <= to; col++ {
+			if canvas[row][col] != ' ' {
+				continue
+			}
+			if col == m.dragStart {
+				canvas[row][col] = '┊'
+			} else if row%2 == 0 {
+				canvas[row][col] = '·'
+			} else {
+				continue
+			}
+			colors[row][col] = styles.ColorSubtext
+		}
+	}
+}
+
+// formatElapsed renders a duration in its two largest units, e.g. "3d 4h"
+// or "2h 15m", and in years and days past a year, e.g. "2y 40d".
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	mins := int(d/time.Minute) % 60
+	switch {
+	case days >= 365 && days%365 > 0:
+		return fmt.Sprintf("%dy %dd", days/365, days%365)
+	case days >= 365:
+		return fmt.Sprintf("%dy", days/365)
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && mins > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
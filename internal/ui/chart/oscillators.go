@@ -0,0 +1,226 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/indicators"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// ToggleRSI shows or hides the RSI panel under the price chart.
+func (m *Model) ToggleRSI() {
+	m.rsiPanel = !m.rsiPanel
+}
+
+func (m Model) RSIPanel() bool {
+	return m.rsiPanel
+}
+
+// ToggleMACD shows or hides the MACD panel under the price chart.
+func (m *Model) ToggleMACD() {
+	m.macdPanel = !m.macdPanel
+}
+
+func (m Model) MACDPanel() bool {
+	return m.macdPanel
+}
+
+// oscillatorPanels returns how many oscillator panels are shown and the
+// height of each for a price canvas of chartH rows. Four rows is the least
+// that keeps the RSI guides on rows of their own.
+func (m Model) oscillatorPanels(chartH int) (n, rows int) {
+	if m.rsiPanel {
+		n++
+	}
+	if m.macdPanel {
+		n++
+	}
+	return n, max(4, min(6, chartH/5))
+}
+
+// panel is a small canvas under the price chart with its own value scale,
+// hi on the top row and lo on the bottom one.
+type panel struct {
+	rows, width int
+	lo, hi      float64
+	cells       [][]rune
+	colors      [][]lipgloss.Color
+	labels      []string // y-axis gutter text per row
+}
+
+func newPanel(rows, width int, lo, hi float64) *panel {
+	p := &panel{rows: rows, width: width, lo: lo, hi: hi, labels: make([]string, rows)}
+	p.cells = make([][]rune, rows)
+	p.colors = make([][]lipgloss.Color, rows)
+	for r := range p.cells {
+		p.cells[r] = []rune(strings.Repeat(" ", width))
+		p.colors[r] = make([]lipgloss.Color, width)
+	}
+	return p
+}
+
+func (p *panel) row(v float64) int {
+	if p.hi == p.lo {
+		return p.rows / 2
+	}
+	r := int(math.Round((p.hi - v) / (p.hi - p.lo) * float64(p.rows-1)))
+	return max(0, min(p.rows-1, r))
+}
+
+// guide draws a dotted level line behind the data and labels it.
+func (p *panel) guide(v float64, label string) {
+	r := p.row(v)
+	for col := range p.width {
+		p.cells[r][col] = '┈'
+		p.colors[r][col] = styles.ColorSubtext
+	}
+	p.labels[r] = label
+}
+
+// bars draws a histogram from the zero level, one bar per column.
+func (p *panel) bars(values []float64, up, down lipgloss.Color) {
+	zero := p.row(0)
+	for col, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		r := p.row(v)
+		color := up
+		if v < 0 {
+			color = down
+		}
+		for y := min(r, zero); y <= max(r, zero); y++ {
+			if y == zero && r != zero {
+				continue
+			}
+			p.cells[y][col] = '▒'
+			p.colors[y][col] = color
+		}
+	}
+}
+
+// line draws a series, joining rows between neighboring columns so steep
+// moves stay connected. color picks each column's color by value.
+func (p *panel) line(values []float64, color func(float64) lipgloss.Color) {
+	prev := -1
+	for col, v := range values {
+		if math.IsNaN(v) {
+			prev = -1
+			continue
+		}
+		r := p.row(v)
+		c := color(v)
+		if prev >= 0 {
+			for y := min(r, prev) + 1; y < max(r, prev); y++ {
+				p.cells[y][col] = '│'
+				p.colors[y][col] = c
+			}
+		}
+		p.cells[r][col] = '─'
+		p.colors[r][col] = c
+		prev = r
+	}
+}
+
+func (p *panel) render() string {
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	cellStyles := make(map[lipgloss.Color]lipgloss.Style)
+	var b strings.Builder
+	for r := range p.rows {
+		b.WriteString(dimS.Render(fmt.Sprintf("%8s ", p.labels[r])))
+		for col := range p.width {
+			c := p.colors[r][col]
+			st, ok := cellStyles[c]
+			if !ok {
+				st = lipgloss.NewStyle().Foreground(c)
+				cellStyles[c] = st
+			}
+			b.WriteString(st.Render(string(p.cells[r][col])))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// sampleColumns picks each column's value from a series aligned with the
+// candles, using the price chart's column mapping.
+func (m Model) sampleColumns(series []float64, width int) []float64 {
+	out := make([]float64, width)
+	for col := range out {
+		idx := m.columnIndex(col, len(series), width)
+		if idx < 0 {
+			out[col] = math.NaN()
+			continue
+		}
+		out[col] = series[idx]
+	}
+	return out
+}
+
+// lastValue returns the latest defined value of a series.
+func lastValue(values []float64) (float64, bool) {
+	for i := len(values) - 1; i >= 0; i-- {
+		if !math.IsNaN(values[i]) {
+			return values[i], true
+		}
+	}
+	return 0, false
+}
+
+// renderRSI draws RSI on a fixed 0-100 scale with the overbought and
+// oversold guides. The line turns to the down color above overbought and
+// the up color below oversold. closes is the whole series; the zoomed
+// window of it is drawn.
+func (m Model) renderRSI(rows, width int, closes []float64) string {
+	prm := m.indicatorParams
+	series := m.visible(indicators.RSI(closes, prm.RSIPeriod))
+	p := newPanel(rows, width, 0, 100)
+	p.guide(prm.RSIOverbought, fmt.Sprintf("%.0f", prm.RSIOverbought))
+	p.guide(prm.RSIOversold, fmt.Sprintf("%.0f", prm.RSIOversold))
+	tc := styles.Trend(m.symbol)
+	p.line(m.sampleColumns(series, width), func(v float64) lipgloss.Color {
+		switch {
+		case v >= prm.RSIOverbought:
+			return tc.Down
+		case v <= prm.RSIOversold:
+			return tc.Up
+		}
+		return styles.ColorOverlay[2]
+	})
+	p.labels[0] = fmt.Sprintf("RSI%d", prm.RSIPeriod)
+	if v, ok := lastValue(series); ok {
+		p.labels[rows-1] = fmt.Sprintf("%.1f", v)
+	}
+	return p.render()
+}
+
+// renderMACD draws the MACD and signal lines over the histogram, on a
+// scale centered on zero, for the zoomed window of closes.
+func (m Model) renderMACD(rows, width int, closes []float64) string {
+	prm := m.indicatorParams
+	line, signal, hist := indicators.MACD(closes, prm.MACDFast, prm.MACDSlow, prm.MACDSignal)
+	line, signal, hist = m.visible(line), m.visible(signal), m.visible(hist)
+	line, signal, hist = m.sampleColumns(line, width), m.sampleColumns(signal, width), m.sampleColumns(hist, width)
+	var span float64
+	for _, s := range [][]float64{line, signal, hist} {
+		for _, v := range s {
+			if !math.IsNaN(v) {
+				span = max(span, math.Abs(v))
+			}
+		}
+	}
+	p := newPanel(rows, width, -span, span)
+	p.guide(0, "0")
+	tc := styles.Trend(m.symbol)
+	p.bars(hist, styles.Dim(tc.Up), styles.Dim(tc.Down))
+	p.line(signal, func(float64) lipgloss.Color { return styles.ColorOverlay[1] })
+	p.line(line, func(float64) lipgloss.Color { return styles.ColorOverlay[0] })
+	p.labels[0] = "MACD"
+	if v, ok := lastValue(line); ok {
+		p.labels[rows-1] = fmt.Sprintf("%+.2f", v)
+	}
+	return p.render()
+}
@@ -0,0 +1,70 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/paper"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// PaperSummary is the paper-trading account as listed under the portfolio
+// chart: the cash, the positions' value at the latest prices, the profit
+// and loss on them and on past sales, and the open orders.
+type PaperSummary struct {
+	Cash, Value          float64
+	Unrealized, Realized float64
+	Orders               []paper.Order
+}
+
+// maxPaperOrders is how many open orders are listed before the rest are
+// counted.
+const maxPaperOrders = 4
+
+// SetPaper lists the paper-trading account under the portfolio chart; nil
+// leaves it out.
+func (m *Model) SetPaper(s *PaperSummary) {
+	m.paper = s
+}
+
+// renderPaper lists the paper-trading account in width columns.
+func (m Model) renderPaper(width int) string {
+	s := m.paper
+	dim := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	text := lipgloss.NewStyle().Foreground(styles.ColorText)
+	pnl := func(v float64) string {
+		st := styles.PositiveChange
+		if v < 0 {
+			st = styles.NegativeChange
+		}
+		return st.Render(fmt.Sprintf("%+.2f", v))
+	}
+	money := func(v float64) string { return text.Render(market.FormatPrice(v, "", "%.2f")) }
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render("Paper") +
+			dim.Render("  Cash ") + money(s.Cash) +
+			dim.Render("  Positions ") + money(s.Value) +
+			dim.Render("  Unrealized ") + pnl(s.Unrealized) +
+			dim.Render("  Realized ") + pnl(s.Realized),
+	}
+	if len(s.Orders) == 0 {
+		lines = append(lines, dim.Render("No open orders"))
+	}
+	for i, o := range s.Orders {
+		if i == maxPaperOrders {
+			lines = append(lines, dim.Render(fmt.Sprintf("+%d more", len(s.Orders)-i)))
+			break
+		}
+		o.Symbol = m.aliases.Name(o.Symbol)
+		lines = append(lines, dim.Render(fmt.Sprintf("#%-3d ", o.ID))+text.Render(o.String())+
+			dim.Render("  placed "+o.Placed.Local().Format("Jan 02 15:04")))
+	}
+	for i, l := range lines {
+		lines[i] = ansi.Truncate(l, width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
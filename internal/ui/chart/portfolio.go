@@ -0,0 +1,64 @@
+package chart
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+)
+
+// PortfolioSymbol names the portfolio series in the legend.
+const PortfolioSymbol = "Portfolio"
+
+// SetPortfolio switches between the selected symbol and the portfolio
+// charted against its benchmark. The chart shows it loading until
+// SetPortfolioData.
+func (m *Model) SetPortfolio(on bool) {
+	m.portfolio = on
+	if !on {
+		m.portfolioSet, m.portfolioErr = nil, nil
+	}
+}
+
+// Portfolio reports whether the portfolio chart is showing.
+func (m Model) Portfolio() bool {
+	return m.portfolio
+}
+
+// SetPortfolioData sets the portfolio's value history and the benchmark's,
+// or the error that kept the portfolio from loading. A benchmark without
+// data leaves the portfolio charted alone.
+func (m *Model) SetPortfolioData(portfolio, benchmark Series, err error) {
+	m.portfolioErr = err
+	m.portfolioSet = []Series{portfolio}
+	if len(benchmark.Data) > 0 {
+		m.portfolioSet = append(m.portfolioSet, benchmark)
+	}
+}
+
+func (m Model) renderPortfolio() string {
+	// The paper-trading account takes the rows it needs under the chart
+	if m.paper != nil {
+		panel := m.renderPaper(m.width - 4)
+		rest := m
+		rest.paper = nil
+		rest.height -= lipgloss.Height(panel) + 1
+		if rest.height >= 8 {
+			top := lipgloss.NewStyle().Height(rest.height - 4).Render(rest.renderPortfolio())
+			return lipgloss.JoinVertical(lipgloss.Left, top, "", panel)
+		}
+	}
+	place := func(s string) string {
+		return lipgloss.Place(m.width-4, m.height-4, lipgloss.Center, lipgloss.Center, s)
+	}
+	switch {
+	case m.portfolioErr != nil:
+		return place(m.portfolioErr.Error())
+	case len(m.portfolioSet) == 0 || len(m.portfolioSet[0].Data) == 0:
+		return place("Loading...")
+	}
+	p := m.portfolioSet[0].Data
+	title := "Portfolio " + market.FormatPrice(p[len(p)-1].Close, "", "%.2f")
+	if len(m.portfolioSet) > 1 {
+		title += " vs " + m.aliases.Name(m.portfolioSet[1].Symbol)
+	}
+	return m.renderNormalized(title, m.portfolioSet)
+}
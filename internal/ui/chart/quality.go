@@ -0,0 +1,164 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// maxSlots bounds the candle slots walked through any one gap, so a long
+// stretch of missing 1m data stays cheap to score.
+const maxSlots = 2000
+
+// Quality scores how complete and current a charted series is.
+type Quality struct {
+	Score   int                   // 0-100
+	Missing int                   // candles absent while the market traded
+	Gaps    map[int]time.Duration // trading time missing before each candle
+	Dropped int                   // malformed points the provider left out
+	Total   int                   // points the provider received
+	Age     time.Duration         // since the last candle
+	Stale   bool                  // candles the market should have made are missing at the end
+}
+
+// assess scores data for symbol over the chart's range at now. A gap
+// counts only where the market traded, so nights and weekends of a stock
+// cost nothing; the extended sessions count when the series has candles in
+// them.
+func (m Model) assess(symbol string, data []models.Candle, dropped [2]int, now time.Time) Quality {
+	q := Quality{Score: 100, Gaps: make(map[int]time.Duration), Dropped: dropped[0], Total: dropped[1]}
+	step := spacing(data)
+	if step <= 0 {
+		return q
+	}
+	cal := market.CalendarFor(symbol)
+	extended := slices.ContainsFunc(data, func(c models.Candle) bool {
+		st := cal.State(c.Timestamp)
+		return st == market.StatePre || st == market.StatePost
+	})
+	// missed counts the slots in [from, to) that should have had a candle
+	missed := func(from, to time.Time) int {
+		n := 0
+		for t, i := from, 0; t.Before(to) && i < maxSlots; t, i = t.Add(step), i+1 {
+			if tradable(cal, t, step, extended) {
+				n++
+			}
+		}
+		return n
+	}
+
+	for i := 1; i < len(data); i++ {
+		prev, next := data[i-1].Timestamp, data[i].Timestamp
+		if next.Sub(prev) <= step*3/2 {
+			continue
+		}
+		if n := missed(prev.Add(step), next.Add(-step/2)); n > 0 {
+			q.Missing += n
+			q.Gaps[i] = time.Duration(n) * step
+		}
+	}
+
+	last := data[len(data)-1].Timestamp
+	q.Age = now.Sub(last)
+	if !m.timeRange.IsCustom() && q.Age > 5*time.Minute {
+		// The latest slot may still be filling in
+		q.Stale = missed(last.Add(step), now.Add(-step)) >= 2
+	}
+	q.Stale = q.Stale || (m.stale && symbol == m.symbol)
+
+	present := float64(len(data)) / float64(len(data)+q.Missing)
+	if q.Total > 0 {
+		present *= 1 - float64(q.Dropped)/float64(q.Total)
+	}
+	q.Score = int(math.Round(present * 100))
+	if q.Stale {
+		q.Score = max(0, q.Score-25)
+	}
+	return q
+}
+
+// spacing returns the usual distance between candles, the median of the
+// gaps between neighbors.
+func spacing(data []models.Candle) time.Duration {
+	if len(data) < 3 {
+		return 0
+	}
+	gaps := make([]time.Duration, 0, len(data)-1)
+	for i := 1; i < len(data); i++ {
+		if d := data[i].Timestamp.Sub(data[i-1].Timestamp); d > 0 {
+			gaps = append(gaps, d)
+		}
+	}
+	if len(gaps) == 0 {
+		return 0
+	}
+	slices.Sort(gaps)
+	return gaps[len(gaps)/2]
+}
+
+// tradable reports whether the candle slot starting at t should hold a
+// candle: part of it falls in the regular session, or in an extended one
+// when extended is set. Sampling within the slot lets daily candles match
+// whatever time of day the provider stamps them with.
+func tradable(cal *market.Calendar, t time.Time, step time.Duration, extended bool) bool {
+	for i := range 4 {
+		st := cal.State(t.Add(step * time.Duration(i) / 4))
+		if st == market.StateOpen || (extended && st != market.StateClosed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q Quality) color() lipgloss.Color {
+	switch {
+	case q.Score < 70:
+		return styles.ColorError
+	case q.Score < 95:
+		return styles.ColorWarning
+	}
+	return styles.ColorSubtext
+}
+
+// badge is the short form shown in the chart header.
+func (q Quality) badge() string {
+	return lipgloss.NewStyle().Foreground(q.color()).Render(fmt.Sprintf("data %d%%", q.Score))
+}
+
+// details spells out what went into the score.
+func (q Quality) details() string {
+	parts := []string{fmt.Sprintf("Data quality %d%%", q.Score)}
+	switch len(q.Gaps) {
+	case 0:
+		parts = append(parts, "no gaps")
+	case 1:
+		parts = append(parts, fmt.Sprintf("1 gap, %d candles missing", q.Missing))
+	default:
+		parts = append(parts, fmt.Sprintf("%d gaps, %d candles missing", len(q.Gaps), q.Missing))
+	}
+	if q.Dropped > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d points dropped", q.Dropped, q.Total))
+	}
+	age := "last candle " + formatElapsed(max(q.Age, 0)) + " ago"
+	if q.Stale {
+		age = "stale, " + age
+	}
+	return strings.Join(append(parts, age), " · ")
+}
+
+// qualityLine renders the details under the header while the pointer is
+// over it.
+func (m Model) qualityLine(q Quality, width int) string {
+	tipStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorText).
+		Background(styles.ColorHighlight)
+	return ansi.Truncate(fmt.Sprintf("%9s", "")+tipStyle.Render(" "+q.details()+" "), width+9, "…")
+}
@@ -0,0 +1,53 @@
+package chart
+
+import "math"
+
+// yScale maps prices to vertical positions on the chart, linearly or on a
+// log scale where equal percentage moves take equal heights.
+type yScale struct {
+	lo, hi float64 // padded bounds, as logs on a log scale
+	log    bool
+}
+
+// newYScale fits the scale to minP..maxP with 5% padding on each side. A
+// log scale needs positive prices and falls back to linear otherwise.
+func newYScale(minP, maxP float64, log bool) yScale {
+	log = log && minP > 0
+	if log {
+		minP, maxP = math.Log(minP), math.Log(maxP)
+	}
+	spread := maxP - minP
+	if spread == 0 {
+		spread = maxP * 0.01
+		if log {
+			spread = 0.01
+		}
+	}
+	return yScale{lo: minP - spread*0.05, hi: maxP + spread*0.05, log: log}
+}
+
+// pos returns how far down the chart v sits, from 0 at the top to 1 at
+// the bottom.
+func (s yScale) pos(v float64) float64 {
+	if s.log {
+		if v <= 0 {
+			return 1
+		}
+		v = math.Log(v)
+	}
+	return (s.hi - v) / (s.hi - s.lo)
+}
+
+// at is the inverse of pos, for axis labels.
+func (s yScale) at(pos float64) float64 {
+	v := s.hi - pos*(s.hi-s.lo)
+	if s.log {
+		return math.Exp(v)
+	}
+	return v
+}
+
+// row maps v onto one of n rows, clamped to the chart.
+func (s yScale) row(v float64, n int) int {
+	return max(0, min(int(s.pos(v)*float64(n-1)), n-1))
+}
@@ -0,0 +1,87 @@
+package chart
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// SetSessions turns on marking of pre-market and after-hours candles for
+// US stocks, which show up when extended hours are fetched.
+func (m *Model) SetSessions(on bool) {
+	m.sessions = on
+}
+
+// marksSessions reports whether the chart tells extended-hours candles
+// apart: intraday data of a US stock, with sessions on.
+func (m Model) marksSessions() bool {
+	if !m.sessions || len(m.data) < 2 || m.data[1].Timestamp.Sub(m.data[0].Timestamp) >= 24*time.Hour {
+		return false
+	}
+	return market.AssetClass(m.symbol) == market.ClassStock && market.ExchangeFor(m.symbol) == market.US
+}
+
+// candleSession returns the extended session a candle belongs to, or ""
+// for the regular session.
+func (m Model) candleSession(c models.Candle) models.ExtSession {
+	if !m.marksSessions() {
+		return ""
+	}
+	switch market.CalendarFor(m.symbol).State(c.Timestamp) {
+	case market.StatePre:
+		return models.SessionPre
+	case market.StatePost:
+		return models.SessionPost
+	}
+	return ""
+}
+
+// extendedColumns marks the columns drawn from candles outside the regular
+// session, or returns nil when nothing is marked.
+func (m Model) extendedColumns(width int) []bool {
+	if !m.marksSessions() {
+		return nil
+	}
+	cal := market.CalendarFor(m.symbol)
+	n := len(m.data)
+	cols := make([]bool, width)
+	marked := false
+	for col := range cols {
+		idx := m.columnIndex(col, n, width)
+		if idx < 0 {
+			break
+		}
+		if cal.State(m.data[idx].Timestamp) != market.StateOpen {
+			cols[col] = true
+			marked = true
+		}
+	}
+	if !marked {
+		return nil
+	}
+	return cols
+}
+
+// markSessions dims the extended-hours columns of the canvas and draws a
+// dotted rule in the empty cells where the regular session opens or closes.
+func markSessions(canvas [][]rune, colors [][]lipgloss.Color, ext []bool) {
+	for col, out := range ext {
+		for row := range canvas {
+			if out && canvas[row][col] != ' ' {
+				colors[row][col] = styles.Dim(colors[row][col])
+			}
+		}
+		if col == 0 || out == ext[col-1] {
+			continue
+		}
+		for row := range canvas {
+			if canvas[row][col] == ' ' {
+				canvas[row][col] = '┊'
+				colors[row][col] = styles.ColorSubtext
+			}
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package chart
+
+import "github.com/ni5arga/stock-tui/internal/ui/styles"
+
+// Snapshot renders the chart at the given size, border included,
+// independent of the pane's current size, for exports. Zero dimensions
+// fall back to the pane size. Interactive state (crosshair, hover, drag)
+// is left out of the picture.
+func (m Model) Snapshot(width, height int) string {
+	pane := styles.PaneFor(m.focused)
+	if width > 0 {
+		m.width = width - pane.GetHorizontalBorderSize()
+	}
+	if height > 0 {
+		m.height = height - pane.GetVerticalBorderSize()
+	}
+	m.crosshair = false
+	m.hovering = false
+	m.dragging = false
+	return m.View()
+}
@@ -0,0 +1,53 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+func sampleCandles(n int) []models.Candle {
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+	candles := make([]models.Candle, n)
+	for i := range candles {
+		price := 100 + float64(i%7) - float64(i%3)
+		candles[i] = models.Candle{
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+			Open:      price,
+			High:      price + 2,
+			Low:       price - 2,
+			Close:     price + 1,
+			Volume:    1000 + float64(i*10),
+		}
+	}
+	return candles
+}
+
+func TestSnapshotSize(t *testing.T) {
+	m := New()
+	m.SetSize(50, 15)
+	m.SetData("AAPL", models.Range7D, sampleCandles(60))
+
+	for _, size := range [][2]int{{120, 40}, {60, 20}, {200, 60}} {
+		out := m.Snapshot(size[0], size[1])
+		lines := strings.Split(out, "\n")
+		if len(lines) != size[1] {
+			t.Errorf("Snapshot(%d, %d) has %d lines, want %d", size[0], size[1], len(lines), size[1])
+		}
+		if w := lipgloss.Width(out); w != size[0] {
+			t.Errorf("Snapshot(%d, %d) is %d wide, want %d", size[0], size[1], w, size[0])
+		}
+	}
+
+	// Zero falls back to the pane, border included
+	out := m.Snapshot(0, 0)
+	if got := len(strings.Split(out, "\n")); got != 17 {
+		t.Errorf("Snapshot(0, 0) has %d lines, want 17", got)
+	}
+	if w := lipgloss.Width(out); w != 52 {
+		t.Errorf("Snapshot(0, 0) is %d wide, want 52", w)
+	}
+}
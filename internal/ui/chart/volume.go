@@ -0,0 +1,128 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// SetVolume configures the volume strip. It is hidden when show is false or
+// when the pane is shorter than minHeight rows.
+func (m *Model) SetVolume(show bool, minHeight int) {
+	m.showVolume = show
+	m.volumeMinHeight = minHeight
+}
+
+// volumeRows returns the strip height for a price canvas of chartH rows,
+// or 0 if the strip should not be drawn.
+func (m Model) volumeRows(chartH int) int {
+	if !m.showVolume || m.height < m.volumeMinHeight {
+		return 0
+	}
+	var hasVolume bool
+	for _, c := range m.data {
+		if c.Volume > 0 {
+			hasVolume = true
+			break
+		}
+	}
+	if !hasVolume {
+		return 0
+	}
+	return max(2, min(4, chartH/6))
+}
+
+// columnRange returns the half-open data range aggregated into a column.
+func (m Model) columnRange(col, n, width int) (int, int) {
+	if m.chartType == ChartCandle {
+		perCol := max(1, n/width)
+		start := col * perCol
+		return start, min(start+perCol, n)
+	}
+	step := float64(n) / float64(width)
+	start := min(int(float64(col)*step), n-1)
+	end := max(start+1, min(int(float64(col+1)*step), n))
+	return start, end
+}
+
+// renderVolume draws the volume histogram with its own scale, one bar per
+// column in eighth-block resolution.
+func (m Model) renderVolume(rows, width int) string {
+	blocks := []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	n := len(m.data)
+
+	vols := make([]float64, width)
+	ups := make([]bool, width)
+	var maxVol float64
+	for col := 0; col < width; col++ {
+		start, end := m.columnRange(col, n, width)
+		if start >= n {
+			break
+		}
+		for i := start; i < end; i++ {
+			vols[col] += m.data[i].Volume
+		}
+		ups[col] = m.data[end-1].Close >= m.data[start].Open
+		if vols[col] > maxVol {
+			maxVol = vols[col]
+		}
+	}
+	if maxVol == 0 {
+		return ""
+	}
+
+	tc := styles.Trend(m.symbol)
+	greenS := lipgloss.NewStyle().Foreground(tc.Up)
+	redS := lipgloss.NewStyle().Foreground(tc.Down)
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	extGreenS := lipgloss.NewStyle().Foreground(styles.Dim(tc.Up))
+	extRedS := lipgloss.NewStyle().Foreground(styles.Dim(tc.Down))
+	ext := m.extendedColumns(width)
+
+	units := rows * 8
+	var b strings.Builder
+	for row := 0; row < rows; row++ {
+		switch row {
+		case 0:
+			b.WriteString(dimS.Render(fmt.Sprintf("%8s ", formatVolume(maxVol))))
+		case rows - 1:
+			b.WriteString(dimS.Render(fmt.Sprintf("%8s ", "Vol")))
+		default:
+			b.WriteString("         ")
+		}
+
+		// Rows are drawn top-down; level counts eighths from the strip bottom
+		level := (rows - 1 - row) * 8
+		for col := 0; col < width; col++ {
+			h := int(vols[col] / maxVol * float64(units))
+			fill := max(0, min(8, h-level))
+			st := greenS
+			switch {
+			case ext != nil && ext[col] && ups[col]:
+				st = extGreenS
+			case ext != nil && ext[col]:
+				st = extRedS
+			case !ups[col]:
+				st = redS
+			}
+			b.WriteString(st.Render(string(blocks[fill])))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func formatVolume(v float64) string {
+	switch {
+	case v >= 1e9:
+		return fmt.Sprintf("%.1fB", v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case v >= 1e3:
+		return fmt.Sprintf("%.1fK", v/1e3)
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
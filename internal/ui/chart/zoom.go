@@ -0,0 +1,121 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// minWindow is the fewest candles a zoom shows.
+const minWindow = 10
+
+// window returns the bounds [lo, hi) of the zoomed window in the fetched
+// series: winLen candles ending winEnd before the latest, or all of it.
+func (m Model) window() (int, int) {
+	n := len(m.full)
+	if m.winLen <= 0 || m.winLen >= n {
+		return 0, n
+	}
+	hi := n - min(m.winEnd, n-m.winLen)
+	return hi - m.winLen, hi
+}
+
+// applyWindow points data at the zoomed window.
+func (m *Model) applyWindow() {
+	lo, hi := m.window()
+	m.data = m.full[lo:hi]
+	m.cursorCol = min(m.cursorCol, m.lastColumn())
+}
+
+// Zoomed reports whether the chart shows only part of the fetched series.
+func (m Model) Zoomed() bool {
+	lo, hi := m.window()
+	return hi-lo < len(m.full)
+}
+
+// canZoom reports whether the chart shows the symbol alone, the one view
+// that zooms.
+func (m Model) canZoom() bool {
+	return !m.Comparing() && !m.portfolio
+}
+
+// ZoomIn halves the window around its middle, down to minWindow candles.
+func (m *Model) ZoomIn() {
+	lo, hi := m.window()
+	m.zoomTo(max(minWindow, (hi-lo)/2), (lo+hi)/2)
+}
+
+// ZoomOut doubles the window around its middle; at the full series it
+// drops the zoom.
+func (m *Model) ZoomOut() {
+	lo, hi := m.window()
+	m.zoomTo((hi-lo)*2, (lo+hi)/2)
+}
+
+// zoomTo shows size candles centered on mid, kept inside the series.
+func (m *Model) zoomTo(size, mid int) {
+	if !m.canZoom() {
+		return
+	}
+	n := len(m.full)
+	if size >= n {
+		m.ResetZoom()
+		return
+	}
+	hi := max(size, min(n, mid+size/2))
+	m.winLen, m.winEnd = size, n-hi
+	m.applyWindow()
+}
+
+// ResetZoom shows the whole fetched series again.
+func (m *Model) ResetZoom() {
+	m.winLen, m.winEnd = 0, 0
+	m.applyWindow()
+}
+
+// Pan moves a zoomed window by delta eighths of its width, later for a
+// positive delta.
+func (m *Model) Pan(delta int) {
+	if !m.Zoomed() || !m.canZoom() {
+		return
+	}
+	m.panCandles(delta * max(1, m.winLen/8))
+}
+
+// panCandles moves the window by delta candles, stopping at either end.
+func (m *Model) panCandles(delta int) {
+	m.winEnd = max(0, min(m.winEnd-delta, len(m.full)-m.winLen))
+	m.applyWindow()
+}
+
+// dragPan pans to follow a right-button drag that has moved cols columns
+// from where it was pressed, the candles moving with the pointer.
+func (m *Model) dragPan(cols, width int) {
+	if !m.Zoomed() || width <= 0 {
+		return
+	}
+	candles := int(math.Round(float64(cols) * float64(m.winLen) / float64(width)))
+	m.winEnd = m.panStart
+	m.panCandles(-candles)
+}
+
+// visible slices a series aligned with the fetched candles to the
+// window, so indicators computed over the whole series keep their history.
+func (m Model) visible(series []float64) []float64 {
+	lo, hi := m.window()
+	return series[lo:hi]
+}
+
+// fullCloses returns the closes of the whole fetched series.
+func (m Model) fullCloses() []float64 {
+	closes := make([]float64, len(m.full))
+	for i, c := range m.full {
+		closes[i] = c.Close
+	}
+	return closes
+}
+
+// windowSpan returns the first and last candle of the window.
+func (m Model) windowSpan() (models.Candle, models.Candle) {
+	return m.data[0], m.data[len(m.data)-1]
+}
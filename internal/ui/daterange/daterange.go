@@ -0,0 +1,204 @@
+package daterange
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+const layout = "2006-01-02"
+
+// SubmitMsg carries the confirmed window. Both dates are inclusive.
+type SubmitMsg struct {
+	From time.Time
+	To   time.Time
+}
+
+// Model is a modal with start and end date fields. Up/down nudges the
+// focused date by a day, shift+up/down by a month.
+type Model struct {
+	inputs  [2]textinput.Model
+	focus   int
+	err     error
+	visible bool
+	width   int
+	height  int
+}
+
+func New() Model {
+	var m Model
+	for i := range m.inputs {
+		ti := textinput.New()
+		ti.Placeholder = "YYYY-MM-DD"
+		ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+		ti.TextStyle = lipgloss.NewStyle().Foreground(styles.ColorText).Bold(true)
+		ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+		ti.CharLimit = len(layout)
+		ti.Width = len(layout) + 1
+		m.inputs[i] = ti
+	}
+	return m
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+// SetReducedMotion keeps the input cursors from blinking.
+func (m *Model) SetReducedMotion(on bool) {
+	mode := cursor.CursorBlink
+	if on {
+		mode = cursor.CursorStatic
+	}
+	for i := range m.inputs {
+		m.inputs[i].Cursor.SetMode(mode)
+	}
+}
+
+// Open shows the picker pre-filled with the given window.
+func (m *Model) Open(from, to time.Time) tea.Cmd {
+	m.inputs[0].SetValue(from.Format(layout))
+	m.inputs[1].SetValue(to.Format(layout))
+	m.err = nil
+	m.visible = true
+	return m.setFocus(0)
+}
+
+func (m *Model) setFocus(i int) tea.Cmd {
+	m.focus = i
+	m.inputs[1-i].Blur()
+	m.inputs[i].CursorEnd()
+	return m.inputs[i].Focus()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.visible = false
+			return m, nil
+		case "tab", "shift+tab":
+			return m, m.setFocus(1 - m.focus)
+		case "up", "down", "shift+up", "shift+down":
+			m.nudge(msg.String())
+			return m, nil
+		case "enter":
+			from, to, err := m.parse()
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.visible = false
+			return m, func() tea.Msg { return SubmitMsg{From: from, To: to} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	m.err = nil
+	return m, cmd
+}
+
+func (m *Model) nudge(key string) {
+	t, err := time.ParseInLocation(layout, m.inputs[m.focus].Value(), time.Local)
+	if err != nil {
+		return
+	}
+	switch key {
+	case "up":
+		t = t.AddDate(0, 0, 1)
+	case "down":
+		t = t.AddDate(0, 0, -1)
+	case "shift+up":
+		t = t.AddDate(0, 1, 0)
+	case "shift+down":
+		t = t.AddDate(0, -1, 0)
+	}
+	m.inputs[m.focus].SetValue(t.Format(layout))
+	m.inputs[m.focus].CursorEnd()
+	m.err = nil
+}
+
+func (m Model) parse() (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(layout, strings.TrimSpace(m.inputs[0].Value()), time.Local)
+	if err != nil {
+		return from, from, fmt.Errorf("invalid start date")
+	}
+	to, err := time.ParseInLocation(layout, strings.TrimSpace(m.inputs[1].Value()), time.Local)
+	if err != nil {
+		return from, to, fmt.Errorf("invalid end date")
+	}
+	if !from.Before(to) {
+		return from, to, fmt.Errorf("start must be before end")
+	}
+	if from.After(time.Now()) {
+		return from, to, fmt.Errorf("start must not be in the future")
+	}
+	return from, to, nil
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext).
+		Width(7)
+
+	activeLabel := labelStyle.Copy().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext).
+		Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Custom Range"))
+	sb.WriteString("\n\n")
+	for i, label := range []string{"From", "To"} {
+		ls := labelStyle
+		if i == m.focus {
+			ls = activeLabel
+		}
+		sb.WriteString(ls.Render(label))
+		sb.WriteString(m.inputs[i].View())
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	if m.err != nil {
+		sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(hintStyle.Render("Tab switch field • ↑/↓ ±1 day • Shift ±1 month\nEnter to apply • Esc to cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
@@ -0,0 +1,209 @@
+package earnings
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// soonIcon marks reports due within models.EarningsSoonDays, as on the
+// watchlist rows.
+const soonIcon = "◆"
+
+// Model is the earnings calendar modal: the active watchlist's upcoming
+// reports, soonest first.
+type Model struct {
+	toggle  key.Binding
+	visible bool
+	loading bool
+	err     error
+	width   int
+	height  int
+
+	rows    []models.Earnings
+	missing int // listed stocks without a report date
+	offset  int
+}
+
+// New builds the earnings modal; toggle also closes it.
+func New(toggle key.Binding) Model {
+	return Model{toggle: toggle}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.toggle) || msg.String() == "esc" || msg.String() == "q":
+			m.visible = false
+		case msg.String() == "j" || msg.String() == "down":
+			m.offset = min(m.offset+1, max(0, len(m.rows)-m.pageRows()))
+		case msg.String() == "k" || msg.String() == "up":
+			m.offset = max(m.offset-1, 0)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Open shows the modal, loading until SetData or SetError when it has
+// nothing to show yet.
+func (m *Model) Open() {
+	m.visible = true
+	m.loading = m.rows == nil && m.err == nil
+	m.offset = 0
+}
+
+// SetData shows the reports still to come, soonest first. missing is the
+// number of stocks in the list without one.
+func (m *Model) SetData(rows []models.Earnings, missing int) {
+	now := time.Now()
+	m.rows = make([]models.Earnings, 0, len(rows))
+	for _, e := range rows {
+		if e.DaysUntil(now) >= 0 {
+			m.rows = append(m.rows, e)
+		}
+	}
+	slices.SortStableFunc(m.rows, func(a, b models.Earnings) int { return a.Date.Compare(b.Date) })
+	m.missing = missing
+	m.offset = min(m.offset, max(0, len(m.rows)-m.pageRows()))
+	m.loading = false
+	m.err = nil
+}
+
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = err
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// pageRows is the number of reports that fit in the modal.
+func (m Model) pageRows() int {
+	return max(3, m.height-12)
+}
+
+// timing spells out a report's time of day.
+func timing(t string) string {
+	switch t {
+	case models.EarningsBeforeOpen:
+		return "Before open"
+	case models.EarningsAfterClose:
+		return "After close"
+	}
+	return "—"
+}
+
+// when is how far off a report is, in days.
+func when(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	}
+	return fmt.Sprintf("in %dd", days)
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
+
+	soonStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorWarning)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Earnings Calendar"))
+	sb.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		sb.WriteString(dimStyle.Render("Loading report dates..."))
+		sb.WriteString("\n")
+	case m.err != nil:
+		sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+		sb.WriteString("\n")
+	case len(m.rows) == 0:
+		sb.WriteString(dimStyle.Render("No upcoming reports for this watchlist"))
+		sb.WriteString("\n")
+	default:
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("  %-12s %-10s %-12s %9s  %s", "Date", "Symbol", "Time", "EPS est.", "")))
+		sb.WriteString("\n")
+		now := time.Now()
+		end := min(len(m.rows), m.offset+m.pageRows())
+		projected := false
+		for _, e := range m.rows[m.offset:end] {
+			mark := "  "
+			if e.Soon(now) {
+				mark = soonStyle.Render(soonIcon) + " "
+			}
+			date := e.Date.Local().Format("Mon Jan 02")
+			if e.Estimated {
+				date += "~"
+				projected = true
+			}
+			est := "—"
+			if e.EPSEstimate != 0 {
+				est = fmt.Sprintf("%.2f", e.EPSEstimate)
+			}
+			sb.WriteString(mark)
+			sb.WriteString(fmt.Sprintf("%-12s %-10s %-12s %9s  ", date, truncate(e.Symbol, 10), timing(e.Timing), est))
+			sb.WriteString(dimStyle.Render(when(e.DaysUntil(now))))
+			sb.WriteString("\n")
+		}
+		if len(m.rows) > m.pageRows() {
+			sb.WriteString(dimStyle.Render(fmt.Sprintf("  %d–%d of %d, j/k to scroll", m.offset+1, end, len(m.rows))))
+			sb.WriteString("\n")
+		}
+		if projected {
+			sb.WriteString(dimStyle.Render("  ~ date projected, not yet confirmed"))
+			sb.WriteString("\n")
+		}
+	}
+
+	if m.missing > 0 && !m.loading && m.err == nil {
+		sb.WriteString("\n")
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("%d more with no date listed", m.missing)))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("Esc to close"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
+
+func truncate(s string, w int) string {
+	r := []rune(s)
+	if len(r) <= w {
+		return s
+	}
+	return string(r[:w-1]) + "…"
+}
@@ -2,11 +2,15 @@ package footer
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ni5arga/stock-tui/internal/market"
 	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
 )
 
 type Model struct {
@@ -16,6 +20,14 @@ type Model struct {
 	connected  bool
 	err        error
 	timeRange  models.TimeRange
+	message    string
+	watchlist  string
+	queued     int
+	stale      []string // symbols whose quotes stopped refreshing
+	market     *market.Calendar
+	static     bool   // reduced motion: the time of the next change, not a countdown
+	focus      string // the pane with the keyboard focus
+	focusKeys  string // its main keys, e.g. "/ Search  s Sort"
 }
 
 func New(provider string) Model {
@@ -48,63 +60,233 @@ func (m *Model) SetTimeRange(tr models.TimeRange) {
 	m.timeRange = tr
 }
 
+// SetProvider sets the data source name shown in the status area.
+func (m *Model) SetProvider(name string) {
+	m.provider = name
+}
+
+// SetWatchlist sets the active watchlist name shown next to the provider.
+func (m *Model) SetWatchlist(name string) {
+	m.watchlist = name
+}
+
+// SetQueued sets how many requests are waiting for a provider's rate
+// limit; the count shows after the provider while it is above zero.
+func (m *Model) SetQueued(n int) {
+	m.queued = n
+}
+
+// SetStale sets the symbols whose quotes have stopped refreshing, named
+// before the update time while there are any.
+func (m *Model) SetStale(symbols []string) {
+	m.stale = symbols
+}
+
+// staleView renders the stale symbols, the first few by name.
+func (m Model) staleView() string {
+	if len(m.stale) == 0 {
+		return ""
+	}
+	const shown = 3
+	names := strings.Join(m.stale[:min(shown, len(m.stale))], ", ")
+	if len(m.stale) > shown {
+		names += fmt.Sprintf(" +%d", len(m.stale)-shown)
+	}
+	return " ⚠ stale: " + names
+}
+
+// SetMarket sets the calendar of the selected symbol's market, whose state
+// and countdown to the next change show after the watchlist name. Pass nil
+// to hide it.
+func (m *Model) SetMarket(c *market.Calendar) {
+	m.market = c
+}
+
+// SetReducedMotion shows when the market next changes state instead of a
+// countdown that ticks down on every redraw.
+func (m *Model) SetReducedMotion(on bool) {
+	m.static = on
+}
+
+// marketView renders the market state, e.g. "US open · closes in 2h 5m".
+func (m Model) marketView(base lipgloss.Style) string {
+	if m.market == nil {
+		return ""
+	}
+	now := time.Now()
+	st := m.market.Status(now)
+	color := styles.ColorSuccess
+	switch st.State {
+	case market.StatePre, market.StatePost:
+		color = styles.ColorWarning
+	case market.StateClosed:
+		color = styles.ColorError
+	}
+	out := base.Render("│ "+st.Market+" ") + base.Foreground(color).Render(string(st.State))
+	if st.Until.IsZero() {
+		return out + base.Render(" 24/7 ")
+	}
+	verb := "closes"
+	switch st.Next {
+	case market.StateOpen:
+		verb = "opens"
+	case market.StatePre:
+		verb = "pre-market"
+	}
+	if m.static {
+		return out + base.Render(fmt.Sprintf(" · %s %s ", verb, clockTime(st.Until, now)))
+	}
+	return out + base.Render(fmt.Sprintf(" · %s in %s ", verb, countdown(st.Until.Sub(now))))
+}
+
+// clockTime renders t in local time, with the weekday when it is not today
+// and the date when it is a week or more away.
+func clockTime(t, now time.Time) string {
+	t, now = t.Local(), now.Local()
+	y, mo, d := now.Date()
+	today := time.Date(y, mo, d, 0, 0, 0, 0, now.Location())
+	switch {
+	case t.Before(today.AddDate(0, 0, 1)):
+		return t.Format("15:04")
+	case t.Before(today.AddDate(0, 0, 7)):
+		return t.Format("Mon 15:04")
+	default:
+		return t.Format("Jan 2 15:04")
+	}
+}
+
+// countdown renders a wait in its two largest units, rounded up to the
+// minute, e.g. "2d 4h" or "1h 5m".
+func countdown(d time.Duration) string {
+	d = (d + time.Minute - 1).Truncate(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	mins := int(d/time.Minute) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
+
+// SetMessage shows a transient notice in place of the range selector.
+// Pass an empty string to clear it.
+// SetFocus names the pane with the keyboard focus and its main keys,
+// shown in place of the general hints.
+func (m *Model) SetFocus(pane, keys string) {
+	m.focus = pane
+	m.focusKeys = keys
+}
+
+func (m *Model) SetMessage(msg string) {
+	m.message = msg
+}
+
+func (m Model) Message() string {
+	return m.message
+}
+
 func (m Model) View() string {
 	if m.width == 0 {
 		return ""
 	}
 
 	base := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#AAAAAA")).
-		Background(lipgloss.Color("#1a1a2e"))
+		Foreground(styles.ColorSubtext).
+		Background(styles.ColorSurface)
 
 	accent := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7D56F4")).
-		Background(lipgloss.Color("#1a1a2e")).
+		Foreground(styles.ColorPrimary).
+		Background(styles.ColorSurface).
 		Bold(true)
 
-	statusColor := lipgloss.Color("#04B575")
+	statusColor := styles.ColorSuccess
 	statusText := "●"
 	if !m.connected {
-		statusColor = lipgloss.Color("#FF4C4C")
+		statusColor = styles.ColorError
 		statusText = "○"
 	} else if m.err != nil {
-		statusColor = lipgloss.Color("#FF4C4C")
+		statusColor = styles.ColorError
 		statusText = "○"
 	}
 	statusStyle := base.Copy().Foreground(statusColor)
 
 	left := fmt.Sprintf(" %s %s ", statusStyle.Render(statusText), base.Render(m.provider))
+	if m.queued > 0 {
+		left += base.Foreground(styles.ColorWarning).Render(fmt.Sprintf("%d queued", m.queued)) + base.Render(" ")
+	}
+	if m.watchlist != "" {
+		left += base.Render("│ ") + accent.Render(m.watchlist) + base.Render(" ")
+	}
+	left += m.marketView(base)
 
-	timeRanges := []models.TimeRange{models.Range1H, models.Range24H, models.Range7D, models.Range30D}
+	timeRanges := models.Ranges
+	// Anchored and custom ranges are shown after the standard ones
+	current := m.timeRange.Base()
+	if _, ok := current.Anchor(); ok {
+		timeRanges = append(timeRanges[:len(timeRanges):len(timeRanges)], current)
+	}
 	var rangeStr string
 	for _, tr := range timeRanges {
-		if tr == m.timeRange {
-			rangeStr += accent.Render(fmt.Sprintf(" [%s] ", tr))
+		if tr == current {
+			rangeStr += accent.Render(fmt.Sprintf(" [%s] ", tr.Short()))
 		} else {
-			rangeStr += base.Render(fmt.Sprintf(" %s ", tr))
+			rangeStr += base.Render(fmt.Sprintf(" %s ", tr.Short()))
 		}
 	}
+	// A narrow bar shows only the current range
+	compact := accent.Render(fmt.Sprintf(" [%s] ", current.Short()))
+	if iv := m.timeRange.Interval(); iv != models.IntervalAuto {
+		ivStr := base.Render("· ") + accent.Render(string(iv)) + base.Render(" ")
+		rangeStr += ivStr
+		compact += ivStr
+	}
 
 	center := rangeStr
+	if m.message != "" {
+		center = lipgloss.NewStyle().
+			Foreground(styles.ColorWarning).
+			Background(styles.ColorSurface).
+			Bold(true).
+			Render(m.message)
+	}
 
 	timeStr := m.lastUpdate.Format("15:04:05")
 	if m.err != nil {
 		timeStr = "Error"
 	}
-	right := base.Render(fmt.Sprintf(" %s  / Search  s Sort  ? Help  q Quit ", timeStr))
+	hints := base.Render("/ Search  s Sort")
+	if m.focus != "" {
+		hints = accent.Render(m.focus) + base.Render(" "+m.focusKeys)
+	}
+	stale := base.Foreground(styles.ColorWarning).Render(m.staleView())
+	right := stale + base.Render(fmt.Sprintf(" %s  ", timeStr)) + hints + base.Render("  ? Help  q Quit ")
 
 	leftW := lipgloss.Width(left)
 	rightW := lipgloss.Width(right)
+	// A narrow bar keeps the time and help key, and the compact range
+	if leftW+rightW+lipgloss.Width(compact) > m.width {
+		right = stale + base.Render(fmt.Sprintf(" %s  ? Help ", timeStr))
+		rightW = lipgloss.Width(right)
+	}
 	centerW := m.width - leftW - rightW
 
 	if centerW < 0 {
 		centerW = 0
 	}
+	if m.message == "" && lipgloss.Width(center) > centerW {
+		center = compact
+	}
 
+	// Long notices are cut short rather than wrapping the bar
+	center = ansi.Truncate(center, centerW, "…")
 	centeredCenter := lipgloss.PlaceHorizontal(centerW, lipgloss.Center, center)
 
 	bar := lipgloss.NewStyle().
-		Background(lipgloss.Color("#1a1a2e")).
+		Background(styles.ColorSurface).
 		Width(m.width).
 		Render(left + centeredCenter + right)
 
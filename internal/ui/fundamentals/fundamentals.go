@@ -0,0 +1,180 @@
+package fundamentals
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// valueWidth caps the value column so figures stay next to their labels
+// in a wide tab.
+const valueWidth = 16
+
+// Model is the fundamentals list shown in the Details tab.
+type Model struct {
+	symbol  string
+	data    models.Fundamentals
+	loaded  bool
+	loading bool
+	err     error
+	note    string // the user's note on the symbol
+	visible bool
+	focused bool
+	width   int
+	height  int
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+func (m *Model) SetVisible(on bool) {
+	m.visible = on
+}
+
+// SetFocused highlights the pane's border while it has the keyboard focus.
+func (m *Model) SetFocused(on bool) {
+	m.focused = on
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// SetLoading switches the pane to symbol, keeping its figures until fresh
+// ones arrive when the symbol is unchanged.
+func (m *Model) SetLoading(symbol string) {
+	if symbol != m.symbol {
+		m.loaded = false
+	}
+	m.symbol = symbol
+	m.loading = true
+	m.err = nil
+}
+
+// SetData shows fundamentals for symbol; results for a symbol that is no
+// longer shown are ignored.
+func (m *Model) SetData(symbol string, f models.Fundamentals) {
+	if symbol != m.symbol {
+		return
+	}
+	m.data = f
+	m.loaded = true
+	m.loading = false
+	m.err = nil
+}
+
+// SetNote sets the note shown under the title, wrapped to the pane.
+func (m *Model) SetNote(text string) {
+	m.note = text
+}
+
+func (m *Model) SetError(symbol string, err error) {
+	if symbol != m.symbol {
+		return
+	}
+	m.err = err
+	m.loading = false
+}
+
+// compact abbreviates large figures, e.g. 2.41T or 53.2M.
+func compact(v float64) string {
+	switch {
+	case v >= 1e12:
+		return fmt.Sprintf("%.2fT", v/1e12)
+	case v >= 1e9:
+		return fmt.Sprintf("%.2fB", v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case v >= 1e3:
+		return fmt.Sprintf("%.1fK", v/1e3)
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+	titleS := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	valueS := lipgloss.NewStyle().Foreground(styles.ColorText)
+
+	var b strings.Builder
+	b.WriteString(titleS.Render("Details"))
+	if m.symbol != "" {
+		b.WriteString(dimS.Render("  " + m.symbol))
+	}
+	if m.note != "" {
+		b.WriteString("\n" + dimS.Italic(true).Width(max(1, m.width-4)).Render("✎ "+m.note) + "\n")
+	}
+
+	switch {
+	case m.err != nil && !m.loaded:
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+	case !m.loaded:
+		b.WriteString("\n" + dimS.Render("Loading details..."))
+	default:
+		f := m.data
+		price := func(v float64) string {
+			if v == 0 {
+				return "—"
+			}
+			return market.FormatPrice(v, f.Currency, "%.2f")
+		}
+		num := func(v float64, format string) string {
+			if v == 0 {
+				return "—"
+			}
+			return fmt.Sprintf(format, v)
+		}
+		big := func(v float64) string {
+			if v == 0 {
+				return "—"
+			}
+			return compact(v)
+		}
+		capStr := "—"
+		if f.MarketCap != 0 {
+			capStr = market.CurrencySymbol(f.Currency) + compact(f.MarketCap)
+		}
+		rows := [][2]string{
+			{"Market cap", capStr},
+			{"P/E", num(f.PE, "%.2f")},
+			{"EPS", price(f.EPS)},
+			{"52W high", price(f.High52)},
+			{"52W low", price(f.Low52)},
+			{"Div yield", num(f.DividendYield, "%.2f%%")},
+			{"Volume", big(f.Volume)},
+			{"Avg volume", big(f.AvgVolume)},
+		}
+		valueW := max(1, min(m.width-4-12, valueWidth))
+		for _, r := range rows {
+			b.WriteString("\n" + dimS.Render(fmt.Sprintf("%-12s", r[0])) + valueS.Render(fmt.Sprintf("%*s", valueW, r[1])))
+		}
+
+		// Past payouts fill what room is left, most recent first
+		room := m.height - strings.Count(b.String(), "\n") - 4
+		if len(f.Dividends) > 0 && room > 0 {
+			b.WriteString("\n\n" + titleS.Render("Dividends"))
+			b.WriteString("\n" + dimS.Render(fmt.Sprintf("%-12s%*s", "Ex-date", valueW, "Amount")))
+			for _, d := range f.Dividends[:min(len(f.Dividends), room)] {
+				amount := market.FormatPrice(d.Amount, f.Currency, "%.4f")
+				b.WriteString("\n" + dimS.Render(fmt.Sprintf("%-12s", d.ExDate.Local().Format("Jan 02 2006"))) +
+					valueS.Render(fmt.Sprintf("%*s", valueW, amount)))
+			}
+		}
+	}
+
+	return styles.PaneFor(m.focused).Width(m.width).Height(m.height).Render(b.String())
+}
@@ -0,0 +1,215 @@
+package heatmap
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Tile is one symbol in the heatmap.
+type Tile struct {
+	Symbol    string
+	Label     string
+	Price     float64
+	ChangePct float64
+	Quoted    bool
+}
+
+// fullScale is the daily move, in percent, drawn at full color.
+const fullScale = 3.0
+
+// Tile size limits in cells. Terminal cells are about twice as tall as
+// they are wide, so tiles aim for a 3:1 width to height ratio to look
+// roughly square-ish.
+const (
+	minTileW    = 12
+	minTileH    = 3
+	tileAspect  = 3.0
+	headerLines = 2
+)
+
+type Model struct {
+	tiles   []Tile
+	toggle  key.Binding
+	visible bool
+	width   int
+	height  int
+}
+
+// New builds the heatmap view; toggle also closes it.
+func New(toggle key.Binding) Model {
+	return Model{toggle: toggle}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, m.toggle) || msg.String() == "esc" || msg.String() == "q" {
+			m.visible = false
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+func (m *Model) Toggle() {
+	m.visible = !m.visible
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// SetTiles replaces the symbols shown, in display order.
+func (m *Model) SetTiles(tiles []Tile) {
+	m.tiles = tiles
+}
+
+// grid picks the column count whose tiles come closest to the target
+// aspect ratio while fitting every symbol, and returns columns and rows.
+func grid(n, w, h int) (int, int) {
+	// Without a good fit, use as many columns as fit and clip the rows
+	best, bestScore := max(1, min(n, w/minTileW)), math.Inf(1)
+	for cols := 1; cols <= n; cols++ {
+		rows := (n + cols - 1) / cols
+		tw, th := w/cols, h/rows
+		if tw < minTileW {
+			break
+		}
+		if th < minTileH {
+			continue
+		}
+		score := math.Abs(math.Log(float64(tw) / float64(th) / tileAspect))
+		if score < bestScore {
+			best, bestScore = cols, score
+		}
+	}
+	return best, (n + best - 1) / best
+}
+
+// tint blends from the neutral tile color toward the gain or loss color as
+// the move approaches fullScale. It returns the blend factor too.
+func tint(pct float64) (lipgloss.Color, float64) {
+	t := math.Min(math.Abs(pct)/fullScale, 1)
+	target := styles.ColorSuccess
+	if pct < 0 {
+		target = styles.ColorError
+	}
+	return blend(styles.ColorHighlight, target, t), t
+}
+
+// blend mixes two hex colors. Colors that are not #RRGGBB (ANSI numbers
+// from a theme override) snap to whichever end is nearer.
+func blend(a, b lipgloss.Color, t float64) lipgloss.Color {
+	ar, ag, ab, okA := rgb(a)
+	br, bg, bb, okB := rgb(b)
+	if !okA || !okB {
+		if t < 0.5 {
+			return a
+		}
+		return b
+	}
+	mix := func(x, y int) int { return x + int(math.Round(float64(y-x)*t)) }
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", mix(ar, br), mix(ag, bg), mix(ab, bb)))
+}
+
+func rgb(c lipgloss.Color) (int, int, int, bool) {
+	s := strings.TrimPrefix(string(c), "#")
+	if len(s) != 6 {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(v >> 16), int(v >> 8 & 0xFF), int(v & 0xFF), true
+}
+
+func (m Model) renderTile(t Tile, w, h int) string {
+	bg, strength := styles.ColorHighlight, 0.0
+	if t.Quoted {
+		bg, strength = tint(t.ChangePct)
+	}
+	fg := styles.ColorText
+	if strength >= 0.6 {
+		fg = styles.ColorInverse
+	}
+
+	label := []rune(t.Label)
+	if len(label) > w-2 {
+		label = append(label[:max(0, w-3)], '…')
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(string(label))}
+	if t.Quoted {
+		change := fmt.Sprintf("%+.2f%%", t.ChangePct)
+		price := fmt.Sprintf("%.2f", t.Price)
+		if market.IsYield(t.Symbol) {
+			change = market.FormatBP(market.YieldChangeBP(t.Price, t.ChangePct))
+			price = market.FormatYield(t.Price)
+		}
+		lines = append(lines, change)
+		if h >= 4 {
+			lines = append(lines, price)
+		}
+	} else {
+		lines = append(lines, "—")
+	}
+
+	return lipgloss.NewStyle().
+		Width(w-1).
+		Height(h).
+		MarginRight(1).
+		Background(bg).
+		Foreground(fg).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleS := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	header := titleS.Render("Heatmap") + dimS.Render(fmt.Sprintf("  daily %% change, full color at ±%.0f%%  •  esc to close", fullScale))
+
+	if len(m.tiles) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, "No symbols")
+	}
+
+	w, h := m.width, m.height-headerLines
+	cols, rows := grid(len(m.tiles), w, h)
+	tileW, tileH := w/cols, max(minTileH, h/rows)
+
+	// Each tile gives up its last row and column as a gutter
+	var out []string
+	for r := 0; r < rows; r++ {
+		var row []string
+		for c := 0; c < cols; c++ {
+			i := r*cols + c
+			if i >= len(m.tiles) {
+				break
+			}
+			row = append(row, m.renderTile(m.tiles[i], tileW, tileH-1))
+		}
+		out = append(out, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	body := strings.Join(out, "\n\n")
+
+	view := header + "\n\n" + lipgloss.NewStyle().MaxWidth(m.width).MaxHeight(h).Render(body)
+	return lipgloss.NewStyle().Width(m.width).Height(m.height).Render(view)
+}
@@ -1,112 +1,290 @@
 package help
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/keys"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
 )
 
-type Binding struct {
-	Key  string
-	Desc string
+// Model is the help overlay: a page of bindings per part of the app, or
+// the bindings on every page matching a search.
+type Model struct {
+	pages   []keys.HelpPage
+	page    int
+	offset  int
+	toggle  key.Binding
+	input   textinput.Model
+	search  bool // typing into the search field
+	visible bool
+	width   int
+	height  int
 }
 
-type Model struct {
-	bindings []Binding
-	visible  bool
-	width    int
-	height   int
+// Column widths of a row, besides its description.
+const (
+	keyWidth  = 15
+	pageWidth = 10
+)
+
+// row is a binding as listed, with the page it is from for search results.
+type row struct {
+	page string
+	b    key.Binding
 }
 
-func New() Model {
-	return Model{
-		bindings: []Binding{
-			{"j/↓", "Move down"},
-			{"k/↑", "Move up"},
-			{"/", "Search symbols"},
-			{"s", "Cycle sort (Name/Price/%)"},
-			{"S", "Toggle sort direction"},
-			{"Tab", "Cycle time range"},
-			{"1-4", "Select time range"},
-			{"c", "Cycle chart type"},
-			{"r", "Refresh data"},
-			{"?", "Toggle help"},
-			{"q", "Quit"},
-		},
-	}
+// New builds the help overlay from the active key bindings' pages; toggle
+// also closes it.
+func New(pages []keys.HelpPage, toggle key.Binding) Model {
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	ti.Placeholder = "search keys and actions"
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(styles.ColorText).Bold(true)
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	ti.CharLimit = 40
+	return Model{pages: pages, toggle: toggle, input: ti}
 }
 
 func (m Model) Init() tea.Cmd { return nil }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if msg.String() == "?" || msg.String() == "esc" || msg.String() == "q" {
+	km, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.search {
+		switch km.String() {
+		case "esc":
+			m.search = false
+			m.input.Blur()
+			m.input.SetValue("")
+			m.offset = 0
+		case "enter":
+			m.search = false
+			m.input.Blur()
+		case "up", "down":
+			m.scroll(km.String())
+		default:
+			before := m.input.Value()
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			if m.input.Value() != before {
+				m.offset = 0
+			}
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	switch s := km.String(); {
+	case key.Matches(km, m.toggle) || s == "q":
+		m.visible = false
+	case s == "esc":
+		// Esc clears a search before it closes the overlay
+		if m.input.Value() != "" {
+			m.input.SetValue("")
+			m.offset = 0
+		} else {
 			m.visible = false
 		}
+	case s == "/":
+		m.search = true
+		return m, m.input.Focus()
+	case s == "l" || s == "right" || s == "tab":
+		m.turn(1)
+	case s == "h" || s == "left" || s == "shift+tab":
+		m.turn(-1)
+	default:
+		m.scroll(s)
 	}
 	return m, nil
 }
 
+// turn moves to the next or previous page, leaving any search.
+func (m *Model) turn(delta int) {
+	if len(m.pages) == 0 {
+		return
+	}
+	m.input.SetValue("")
+	m.page = (m.page + delta + len(m.pages)) % len(m.pages)
+	m.offset = 0
+}
+
+// scroll moves the list for the key s, if it is a scrolling key.
+func (m *Model) scroll(s string) {
+	last := max(0, len(m.rows())-m.pageRows())
+	switch s {
+	case "j", "down":
+		m.offset = min(m.offset+1, last)
+	case "k", "up":
+		m.offset = max(m.offset-1, 0)
+	case "pgdown", "ctrl+d", " ":
+		m.offset = min(m.offset+m.pageRows(), last)
+	case "pgup", "ctrl+u":
+		m.offset = max(m.offset-m.pageRows(), 0)
+	case "g", "home":
+		m.offset = 0
+	case "G", "end":
+		m.offset = last
+	}
+}
+
 func (m *Model) SetSize(w, h int) {
 	m.width = w
 	m.height = h
+	m.input.Width = min(40, max(10, w-24))
 }
 
-func (m *Model) Toggle() {
-	m.visible = !m.visible
-}
-
-func (m *Model) Show() {
+// Open shows the overlay on the page titled page, or the first, with no
+// search.
+func (m *Model) Open(page string) {
+	m.page = 0
+	for i, p := range m.pages {
+		if p.Title == page {
+			m.page = i
+		}
+	}
+	m.offset = 0
+	m.search = false
+	m.input.Blur()
+	m.input.SetValue("")
 	m.visible = true
 }
 
 func (m *Model) Hide() {
 	m.visible = false
+	m.search = false
+	m.input.Blur()
 }
 
 func (m Model) Visible() bool {
 	return m.visible
 }
 
+// rows lists the current page, or every binding matching the search.
+// Each word of the search must appear in the keys or the description.
+func (m Model) rows() []row {
+	words := strings.Fields(strings.ToLower(m.input.Value()))
+	var out []row
+	if len(words) == 0 {
+		if m.page < len(m.pages) {
+			for _, b := range m.pages[m.page].Bindings {
+				out = append(out, row{b: b})
+			}
+		}
+		return out
+	}
+	for _, p := range m.pages {
+	next:
+		for _, b := range p.Bindings {
+			text := strings.ToLower(b.Help().Key + " " + b.Help().Desc)
+			for _, w := range words {
+				if !strings.Contains(text, w) {
+					continue next
+				}
+			}
+			out = append(out, row{page: p.Title, b: b})
+		}
+	}
+	return out
+}
+
+// pageRows is the number of bindings that fit in the overlay.
+func (m Model) pageRows() int {
+	return max(3, m.height-16)
+}
+
 func (m Model) View() string {
 	if !m.visible {
 		return ""
 	}
 
 	keyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7D56F4")).
+		Foreground(styles.ColorPrimary).
 		Bold(true).
-		Width(10)
+		Width(keyWidth)
 
 	descStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#CCCCCC"))
+		Foreground(styles.ColorText)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7D56F4")).
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	activeStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
 		Bold(true).
-		MarginBottom(1)
+		Underline(true)
 
 	var sb strings.Builder
 	sb.WriteString(titleStyle.Render("Keyboard Shortcuts"))
 	sb.WriteString("\n\n")
 
-	for _, b := range m.bindings {
-		sb.WriteString(keyStyle.Render(b.Key))
-		sb.WriteString(descStyle.Render(b.Desc))
+	searching := m.input.Value() != ""
+	var tabs []string
+	for i, p := range m.pages {
+		if i == m.page && !searching {
+			tabs = append(tabs, activeStyle.Render(p.Title))
+		} else {
+			tabs = append(tabs, dimStyle.Render(p.Title))
+		}
+	}
+	sb.WriteString(strings.Join(tabs, dimStyle.Render("  ")))
+	if m.search || searching {
+		sb.WriteString("\n\n")
+		sb.WriteString(m.input.View())
+	}
+	sb.WriteString("\n\n")
+
+	rows := m.rows()
+	if len(rows) == 0 {
+		sb.WriteString(dimStyle.Render("No matching keys"))
+		sb.WriteString("\n")
+	}
+	// Search results name their page in a column on the right
+	width := min(72, max(40, m.width-4))
+	descW := width - 6 - keyWidth - pageWidth
+	end := min(len(rows), m.offset+m.pageRows())
+	for _, r := range rows[m.offset:end] {
+		sb.WriteString(keyStyle.Render(r.b.Help().Key))
+		if r.page != "" {
+			sb.WriteString(descStyle.Width(descW).MaxWidth(descW).Render(r.b.Help().Desc))
+			sb.WriteString(dimStyle.Width(pageWidth).Align(lipgloss.Right).Render(r.page))
+		} else {
+			sb.WriteString(descStyle.Render(r.b.Help().Desc))
+		}
 		sb.WriteString("\n")
 	}
 
-	content := sb.String()
+	sb.WriteString("\n")
+	var hint []string
+	if len(rows) > m.pageRows() {
+		hint = append(hint, fmt.Sprintf("%d–%d of %d, j/k to scroll", m.offset+1, end, len(rows)))
+	}
+	if m.search {
+		hint = append(hint, "Enter to keep, Esc to clear")
+	} else {
+		hint = append(hint, "h/l page", "/ search", "Esc to close")
+	}
+	sb.WriteString(dimStyle.Render(strings.Join(hint, " · ")))
 
 	modalStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7D56F4")).
+		BorderForeground(styles.ColorPrimary).
 		Padding(1, 2).
-		Background(lipgloss.Color("#1a1a2e"))
+		Width(width).
+		Background(styles.ColorSurface)
 
-	modal := modalStyle.Render(content)
+	modal := modalStyle.Render(sb.String())
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
 }
@@ -0,0 +1,257 @@
+package lookup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// debounce is how long typing must pause before a lookup is sent.
+const debounce = 300 * time.Millisecond
+
+// QueryMsg asks the app to run a lookup. It is sent once typing settles;
+// the app should drop it unless Pending reports it is still current.
+type QueryMsg struct {
+	Seq   int
+	Query string
+}
+
+// SelectMsg is emitted when the user picks a result.
+type SelectMsg struct {
+	Match models.SymbolMatch
+}
+
+// Model is a modal that searches a provider's symbol lookup as the user
+// types. Results from the last lookup are filtered locally by the current
+// input while the next one is in flight.
+type Model struct {
+	input   textinput.Model
+	seq     int
+	query   string // query the results belong to
+	results []models.SymbolMatch
+	loading bool
+	err     error
+	cursor  int
+	visible bool
+	width   int
+	height  int
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "symbol or company name"
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(styles.ColorText).Bold(true)
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	ti.CharLimit = 40
+	ti.Width = 30
+	return Model{input: ti}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+// SetReducedMotion keeps the input cursor from blinking.
+func (m *Model) SetReducedMotion(on bool) {
+	mode := cursor.CursorBlink
+	if on {
+		mode = cursor.CursorStatic
+	}
+	m.input.Cursor.SetMode(mode)
+}
+
+// Open shows the modal with an empty query.
+func (m *Model) Open() tea.Cmd {
+	m.input.SetValue("")
+	m.query = ""
+	m.results = nil
+	m.loading = false
+	m.err = nil
+	m.cursor = 0
+	m.visible = true
+	return m.input.Focus()
+}
+
+// Pending reports whether msg is the latest query, i.e. the user has not
+// typed since it was scheduled.
+func (m Model) Pending(msg QueryMsg) bool {
+	return m.visible && msg.Seq == m.seq
+}
+
+// SetResults shows the matches for query. Results for a query that is no
+// longer in the input are ignored.
+func (m *Model) SetResults(query string, matches []models.SymbolMatch, err error) {
+	if query != strings.TrimSpace(m.input.Value()) {
+		return
+	}
+	m.loading = false
+	m.query = query
+	m.results = matches
+	m.err = err
+	m.cursor = 0
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.visible = false
+			m.input.Blur()
+			return m, nil
+		case "up", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.shown())-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			shown := m.shown()
+			if len(shown) == 0 {
+				return m, nil
+			}
+			m.visible = false
+			m.input.Blur()
+			match := shown[m.cursor]
+			return m, func() tea.Msg { return SelectMsg{Match: match} }
+		}
+	}
+
+	before := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() == before {
+		return m, cmd
+	}
+
+	m.seq++
+	m.cursor = 0
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		m.loading = false
+		m.query = ""
+		m.results = nil
+		m.err = nil
+		return m, cmd
+	}
+	m.loading = true
+	q := QueryMsg{Seq: m.seq, Query: query}
+	return m, tea.Batch(cmd, tea.Tick(debounce, func(time.Time) tea.Msg { return q }))
+}
+
+// shown returns the results to list: all of them when they belong to the
+// current input, otherwise those still matching what has been typed.
+func (m Model) shown() []models.SymbolMatch {
+	query := strings.TrimSpace(m.input.Value())
+	if query == m.query {
+		return m.results
+	}
+	q := strings.ToLower(query)
+	var out []models.SymbolMatch
+	for _, r := range m.results {
+		if strings.Contains(strings.ToLower(r.Symbol+" "+r.Name+" "+r.Exchange), q) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.input.Width = min(40, max(10, w-20))
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext).
+		Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Add symbol"))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.input.View())
+	sb.WriteString("\n\n")
+
+	shown := m.shown()
+	rowW := min(60, max(30, m.width-12))
+	switch {
+	case m.err != nil && len(shown) == 0:
+		sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorError).Render("Lookup failed: " + m.err.Error()))
+		sb.WriteString("\n")
+	case len(shown) == 0 && m.loading:
+		sb.WriteString(hintStyle.Render("Searching…"))
+		sb.WriteString("\n")
+	case len(shown) == 0 && strings.TrimSpace(m.input.Value()) != "":
+		sb.WriteString(hintStyle.Render("No matches"))
+		sb.WriteString("\n")
+	}
+
+	// Keep the cursor visible when there are more results than rows
+	rows := max(1, m.height-14)
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	end := min(len(shown), start+rows)
+	for i := start; i < end; i++ {
+		row := formatMatch(shown[i], rowW)
+		if i == m.cursor {
+			sb.WriteString(styles.SelectedItem.Render("› " + row))
+		} else {
+			sb.WriteString(styles.ListItem.Render("  " + row))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("↑/↓ move • Enter add to watchlist • Esc cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
+
+// formatMatch lays out a result as symbol, name and exchange columns in w
+// cells.
+func formatMatch(r models.SymbolMatch, w int) string {
+	const symW, exchW = 10, 12
+	nameW := max(8, w-symW-exchW-2)
+	return fmt.Sprintf("%-*s %-*s %*s", symW, truncate(r.Symbol, symW), nameW, truncate(r.Name, nameW), exchW, truncate(r.Exchange, exchW))
+}
+
+func truncate(s string, w int) string {
+	r := []rune(s)
+	if len(r) <= w {
+		return s
+	}
+	return string(r[:w-1]) + "…"
+}
@@ -5,14 +5,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
 )
 
-var overlayStyle = lipgloss.NewStyle().
-	Border(lipgloss.RoundedBorder()).
-	BorderForeground(lipgloss.Color("#7D56F4")).
-	Padding(1, 2).
-	Background(lipgloss.Color("#1a1a2e"))
-
 type Model struct {
 	title   string
 	content string
@@ -72,11 +67,18 @@ func (m Model) View() string {
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#7D56F4")).
+		Foreground(styles.ColorPrimary).
 		MarginBottom(1)
 
 	content := titleStyle.Render(m.title) + "\n\n" + m.content
 
+	// Built per render so it follows the active theme
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
 	modal := overlayStyle.
 		Width(modalWidth).
 		Height(modalHeight).
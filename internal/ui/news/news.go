@@ -0,0 +1,180 @@
+package news
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Model is the headlines list shown in the News tab.
+type Model struct {
+	symbol    string
+	headlines []models.Headline
+	cursor    int
+	offset    int // first visible headline
+	loading   bool
+	err       error
+	visible   bool
+	focused   bool
+	width     int
+	height    int
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.clampOffset()
+}
+
+func (m *Model) SetVisible(on bool) {
+	m.visible = on
+}
+
+// SetFocused highlights the pane's border while it has the keyboard focus.
+func (m *Model) SetFocused(on bool) {
+	m.focused = on
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// Symbol returns the symbol the pane is showing headlines for.
+func (m Model) Symbol() string {
+	return m.symbol
+}
+
+// SetLoading switches the pane to symbol, keeping its headlines until
+// fresh ones arrive when the symbol is unchanged.
+func (m *Model) SetLoading(symbol string) {
+	if symbol != m.symbol {
+		m.headlines = nil
+		m.cursor, m.offset = 0, 0
+	}
+	m.symbol = symbol
+	m.loading = true
+	m.err = nil
+}
+
+// SetHeadlines shows headlines for symbol; results for a symbol that is no
+// longer shown are ignored.
+func (m *Model) SetHeadlines(symbol string, headlines []models.Headline) {
+	if symbol != m.symbol {
+		return
+	}
+	m.headlines = headlines
+	m.cursor = min(m.cursor, max(0, len(headlines)-1))
+	m.loading = false
+	m.err = nil
+	m.clampOffset()
+}
+
+func (m *Model) SetError(symbol string, err error) {
+	if symbol != m.symbol {
+		return
+	}
+	m.err = err
+	m.loading = false
+}
+
+// Scroll moves the highlighted headline by delta.
+func (m *Model) Scroll(delta int) {
+	if len(m.headlines) == 0 {
+		return
+	}
+	m.cursor = max(0, min(m.cursor+delta, len(m.headlines)-1))
+	m.clampOffset()
+}
+
+// Selected returns the highlighted headline.
+func (m Model) Selected() (models.Headline, bool) {
+	if m.cursor < len(m.headlines) {
+		return m.headlines[m.cursor], true
+	}
+	return models.Headline{}, false
+}
+
+// rows is the number of headlines that fit below the title.
+func (m Model) rows() int {
+	return max(1, m.height-2)
+}
+
+func (m *Model) clampOffset() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.rows() {
+		m.offset = m.cursor - m.rows() + 1
+	}
+}
+
+// age renders how long ago a headline was published.
+func age(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", max(0, int(d.Minutes())))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return t.Format("Jan 02")
+	}
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+	titleS := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+
+	var b strings.Builder
+	b.WriteString(titleS.Render("News"))
+	if m.symbol != "" {
+		b.WriteString(dimS.Render("  " + m.symbol))
+	}
+	b.WriteString(dimS.Render("  j/k scroll • o open"))
+	b.WriteString("\n")
+
+	innerW := m.width - 4
+	switch {
+	case m.err != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+	case len(m.headlines) == 0 && m.loading:
+		b.WriteString(dimS.Render("Loading headlines..."))
+	case len(m.headlines) == 0:
+		b.WriteString(dimS.Render("No recent headlines"))
+	default:
+		end := min(len(m.headlines), m.offset+m.rows())
+		for i := m.offset; i < end; i++ {
+			h := m.headlines[i]
+			when := fmt.Sprintf("%-7s", age(h.Published))
+			pub := ""
+			if h.Publisher != "" {
+				pub = "  " + h.Publisher
+			}
+			title := []rune(h.Title)
+			if room := innerW - 2 - len([]rune(when)) - len([]rune(pub)); room > 0 && len(title) > room {
+				title = append(title[:max(0, room-1)], '…')
+			}
+			if i == m.cursor {
+				b.WriteString(styles.SelectedItem.Render(when + string(title) + pub))
+			} else {
+				b.WriteString(" " + dimS.Render(when) + lipgloss.NewStyle().Foreground(styles.ColorText).Render(string(title)) + dimS.Render(pub) + " ")
+			}
+			if i < end-1 {
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	return styles.PaneFor(m.focused).Width(m.width).Height(m.height).Render(b.String())
+}
@@ -0,0 +1,132 @@
+package note
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// maxNote bounds a note, which is meant as a reminder rather than a
+// journal.
+const maxNote = 1000
+
+// SubmitMsg is emitted when the user saves a note. An empty Text deletes
+// the symbol's note.
+type SubmitMsg struct {
+	Symbol string
+	Text   string
+}
+
+// Model is a multi-line modal for editing a symbol's note.
+type Model struct {
+	symbol  string
+	title   string
+	input   textarea.Model
+	visible bool
+	width   int
+	height  int
+}
+
+func New() Model {
+	ta := textarea.New()
+	ta.Placeholder = "Why are you watching this?"
+	ta.ShowLineNumbers = false
+	ta.Prompt = ""
+	ta.CharLimit = maxNote
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	ta.FocusedStyle.Text = lipgloss.NewStyle().Foreground(styles.ColorText)
+	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+	ta.Cursor.Style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	ta.SetWidth(40)
+	ta.SetHeight(5)
+	return Model{input: ta}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+// SetReducedMotion keeps the cursor from blinking.
+func (m *Model) SetReducedMotion(on bool) {
+	mode := cursor.CursorBlink
+	if on {
+		mode = cursor.CursorStatic
+	}
+	m.input.Cursor.SetMode(mode)
+}
+
+// Open shows the editor for symbol's note, filled with its current text.
+func (m *Model) Open(symbol, title, text string) tea.Cmd {
+	m.symbol = symbol
+	m.title = title
+	m.input.Reset()
+	m.input.SetValue(text)
+	m.visible = true
+	return m.input.Focus()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.visible = false
+			m.input.Blur()
+			return m, nil
+		case "ctrl+s":
+			m.visible = false
+			m.input.Blur()
+			sub := SubmitMsg{Symbol: m.symbol, Text: strings.TrimSpace(m.input.Value())}
+			return m, func() tea.Msg { return sub }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.input.SetWidth(min(60, max(20, w-16)))
+	m.input.SetHeight(min(8, max(3, h-14)))
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext).
+		Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(m.title))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.input.View())
+	sb.WriteString("\n\n")
+	sb.WriteString(hintStyle.Render("Ctrl+S to save • Esc to cancel • empty to delete"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
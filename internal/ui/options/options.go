@@ -0,0 +1,327 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Column widths of the chain table.
+const (
+	colWidth    = 9
+	strikeWidth = 11
+)
+
+// columns are the figures shown per side, most useful first; narrow panes
+// drop them from the end.
+var columns = []struct {
+	title string
+	value func(models.OptionContract) string
+}{
+	{"Last", func(c models.OptionContract) string { return price(c.Last) }},
+	{"IV", func(c models.OptionContract) string {
+		if c.ImpliedVol == 0 {
+			return "—"
+		}
+		return fmt.Sprintf("%.1f%%", c.ImpliedVol*100)
+	}},
+	{"Bid", func(c models.OptionContract) string { return price(c.Bid) }},
+	{"Ask", func(c models.OptionContract) string { return price(c.Ask) }},
+	{"Volume", func(c models.OptionContract) string { return count(c.Volume) }},
+	{"Open int", func(c models.OptionContract) string { return count(c.OpenInterest) }},
+}
+
+func price(v float64) string {
+	if v == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func count(v float64) string {
+	switch {
+	case v == 0:
+		return "—"
+	case v >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case v >= 1e4:
+		return fmt.Sprintf("%.1fK", v/1e3)
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
+
+// Model is the option chain shown in the Options tab: calls on the left,
+// puts on the right, one row per strike.
+type Model struct {
+	symbol  string
+	expiry  time.Time // expiry asked for; zero for the nearest
+	chain   models.OptionChain
+	loaded  bool
+	cursor  int
+	offset  int // first visible strike
+	loading bool
+	err     error
+	visible bool
+	focused bool
+	width   int
+	height  int
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.clampOffset()
+}
+
+func (m *Model) SetVisible(on bool) {
+	m.visible = on
+}
+
+// SetFocused highlights the pane's border while it has the keyboard focus.
+func (m *Model) SetFocused(on bool) {
+	m.focused = on
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// SetLoading switches the pane to symbol's chain for expiry, keeping the
+// chain shown until a fresh one arrives when neither has changed.
+func (m *Model) SetLoading(symbol string, expiry time.Time) {
+	if symbol != m.symbol || !expiry.Equal(m.expiry) {
+		m.loaded = false
+		m.cursor, m.offset = 0, 0
+	}
+	m.symbol = symbol
+	m.expiry = expiry
+	m.loading = true
+	m.err = nil
+}
+
+// ExpiryFor returns the expiry shown for symbol, or zero (the nearest)
+// when the pane is on another symbol.
+func (m Model) ExpiryFor(symbol string) time.Time {
+	if symbol != m.symbol {
+		return time.Time{}
+	}
+	return m.expiry
+}
+
+// StepExpiry returns the listed expiry delta away from the one shown, or
+// false past either end or before a chain has loaded.
+func (m Model) StepExpiry(delta int) (time.Time, bool) {
+	if !m.loaded {
+		return time.Time{}, false
+	}
+	i := m.expiryIndex() + delta
+	if i < 0 || i >= len(m.chain.Expiries) {
+		return time.Time{}, false
+	}
+	return m.chain.Expiries[i], true
+}
+
+// expiryIndex is the shown expiry's position among the listed ones.
+func (m Model) expiryIndex() int {
+	for i, e := range m.chain.Expiries {
+		if e.Equal(m.chain.Expiry) {
+			return i
+		}
+	}
+	return 0
+}
+
+// SetChain shows chain for symbol and expiry; results for a symbol or
+// expiry that is no longer shown are ignored. A new chain starts at the
+// strike nearest the money.
+func (m *Model) SetChain(symbol string, expiry time.Time, chain models.OptionChain) {
+	if symbol != m.symbol || !expiry.Equal(m.expiry) {
+		return
+	}
+	if !m.loaded {
+		m.cursor = nearest(chain)
+		m.offset = max(0, m.cursor-m.rows()/2)
+	}
+	m.chain = chain
+	m.cursor = min(m.cursor, max(0, len(m.strikes())-1))
+	m.loaded = true
+	m.loading = false
+	m.err = nil
+	m.clampOffset()
+}
+
+func (m *Model) SetError(symbol string, expiry time.Time, err error) {
+	if symbol != m.symbol || !expiry.Equal(m.expiry) {
+		return
+	}
+	m.err = err
+	m.loading = false
+}
+
+// Scroll moves the highlighted strike by delta.
+func (m *Model) Scroll(delta int) {
+	n := len(m.strikes())
+	if n == 0 {
+		return
+	}
+	m.cursor = max(0, min(m.cursor+delta, n-1))
+	m.clampOffset()
+}
+
+// strikes returns every strike listed on either side, ascending.
+func (m Model) strikes() []float64 {
+	var out []float64
+	i, j := 0, 0
+	calls, puts := m.chain.Calls, m.chain.Puts
+	for i < len(calls) || j < len(puts) {
+		switch {
+		case j >= len(puts) || (i < len(calls) && calls[i].Strike < puts[j].Strike):
+			out = append(out, calls[i].Strike)
+			i++
+		case i >= len(calls) || puts[j].Strike < calls[i].Strike:
+			out = append(out, puts[j].Strike)
+			j++
+		default:
+			out = append(out, calls[i].Strike)
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// nearest returns the row of the strike closest to the underlying.
+func nearest(chain models.OptionChain) int {
+	m := Model{chain: chain}
+	best, dist := 0, math.Inf(1)
+	for i, k := range m.strikes() {
+		if d := math.Abs(k - chain.Underlying); d < dist {
+			best, dist = i, d
+		}
+	}
+	return best
+}
+
+// rows is the number of strikes that fit below the title and headers.
+func (m Model) rows() int {
+	return max(1, m.height-3)
+}
+
+func (m *Model) clampOffset() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.rows() {
+		m.offset = m.cursor - m.rows() + 1
+	}
+}
+
+func find(side []models.OptionContract, strike float64) (models.OptionContract, bool) {
+	for _, c := range side {
+		if c.Strike == strike {
+			return c, true
+		}
+	}
+	return models.OptionContract{}, false
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+	titleS := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	textS := lipgloss.NewStyle().Foreground(styles.ColorText)
+	strikeS := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+
+	var b strings.Builder
+	b.WriteString(titleS.Render("Options"))
+	if m.symbol != "" {
+		b.WriteString(dimS.Render("  " + m.symbol))
+	}
+	if m.loaded {
+		exp := "  exp " + m.chain.Expiry.Format("Jan 02 2006")
+		if n := len(m.chain.Expiries); n > 1 {
+			exp += fmt.Sprintf(" (%d of %d)", m.expiryIndex()+1, n)
+		}
+		b.WriteString(dimS.Render(exp))
+	}
+	b.WriteString(dimS.Render("  j/k scroll  [/] expiry"))
+
+	innerW := m.width - 4
+	switch {
+	case m.err != nil && !m.loaded:
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+	case !m.loaded:
+		b.WriteString("\n" + dimS.Render("Loading option chain..."))
+	default:
+		cols := columns[:max(1, min(len(columns), (innerW-2-strikeWidth)/(2*colWidth)))]
+		side := func(c models.OptionContract, ok bool, reverse bool) []string {
+			cells := make([]string, len(cols))
+			for i, col := range cols {
+				v := ""
+				if ok {
+					v = col.value(c)
+				}
+				cells[i] = fmt.Sprintf("%*s", colWidth, v)
+			}
+			if reverse {
+				for i, j := 0, len(cells)-1; i < j; i, j = i+1, j-1 {
+					cells[i], cells[j] = cells[j], cells[i]
+				}
+			}
+			return cells
+		}
+
+		// Calls read outward from the strike, mirroring the puts
+		var head []string
+		for i := len(cols) - 1; i >= 0; i-- {
+			head = append(head, fmt.Sprintf("%*s", colWidth, cols[i].title))
+		}
+		callsLabel := fmt.Sprintf("%-*s", len(cols)*colWidth, "Calls")
+		putsLabel := fmt.Sprintf("%*s", len(cols)*colWidth, "Puts")
+		b.WriteString("\n " + dimS.Render(callsLabel+strings.Repeat(" ", strikeWidth)+putsLabel))
+		putHead := make([]string, len(cols))
+		for i, col := range cols {
+			putHead[i] = fmt.Sprintf("%*s", colWidth, col.title)
+		}
+		b.WriteString("\n " + dimS.Render(strings.Join(head, "")+fmt.Sprintf("%*s ", strikeWidth-1, "Strike")+strings.Join(putHead, "")))
+
+		strikes := m.strikes()
+		end := min(len(strikes), m.offset+m.rows())
+		for i := m.offset; i < end; i++ {
+			k := strikes[i]
+			call, okCall := find(m.chain.Calls, k)
+			put, okPut := find(m.chain.Puts, k)
+			calls := strings.Join(side(call, okCall, true), "")
+			puts := strings.Join(side(put, okPut, false), "")
+			strike := fmt.Sprintf("%*s ", strikeWidth-1, fmt.Sprintf("%.2f", k))
+			b.WriteString("\n")
+			if i == m.cursor {
+				b.WriteString(styles.SelectedItem.Render(calls + strike + puts))
+				continue
+			}
+			// In-the-money contracts are bright, the rest dimmed
+			callS, putS := dimS, dimS
+			if call.InTheMoney {
+				callS = textS
+			}
+			if put.InTheMoney {
+				putS = textS
+			}
+			b.WriteString(" " + callS.Render(calls) + strikeS.Render(strike) + putS.Render(puts) + " ")
+		}
+	}
+
+	return styles.PaneFor(m.focused).Width(m.width).Height(m.height).Render(b.String())
+}
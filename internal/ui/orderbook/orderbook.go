@@ -0,0 +1,167 @@
+package orderbook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Column widths of a side, besides its depth bar.
+const (
+	priceWidth = 12
+	sizeWidth  = 10
+)
+
+// Model is the order book pane under the chart: bids on the left, asks on
+// the right, each level with a bar of the depth up to it.
+type Model struct {
+	symbol string
+	book   models.OrderBook
+	loaded bool
+	err    error
+	width  int
+	height int
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Levels is the number of levels a side shows at the current height.
+func (m Model) Levels() int {
+	return max(1, m.height-2)
+}
+
+// SetSymbol switches the pane to symbol, clearing the last book when the
+// symbol changes.
+func (m *Model) SetSymbol(symbol string) {
+	if symbol != m.symbol {
+		m.book = models.OrderBook{}
+		m.loaded = false
+	}
+	m.symbol = symbol
+	m.err = nil
+}
+
+// SetBook shows book for symbol; books for a symbol no longer shown are
+// ignored.
+func (m *Model) SetBook(symbol string, book models.OrderBook) {
+	if symbol != m.symbol {
+		return
+	}
+	m.book = book
+	m.loaded = true
+	m.err = nil
+}
+
+// SetError shows err for symbol. A book already shown stays up, so one
+// failed poll does not blank the pane.
+func (m *Model) SetError(symbol string, err error) {
+	if symbol != m.symbol {
+		return
+	}
+	m.err = err
+}
+
+// format picks decimals by magnitude, so small coins keep their digits.
+func format(v float64) string {
+	switch {
+	case v >= 1000:
+		return fmt.Sprintf("%.2f", v)
+	case v >= 1:
+		return fmt.Sprintf("%.4f", v)
+	default:
+		return fmt.Sprintf("%.6f", v)
+	}
+}
+
+func (m Model) View() string {
+	titleS := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	textS := lipgloss.NewStyle().Foreground(styles.ColorText)
+
+	var b strings.Builder
+	b.WriteString(titleS.Render("Order book"))
+	if m.symbol != "" {
+		b.WriteString(dimS.Render("  " + m.symbol))
+	}
+	bids, asks := m.book.Bids, m.book.Asks
+	if len(bids) > 0 && len(asks) > 0 {
+		spread := asks[0].Price - bids[0].Price
+		mid := (asks[0].Price + bids[0].Price) / 2
+		b.WriteString(dimS.Render(fmt.Sprintf("  spread %s (%.3f%%)", format(spread), spread/mid*100)))
+	}
+	if m.err != nil && m.loaded {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorWarning).Render("  ⚠ stale"))
+	}
+
+	innerW := m.width - 4
+	half := (innerW - 3) / 2
+	barW := max(0, half-priceWidth-sizeWidth-1)
+	switch {
+	case m.err != nil && !m.loaded:
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+	case !m.loaded:
+		b.WriteString("\n" + dimS.Render("Loading order book..."))
+	default:
+		b.WriteString("\n" + dimS.Render(
+			fmt.Sprintf("%*s%*s%*s", barW, "", sizeWidth+1, "Size", priceWidth, "Bid")+" │ "+
+				fmt.Sprintf("%-*s%-*s", priceWidth, "Ask", sizeWidth+1, "Size")))
+
+		// Bars show the depth from the touch out, on one scale for both sides
+		n := min(m.Levels(), max(len(bids), len(asks)))
+		cumBids, cumAsks := cumulative(bids, n), cumulative(asks, n)
+		scale := max(last(cumBids), last(cumAsks))
+		bar := func(cum float64) string {
+			if scale == 0 {
+				return ""
+			}
+			return strings.Repeat("█", int(cum/scale*float64(barW)+0.5))
+		}
+		for i := range n {
+			left := strings.Repeat(" ", half)
+			if i < len(bids) {
+				l := bids[i]
+				left = styles.PositiveChange.Faint(true).Render(fmt.Sprintf("%*s", barW, bar(cumBids[i]))) +
+					textS.Render(fmt.Sprintf("%*s", sizeWidth+1, format(l.Size))) +
+					styles.PositiveChange.Render(fmt.Sprintf("%*s", priceWidth, format(l.Price)))
+			}
+			right := ""
+			if i < len(asks) {
+				l := asks[i]
+				right = styles.NegativeChange.Render(fmt.Sprintf("%-*s", priceWidth, format(l.Price))) +
+					textS.Render(fmt.Sprintf("%-*s", sizeWidth+1, format(l.Size))) +
+					styles.NegativeChange.Faint(true).Render(bar(cumAsks[i]))
+			}
+			b.WriteString("\n" + left + dimS.Render(" │ ") + right)
+		}
+	}
+
+	return styles.Pane.Width(m.width).Height(m.height).Render(b.String())
+}
+
+// cumulative returns the running total of the first n levels' sizes.
+func cumulative(levels []models.BookLevel, n int) []float64 {
+	out := make([]float64, min(n, len(levels)))
+	total := 0.0
+	for i := range out {
+		total += levels[i].Size
+		out[i] = total
+	}
+	return out
+}
+
+func last(v []float64) float64 {
+	if len(v) == 0 {
+		return 0
+	}
+	return v[len(v)-1]
+}
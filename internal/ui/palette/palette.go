@@ -0,0 +1,234 @@
+package palette
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/keys"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// RunMsg is emitted when the user picks an action. The app runs it by
+// handling Action.Msg as if the key had been pressed.
+type RunMsg struct {
+	Action keys.Action
+}
+
+// Model is a modal listing every action, narrowed by a fuzzy match on
+// what is typed.
+type Model struct {
+	input   textinput.Model
+	actions []keys.Action
+	shown   []keys.Action
+	cursor  int
+	visible bool
+	width   int
+	height  int
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "type to filter actions"
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(styles.ColorText).Bold(true)
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	ti.CharLimit = 40
+	ti.Width = 30
+	return Model{input: ti}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+// SetReducedMotion keeps the input cursor from blinking.
+func (m *Model) SetReducedMotion(on bool) {
+	mode := cursor.CursorBlink
+	if on {
+		mode = cursor.CursorStatic
+	}
+	m.input.Cursor.SetMode(mode)
+}
+
+// Open shows the modal listing actions, with an empty filter.
+func (m *Model) Open(actions []keys.Action) tea.Cmd {
+	m.actions = actions
+	m.shown = actions
+	m.input.SetValue("")
+	m.cursor = 0
+	m.visible = true
+	return m.input.Focus()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.visible = false
+			m.input.Blur()
+			return m, nil
+		case "up", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.shown)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			if len(m.shown) == 0 {
+				return m, nil
+			}
+			m.visible = false
+			m.input.Blur()
+			a := m.shown[m.cursor]
+			return m, func() tea.Msg { return RunMsg{Action: a} }
+		}
+	}
+
+	before := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != before {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// filter lists the actions matching the input, best match first. Ties
+// keep help order.
+func (m *Model) filter() {
+	m.cursor = 0
+	query := strings.ToLower(strings.Join(strings.Fields(m.input.Value()), " "))
+	if query == "" {
+		m.shown = m.actions
+		return
+	}
+	type scored struct {
+		a     keys.Action
+		score int
+	}
+	var hits []scored
+	for _, a := range m.actions {
+		text := a.Desc + " " + strings.ReplaceAll(a.Name, "_", " ")
+		if s, ok := score(strings.ToLower(text), query); ok {
+			hits = append(hits, scored{a, s})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	m.shown = make([]keys.Action, len(hits))
+	for i, h := range hits {
+		m.shown[i] = h.a
+	}
+}
+
+// score matches query as a subsequence of text, preferring runs of
+// consecutive letters and letters at the start of words. It reports false
+// when some letter of query is not found in order.
+func score(text, query string) (int, bool) {
+	t, q := []rune(text), []rune(query)
+	total, run, j := 0, 0, 0
+	for i := 0; i < len(t) && j < len(q); i++ {
+		if t[i] != q[j] {
+			run = 0
+			continue
+		}
+		run++
+		total += run
+		if i == 0 || t[i-1] == ' ' || t[i-1] == '(' || t[i-1] == '/' {
+			total += 3
+		}
+		j++
+	}
+	if j < len(q) {
+		return 0, false
+	}
+	return total, true
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.input.Width = min(40, max(10, w-20))
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext).
+		Italic(true)
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Command palette"))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.input.View())
+	sb.WriteString("\n\n")
+
+	if len(m.shown) == 0 {
+		sb.WriteString(hintStyle.Render("No matching actions"))
+		sb.WriteString("\n")
+	}
+
+	// Keep the cursor visible when there are more actions than rows
+	rowW := min(60, max(30, m.width-12))
+	const keyW = 12
+	descW := rowW - keyW - 1
+	rows := max(1, m.height-14)
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	end := min(len(m.shown), start+rows)
+	for i := start; i < end; i++ {
+		a := m.shown[i]
+		desc := fmt.Sprintf("%-*s ", descW, truncate(a.Desc, descW))
+		bound := fmt.Sprintf("%*s", keyW, truncate(a.Keys, keyW))
+		if i == m.cursor {
+			sb.WriteString(styles.SelectedItem.Render("› " + desc + bound))
+		} else {
+			sb.WriteString(styles.ListItem.Render("  " + desc + keyStyle.Render(bound)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("↑/↓ move • Enter run • Esc cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
+
+func truncate(s string, w int) string {
+	r := []rune(s)
+	if len(r) <= w {
+		return s
+	}
+	return string(r[:w-1]) + "…"
+}
@@ -0,0 +1,125 @@
+package picker
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// SelectMsg is emitted when the user picks an entry.
+type SelectMsg struct {
+	Tag   string
+	Index int
+}
+
+// Model is a modal list of choices. The tag identifies which action
+// opened it so the app can route the selection.
+type Model struct {
+	tag     string
+	title   string
+	items   []string
+	cursor  int
+	visible bool
+	width   int
+	height  int
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+// Open shows the picker with the cursor on the selected entry.
+func (m *Model) Open(tag, title string, items []string, selected int) {
+	m.tag = tag
+	m.title = title
+	m.items = items
+	m.cursor = max(0, min(selected, len(items)-1))
+	m.visible = true
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q":
+			m.visible = false
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		case "enter":
+			m.visible = false
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			tag, idx := m.tag, m.cursor
+			return m, func() tea.Msg { return SelectMsg{Tag: tag, Index: idx} }
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext).
+		Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(m.title))
+	sb.WriteString("\n\n")
+
+	// Keep the cursor visible when the list is taller than the screen
+	rows := max(1, m.height-12)
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	end := min(len(m.items), start+rows)
+
+	for i := start; i < end; i++ {
+		if i == m.cursor {
+			sb.WriteString(styles.SelectedItem.Render("› " + m.items[i]))
+		} else {
+			sb.WriteString(styles.ListItem.Render("  " + m.items[i]))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("↑/↓ move • Enter select • Esc cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
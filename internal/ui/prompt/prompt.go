@@ -0,0 +1,153 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// SubmitMsg is emitted when the user confirms a valid value.
+type SubmitMsg struct {
+	Tag   string
+	Value string
+}
+
+// Validator checks a value before it is submitted.
+type Validator func(string) error
+
+// Model is a single-line input modal. The tag identifies which action
+// opened it so the app can route the submitted value.
+type Model struct {
+	tag      string
+	title    string
+	hint     string
+	input    textinput.Model
+	validate Validator
+	err      error
+	visible  bool
+	width    int
+	height   int
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(styles.ColorText).Bold(true)
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	ti.CharLimit = 64
+	ti.Width = 30
+	return Model{input: ti}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+// SetReducedMotion keeps the input cursor from blinking.
+func (m *Model) SetReducedMotion(on bool) {
+	mode := cursor.CursorBlink
+	if on {
+		mode = cursor.CursorStatic
+	}
+	m.input.Cursor.SetMode(mode)
+}
+
+// Open shows the prompt. validate may be nil.
+func (m *Model) Open(tag, title, placeholder, hint string, validate Validator) tea.Cmd {
+	m.tag = tag
+	m.title = title
+	m.hint = hint
+	m.validate = validate
+	m.err = nil
+	m.input.Placeholder = placeholder
+	m.input.SetValue("")
+	m.visible = true
+	return m.input.Focus()
+}
+
+// SetValue pre-fills the input, e.g. when editing an existing value.
+func (m *Model) SetValue(v string) {
+	m.input.SetValue(v)
+	m.input.CursorEnd()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.visible = false
+			m.input.Blur()
+			return m, nil
+		case "enter":
+			value := strings.TrimSpace(m.input.Value())
+			if m.validate != nil {
+				if err := m.validate(value); err != nil {
+					m.err = err
+					return m, nil
+				}
+			}
+			m.visible = false
+			m.input.Blur()
+			tag := m.tag
+			return m, func() tea.Msg { return SubmitMsg{Tag: tag, Value: value} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.err = nil
+	return m, cmd
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.input.Width = min(40, max(10, w-20))
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext).
+		Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(m.title))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.input.View())
+	sb.WriteString("\n\n")
+	if m.err != nil {
+		sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+		sb.WriteString("\n")
+	}
+	hint := "Enter to confirm • Esc to cancel"
+	if m.hint != "" {
+		hint = m.hint + "\n" + hint
+	}
+	sb.WriteString(hintStyle.Render(hint))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
@@ -0,0 +1,272 @@
+package risk
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Metrics are the risk figures of a value series.
+type Metrics struct {
+	From        time.Time
+	Return      float64 // percent over the whole series
+	Volatility  float64 // annualised standard deviation of returns, percent
+	MaxDrawdown float64 // worst fall from a peak, percent (negative)
+	Sharpe      float64 // annualised excess return over volatility
+}
+
+// Compute works out the metrics of a value series. rf is the yearly
+// risk-free rate in percent. It reports false for series too short to
+// say anything.
+func Compute(candles []models.Candle, rf float64) (Metrics, bool) {
+	// A series starts flat until the first buy; that stretch is no risk
+	// taken, so it is left out
+	for len(candles) > 1 && candles[1].Close == candles[0].Close {
+		candles = candles[1:]
+	}
+	if len(candles) < 3 || candles[0].Close <= 0 {
+		return Metrics{}, false
+	}
+	first, last := candles[0], candles[len(candles)-1]
+	years := last.Timestamp.Sub(first.Timestamp).Hours() / (24 * 365.25)
+	if years <= 0 {
+		return Metrics{}, false
+	}
+
+	m := Metrics{From: first.Timestamp, Return: (last.Close/first.Close - 1) * 100}
+	returns := make([]float64, 0, len(candles)-1)
+	peak := first.Close
+	for i := 1; i < len(candles); i++ {
+		c := candles[i].Close
+		if prev := candles[i-1].Close; prev > 0 {
+			returns = append(returns, c/prev-1)
+		}
+		peak = max(peak, c)
+		m.MaxDrawdown = min(m.MaxDrawdown, (c/peak-1)*100)
+	}
+
+	var mean, variance float64
+	for _, r := range returns {
+		mean += r / float64(len(returns))
+	}
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean) / float64(len(returns)-1)
+	}
+	// Periods per year from the series itself, as crypto trades every day
+	perYear := float64(len(returns)) / years
+	vol := math.Sqrt(variance * perYear)
+	m.Volatility = vol * 100
+	if vol > 0 {
+		m.Sharpe = (mean*perYear - rf/100) / vol
+	}
+	return m, true
+}
+
+// Share is a sector's or a holding's part of the portfolio's value.
+type Share struct {
+	Name string
+	Pct  float64
+}
+
+// Concentration splits the value of the holdings by sector, largest first,
+// and returns the largest single holding. Symbols without a sector are
+// counted as Other.
+func Concentration(values map[string]float64, port models.Portfolio) ([]Share, Share) {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	if total <= 0 {
+		return nil, Share{}
+	}
+	bySector := make(map[string]float64)
+	var largest Share
+	for sym, v := range values {
+		sector := port.Sector(sym)
+		if sector == "" {
+			sector = "Other"
+		}
+		bySector[sector] += v
+		if pct := v / total * 100; pct > largest.Pct || (pct == largest.Pct && sym < largest.Name) {
+			largest = Share{Name: sym, Pct: pct}
+		}
+	}
+	shares := make([]Share, 0, len(bySector))
+	for name, v := range bySector {
+		shares = append(shares, Share{Name: name, Pct: v / total * 100})
+	}
+	slices.SortFunc(shares, func(a, b Share) int {
+		if c := cmp.Compare(b.Pct, a.Pct); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return shares, largest
+}
+
+// Model is the portfolio risk modal.
+type Model struct {
+	toggle  key.Binding
+	visible bool
+	loading bool
+	err     error
+	width   int
+	height  int
+
+	rf      float64
+	metrics Metrics
+	ok      bool // metrics computed
+	sectors []Share
+	largest Share
+}
+
+// New builds the risk modal; toggle also closes it.
+func New(toggle key.Binding) Model {
+	return Model{toggle: toggle}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(msg, m.toggle) || msg.String() == "esc" || msg.String() == "q" {
+			m.visible = false
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Open shows the modal loading until SetData or SetError.
+func (m *Model) Open() {
+	m.visible = true
+	m.loading = true
+	m.err = nil
+}
+
+// SetData computes the metrics from the portfolio's value history and
+// the current value of each holding.
+func (m *Model) SetData(history []models.Candle, values map[string]float64, port models.Portfolio) {
+	m.loading = false
+	m.err = nil
+	m.rf = port.RiskFreeRate
+	m.metrics, m.ok = Compute(history, port.RiskFreeRate)
+	m.sectors, m.largest = Concentration(values, port)
+}
+
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = err
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	headStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
+
+	signed := func(v float64, format string) string {
+		st := styles.PositiveChange
+		if v < 0 {
+			st = styles.NegativeChange
+		}
+		return st.Render(fmt.Sprintf(format, v))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Portfolio Risk"))
+	sb.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		sb.WriteString(dimStyle.Render("Loading history..."))
+		sb.WriteString("\n")
+	case m.err != nil:
+		sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+		sb.WriteString("\n")
+	default:
+		if m.ok {
+			sb.WriteString(headStyle.Render("Since " + m.metrics.From.Format("Jan 2, 2006")))
+			sb.WriteString("\n")
+			sb.WriteString(fmt.Sprintf("%-14s ", "Return"))
+			sb.WriteString(signed(m.metrics.Return, "%+9.2f%%"))
+			sb.WriteString("\n")
+			sb.WriteString(fmt.Sprintf("%-14s %9.2f%% ", "Volatility", m.metrics.Volatility))
+			sb.WriteString(dimStyle.Render("a year"))
+			sb.WriteString("\n")
+			sb.WriteString(fmt.Sprintf("%-14s ", "Max drawdown"))
+			sb.WriteString(signed(m.metrics.MaxDrawdown, "%+9.2f%%"))
+			sb.WriteString("\n")
+			sb.WriteString(fmt.Sprintf("%-14s ", "Sharpe"))
+			sb.WriteString(signed(m.metrics.Sharpe, "%9.2f "))
+			sb.WriteString(dimStyle.Render(fmt.Sprintf(" risk-free %.2f%%", m.rf)))
+			sb.WriteString("\n")
+		} else {
+			sb.WriteString(dimStyle.Render("Not enough history for the metrics"))
+			sb.WriteString("\n")
+		}
+
+		if len(m.sectors) > 0 {
+			sb.WriteString("\n")
+			sb.WriteString(headStyle.Render("Sectors"))
+			sb.WriteString("\n")
+			barStyle := lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+			for _, s := range m.sectors {
+				bar := int(math.Round(s.Pct / 10))
+				sb.WriteString(fmt.Sprintf("%-14s ", truncate(s.Name, 14)))
+				sb.WriteString(barStyle.Render(strings.Repeat("█", bar)))
+				sb.WriteString(strings.Repeat(" ", 10-bar))
+				sb.WriteString(fmt.Sprintf(" %6.1f%%", s.Pct))
+				sb.WriteString("\n")
+			}
+			sb.WriteString("\n")
+			sb.WriteString(fmt.Sprintf("%-14s %s %.1f%%", "Largest", m.largest.Name, m.largest.Pct))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("Esc to close"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
+
+func truncate(s string, w int) string {
+	r := []rune(s)
+	if len(r) <= w {
+		return s
+	}
+	return string(r[:w-1]) + "…"
+}
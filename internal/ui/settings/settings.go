@@ -0,0 +1,201 @@
+package settings
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Setting is a row of the settings screen: a choice among Options, or an
+// on/off toggle when Options is nil.
+type Setting struct {
+	Key     string // config key, e.g. "refresh_interval"
+	Label   string
+	Options []string
+	Value   string // the current option; "on" or "off" for a toggle
+	Fixed   string // why it cannot be changed here; "" when it can
+}
+
+// Toggle reports whether s is switched on or off rather than chosen.
+func (s Setting) Toggle() bool {
+	return s.Options == nil
+}
+
+// ChangedMsg reports a setting changed to Value, for the app to apply.
+type ChangedMsg struct {
+	Key   string
+	Value string
+}
+
+// ClosedMsg reports the settings screen closed, for the app to save the
+// changes made in it.
+type ClosedMsg struct{}
+
+// Model is the settings modal.
+type Model struct {
+	toggle  key.Binding
+	visible bool
+	width   int
+	height  int
+
+	rows   []Setting
+	cursor int
+	hint   string // where changes are saved
+	err    string // last change the app rejected
+}
+
+// New builds the settings modal; toggle also closes it.
+func New(toggle key.Binding) Model {
+	return Model{toggle: toggle}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	k, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch {
+	case key.Matches(k, m.toggle) || k.String() == "esc" || k.String() == "q":
+		m.visible = false
+		return m, func() tea.Msg { return ClosedMsg{} }
+	case k.String() == "j" || k.String() == "down":
+		m.cursor = min(m.cursor+1, len(m.rows)-1)
+	case k.String() == "k" || k.String() == "up":
+		m.cursor = max(m.cursor-1, 0)
+	case k.String() == "l" || k.String() == "right" || k.String() == "enter" || k.String() == " ":
+		return m, m.step(1)
+	case k.String() == "h" || k.String() == "left":
+		return m, m.step(-1)
+	}
+	return m, nil
+}
+
+// step moves the selected setting to its next or previous option, or
+// flips a toggle, and reports the change.
+func (m *Model) step(dir int) tea.Cmd {
+	if m.cursor >= len(m.rows) {
+		return nil
+	}
+	s := &m.rows[m.cursor]
+	if s.Fixed != "" {
+		m.err = s.Fixed
+		return nil
+	}
+	m.err = ""
+	if s.Toggle() {
+		s.Value = onOff(s.Value != "on")
+	} else {
+		n := len(s.Options)
+		i := slices.Index(s.Options, s.Value)
+		if i < 0 && dir < 0 {
+			i = 0
+		}
+		s.Value = s.Options[((i+dir)%n+n)%n]
+	}
+	c := ChangedMsg{Key: s.Key, Value: s.Value}
+	return func() tea.Msg { return c }
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Open shows rows from the first, with hint saying where changes go.
+func (m *Model) Open(rows []Setting, hint string) {
+	m.visible = true
+	m.rows = rows
+	m.cursor = 0
+	m.hint = hint
+	m.err = ""
+}
+
+// SetValue puts a setting back to value, as when the app could not apply
+// a change, and shows err.
+func (m *Model) SetValue(key, value string, err error) {
+	if i := slices.IndexFunc(m.rows, func(s Setting) bool { return s.Key == key }); i >= 0 {
+		m.rows[i].Value = value
+	}
+	if err != nil {
+		m.err = err.Error()
+	}
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
+
+	selStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	labelWidth := 0
+	for _, s := range m.rows {
+		labelWidth = max(labelWidth, len(s.Label))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Settings"))
+	sb.WriteString("\n\n")
+	for i, s := range m.rows {
+		value := s.Value
+		if !s.Toggle() {
+			value = "‹ " + value + " ›"
+		}
+		line := fmt.Sprintf("%-*s  %s", labelWidth, s.Label, value)
+		switch {
+		case i == m.cursor:
+			sb.WriteString(selStyle.Render("▸ " + line))
+		case s.Fixed != "":
+			sb.WriteString(dimStyle.Render("  " + line))
+		default:
+			sb.WriteString("  " + line)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	if m.err != "" {
+		sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(m.err))
+		sb.WriteString("\n")
+	}
+	if m.hint != "" {
+		sb.WriteString(dimStyle.Render(m.hint))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(dimStyle.Render("j/k to move • ←/→ to change • space to toggle • Esc to close"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
@@ -0,0 +1,204 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Period is a lookback window in the returns summary.
+type Period struct {
+	Label string
+	Start func(now time.Time) time.Time
+}
+
+var periods = []Period{
+	{"1W", func(t time.Time) time.Time { return t.AddDate(0, 0, -7) }},
+	{"1M", func(t time.Time) time.Time { return t.AddDate(0, -1, 0) }},
+	{"3M", func(t time.Time) time.Time { return t.AddDate(0, -3, 0) }},
+	{"6M", func(t time.Time) time.Time { return t.AddDate(0, -6, 0) }},
+	{"1Y", func(t time.Time) time.Time { return t.AddDate(-1, 0, 0) }},
+	{"YTD", func(t time.Time) time.Time { return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location()) }},
+}
+
+// startSlack is how far a series may start after a period's start and
+// still cover it, from its first close. A year of daily history begins
+// on the first trading day on or after a year ago, which can be days
+// later over a weekend or holiday, and later in the day than now.
+const startSlack = 5 * 24 * time.Hour
+
+// Return is the computed performance over a single period.
+type Return struct {
+	Label     string
+	From      float64
+	To        float64
+	Pct       float64
+	Available bool
+}
+
+// Returns computes the period returns from a daily candle series.
+// Periods that reach further back than the series, by more than
+// startSlack, are marked unavailable.
+func Returns(candles []models.Candle, now time.Time) []Return {
+	out := make([]Return, len(periods))
+	for i, p := range periods {
+		out[i] = Return{Label: p.Label}
+		if len(candles) < 2 {
+			continue
+		}
+
+		start := p.Start(now)
+		if candles[0].Timestamp.After(start.Add(startSlack)) {
+			continue
+		}
+
+		// Last close at or before the period start
+		base := candles[0].Close
+		for _, c := range candles {
+			if c.Timestamp.After(start) {
+				break
+			}
+			base = c.Close
+		}
+		last := candles[len(candles)-1].Close
+		if base <= 0 {
+			continue
+		}
+
+		out[i].From = base
+		out[i].To = last
+		out[i].Pct = (last - base) / base * 100
+		out[i].Available = true
+	}
+	return out
+}
+
+type Model struct {
+	symbol  string
+	returns []Return
+	loading bool
+	err     error
+	visible bool
+	width   int
+	height  int
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "esc" || msg.String() == "q" || msg.String() == "R" {
+			m.visible = false
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Open shows the modal for symbol in a loading state until SetData is called.
+func (m *Model) Open(symbol string) {
+	m.symbol = symbol
+	m.returns = nil
+	m.err = nil
+	m.loading = true
+	m.visible = true
+}
+
+func (m *Model) SetData(symbol string, candles []models.Candle) {
+	if symbol != m.symbol {
+		return
+	}
+	m.returns = Returns(candles, time.Now())
+	m.loading = false
+	m.err = nil
+}
+
+func (m *Model) SetError(symbol string, err error) {
+	if symbol != m.symbol {
+		return
+	}
+	m.err = err
+	m.loading = false
+}
+
+func (m *Model) Hide() {
+	m.visible = false
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// Symbol returns the symbol the modal is showing.
+func (m Model) Symbol() string {
+	return m.symbol
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	headStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(m.symbol + " Returns"))
+	sb.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		sb.WriteString(dimStyle.Render("Loading history..."))
+	case m.err != nil:
+		sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorError).Render(m.err.Error()))
+	default:
+		sb.WriteString(headStyle.Render(fmt.Sprintf("%-6s %12s %12s %10s", "Period", "From", "To", "Return")))
+		sb.WriteString("\n")
+		for _, r := range m.returns {
+			if !r.Available {
+				sb.WriteString(dimStyle.Render(fmt.Sprintf("%-6s %12s %12s %10s", r.Label, "—", "—", "—")))
+				sb.WriteString("\n")
+				continue
+			}
+			pctStyle := styles.PositiveChange
+			if r.Pct < 0 {
+				pctStyle = styles.NegativeChange
+			}
+			sb.WriteString(fmt.Sprintf("%-6s %12.2f %12.2f ", r.Label, r.From, r.To))
+			sb.WriteString(pctStyle.Render(fmt.Sprintf("%+9.2f%%", r.Pct)))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("Esc to close"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Background(styles.ColorSurface)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(sb.String()))
+}
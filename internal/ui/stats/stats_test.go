@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// dailyBars returns a close for every weekday from start through end, at
+// the time of day of start.
+func dailyBars(start, end time.Time) []models.Candle {
+	var candles []models.Candle
+	price := 100.0
+	for t := start; !t.After(end); t = t.AddDate(0, 0, 1) {
+		if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+			continue
+		}
+		candles = append(candles, models.Candle{Timestamp: t, Close: price})
+		price++
+	}
+	return candles
+}
+
+func TestReturnsYearFetch(t *testing.T) {
+	// A year ago was a Sunday, so a year of history starts on the Monday,
+	// and the bars are later in the day than now
+	now := time.Date(2024, 6, 9, 10, 0, 0, 0, time.UTC)
+	candles := dailyBars(time.Date(2023, 6, 12, 13, 30, 0, 0, time.UTC), now)
+
+	for _, r := range Returns(candles, now) {
+		if !r.Available {
+			t.Errorf("%s is unavailable, want it covered by a year of history", r.Label)
+		}
+	}
+	if r := Returns(candles, now)[4]; r.Label != "1Y" || r.From != candles[0].Close {
+		t.Errorf("%s from %v, want from the first close %v", r.Label, r.From, candles[0].Close)
+	}
+}
+
+func TestReturnsShortHistory(t *testing.T) {
+	now := time.Date(2024, 6, 7, 10, 0, 0, 0, time.UTC)
+	candles := dailyBars(time.Date(2024, 3, 1, 13, 30, 0, 0, time.UTC), now)
+
+	want := map[string]bool{"1W": true, "1M": true, "3M": true, "6M": false, "1Y": false, "YTD": false}
+	for _, r := range Returns(candles, now) {
+		if r.Available != want[r.Label] {
+			t.Errorf("%s available = %v, want %v", r.Label, r.Available, want[r.Label])
+		}
+	}
+}
@@ -1,46 +1,176 @@
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+)
+
+// The active theme's colors and the styles derived from them. They are set
+// by Use; the dark theme is active until then.
 var (
 	// Colors
-	ColorPrimary   = lipgloss.Color("#7D56F4")
-	ColorSecondary = lipgloss.Color("#666666")
-	ColorSuccess   = lipgloss.Color("#04B575")
-	ColorWarning   = lipgloss.Color("#FFA500")
-	ColorError     = lipgloss.Color("#FF4C4C")
-	ColorText      = lipgloss.Color("#EEEEEE")
-	ColorSubtext   = lipgloss.Color("#999999")
-	ColorHighlight = lipgloss.Color("#2D2D2D")
+	ColorPrimary   lipgloss.Color
+	ColorSecondary lipgloss.Color
+	ColorSuccess   lipgloss.Color
+	ColorWarning   lipgloss.Color
+	ColorError     lipgloss.Color
+	ColorText      lipgloss.Color
+	ColorSubtext   lipgloss.Color
+	ColorHighlight lipgloss.Color
+	ColorSurface   lipgloss.Color // modal and footer background
+	ColorInverse   lipgloss.Color // text on warning/flash backgrounds
+
+	// Chart overlay palette (indicators, extra series)
+	ColorOverlay []lipgloss.Color
+
+	symbolTrends map[string]TrendColors
+	classTrends  map[string]TrendColors
 
 	// Base styles
-	Base = lipgloss.NewStyle().Foreground(ColorText)
+	Base lipgloss.Style
 
 	// Panes
+	Pane       lipgloss.Style
+	ActivePane lipgloss.Style
+
+	// Watchlist
+	ListItem     lipgloss.Style
+	SelectedItem lipgloss.Style
+	FlashItem    lipgloss.Style
+
+	PositiveChange lipgloss.Style
+	NegativeChange lipgloss.Style
+
+	// Pre-market and after-hours prices, and their PRE/AH badge
+	ExtendedHours lipgloss.Style
+	ExtendedBadge lipgloss.Style
+
+	// Chart
+	ChartLabel lipgloss.Style
+)
+
+// PaneFor is a pane's border style: ActivePane while the pane has the
+// keyboard focus, Pane otherwise.
+func PaneFor(focused bool) lipgloss.Style {
+	if focused {
+		return ActivePane
+	}
+	return Pane
+}
+
+// Dim returns c faded halfway toward the subtext color, for chart data
+// that is secondary, such as extended-hours candles. Colors other than
+// "#RRGGBB" fade to the subtext color itself.
+func Dim(c lipgloss.Color) lipgloss.Color {
+	from, ok := parseHex(c)
+	to, ok2 := parseHex(ColorSubtext)
+	if !ok || !ok2 {
+		return ColorSubtext
+	}
+	var mixed [3]uint64
+	for i := range mixed {
+		mixed[i] = (from[i] + to[i]) / 2
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", mixed[0], mixed[1], mixed[2]))
+}
+
+func parseHex(c lipgloss.Color) ([3]uint64, bool) {
+	var rgb [3]uint64
+	s, ok := strings.CutPrefix(string(c), "#")
+	if !ok || len(s) != 6 {
+		return rgb, false
+	}
+	for i := range rgb {
+		v, err := strconv.ParseUint(s[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return rgb, false
+		}
+		rgb[i] = v
+	}
+	return rgb, true
+}
+
+// Trend returns the chart's up and down colors for symbol: its own
+// override, else its asset class's, else the theme's Success and Error.
+func Trend(symbol string) TrendColors {
+	tc, ok := symbolTrends[strings.ToUpper(symbol)]
+	if !ok {
+		tc = classTrends[market.AssetClass(symbol)]
+	}
+	if tc.Up == "" {
+		tc.Up = ColorSuccess
+	}
+	if tc.Down == "" {
+		tc.Down = ColorError
+	}
+	return tc
+}
+
+// For returns the color for a rising (up) or falling move.
+func (tc TrendColors) For(up bool) lipgloss.Color {
+	if up {
+		return tc.Up
+	}
+	return tc.Down
+}
+
+func init() {
+	Use(Dark)
+}
+
+// Use makes t the active theme. It must be called before the UI components
+// are built, since some of them capture styles at construction.
+func Use(t Theme) {
+	ColorPrimary = t.Primary
+	ColorSecondary = t.Secondary
+	ColorSuccess = t.Success
+	ColorWarning = t.Warning
+	ColorError = t.Error
+	ColorText = t.Text
+	ColorSubtext = t.Subtext
+	ColorHighlight = t.Highlight
+	ColorSurface = t.Surface
+	ColorInverse = t.Inverse
+	ColorOverlay = t.Overlay
+	symbolTrends = t.SymbolTrends
+	classTrends = t.ClassTrends
+
+	Base = lipgloss.NewStyle().Foreground(ColorText)
+
 	Pane = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(ColorSecondary).
 		Padding(0, 1)
 
 	ActivePane = Pane.Copy().
-			BorderForeground(ColorPrimary)
+		BorderForeground(ColorPrimary)
 
-	// Watchlist
 	ListItem = lipgloss.NewStyle().
-			PaddingLeft(1).
-			PaddingRight(1)
+		PaddingLeft(1).
+		PaddingRight(1)
 
 	SelectedItem = ListItem.Copy().
-			Background(ColorHighlight).
-			Foreground(ColorPrimary).
-			Bold(true)
+		Background(ColorHighlight).
+		Foreground(ColorPrimary).
+		Bold(true)
+
+	FlashItem = ListItem.Copy().
+		Background(ColorWarning).
+		Foreground(ColorInverse).
+		Bold(true)
 
 	PositiveChange = lipgloss.NewStyle().Foreground(ColorSuccess)
 	NegativeChange = lipgloss.NewStyle().Foreground(ColorError)
 
-	// Chart
+	ExtendedHours = lipgloss.NewStyle().Foreground(ColorText).Italic(true)
+	ExtendedBadge = lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
+
 	ChartLabel = lipgloss.NewStyle().
-			Foreground(ColorSubtext).
-			Width(8).
-			Align(lipgloss.Right)
-)
+		Foreground(ColorSubtext).
+		Width(8).
+		Align(lipgloss.Right)
+}
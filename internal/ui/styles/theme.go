@@ -0,0 +1,189 @@
+package styles
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// Theme is a named color scheme.
+type Theme struct {
+	Name      string
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Success   lipgloss.Color
+	Warning   lipgloss.Color
+	Error     lipgloss.Color
+	Text      lipgloss.Color
+	Subtext   lipgloss.Color
+	Highlight lipgloss.Color
+	Surface   lipgloss.Color
+	Inverse   lipgloss.Color
+	Overlay   []lipgloss.Color
+
+	// Chart up/down colors by symbol and by asset class, replacing
+	// Success and Error on the chart
+	SymbolTrends map[string]TrendColors
+	ClassTrends  map[string]TrendColors
+}
+
+// TrendColors is a pair of chart colors for rising and falling moves.
+// An empty color falls back to the theme's Success or Error.
+type TrendColors struct {
+	Up   lipgloss.Color
+	Down lipgloss.Color
+}
+
+// Dark is the default theme.
+var Dark = Theme{
+	Name:      "dark",
+	Primary:   "#7D56F4",
+	Secondary: "#666666",
+	Success:   "#04B575",
+	Warning:   "#FFA500",
+	Error:     "#FF4C4C",
+	Text:      "#EEEEEE",
+	Subtext:   "#999999",
+	Highlight: "#2D2D2D",
+	Surface:   "#1a1a2e",
+	Inverse:   "#000000",
+	Overlay:   []lipgloss.Color{"#5DA9E9", "#F4D35E", "#EE964B", "#C77DFF", "#F06292"},
+}
+
+var themes = map[string]Theme{
+	"dark": Dark,
+	"light": {
+		Name:      "light",
+		Primary:   "#5A3FC0",
+		Secondary: "#AAAAAA",
+		Success:   "#0A8A4F",
+		Warning:   "#C47A00",
+		Error:     "#D32F2F",
+		Text:      "#1F1F1F",
+		Subtext:   "#6B6B6B",
+		Highlight: "#E4E4EC",
+		Surface:   "#F4F4F8",
+		Inverse:   "#FFFFFF",
+		Overlay:   []lipgloss.Color{"#1E6FB8", "#B58900", "#C8551B", "#8E44AD", "#C2185B"},
+	},
+	"solarized": {
+		Name:      "solarized",
+		Primary:   "#268BD2",
+		Secondary: "#586E75",
+		Success:   "#859900",
+		Warning:   "#B58900",
+		Error:     "#DC322F",
+		Text:      "#EEE8D5",
+		Subtext:   "#93A1A1",
+		Highlight: "#073642",
+		Surface:   "#002B36",
+		Inverse:   "#002B36",
+		Overlay:   []lipgloss.Color{"#2AA198", "#B58900", "#CB4B16", "#6C71C4", "#D33682"},
+	},
+	"gruvbox": {
+		Name:      "gruvbox",
+		Primary:   "#D79921",
+		Secondary: "#665C54",
+		Success:   "#98971A",
+		Warning:   "#FE8019",
+		Error:     "#CC241D",
+		Text:      "#EBDBB2",
+		Subtext:   "#A89984",
+		Highlight: "#3C3836",
+		Surface:   "#282828",
+		Inverse:   "#282828",
+		Overlay:   []lipgloss.Color{"#458588", "#FABD2F", "#D65D0E", "#B16286", "#D3869B"},
+	},
+	"nord": {
+		Name:      "nord",
+		Primary:   "#88C0D0",
+		Secondary: "#4C566A",
+		Success:   "#A3BE8C",
+		Warning:   "#EBCB8B",
+		Error:     "#BF616A",
+		Text:      "#ECEFF4",
+		Subtext:   "#D8DEE9",
+		Highlight: "#3B4252",
+		Surface:   "#2E3440",
+		Inverse:   "#2E3440",
+		Overlay:   []lipgloss.Color{"#81A1C1", "#EBCB8B", "#D08770", "#B48EAD", "#8FBCBB"},
+	},
+}
+
+// Lookup returns the built-in theme with the given name. An empty name is
+// the dark theme.
+func Lookup(name string) (Theme, error) {
+	if name == "" {
+		return Dark, nil
+	}
+	t, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return t, nil
+}
+
+// Names lists the built-in themes.
+func Names() []string {
+	names := make([]string, 0, len(themes))
+	for n := range themes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithColors returns t with the colors set in c replacing its own.
+func (t Theme) WithColors(c models.ThemeColors) Theme {
+	set := func(dst *lipgloss.Color, v string) {
+		if v != "" {
+			*dst = lipgloss.Color(v)
+		}
+	}
+	set(&t.Primary, c.Primary)
+	set(&t.Secondary, c.Secondary)
+	set(&t.Success, c.Success)
+	set(&t.Warning, c.Warning)
+	set(&t.Error, c.Error)
+	set(&t.Text, c.Text)
+	set(&t.Subtext, c.Subtext)
+	set(&t.Highlight, c.Highlight)
+	set(&t.Surface, c.Surface)
+	set(&t.Inverse, c.Inverse)
+	if len(c.Overlay) > 0 {
+		t.Overlay = make([]lipgloss.Color, len(c.Overlay))
+		for i, v := range c.Overlay {
+			t.Overlay[i] = lipgloss.Color(v)
+		}
+	}
+	return t
+}
+
+// WithChartColors returns t with per-symbol and per-class chart colors.
+// Each rule names a symbol or a class, not both.
+func (t Theme) WithChartColors(rules []models.ChartColors) (Theme, error) {
+	symbols := make(map[string]TrendColors)
+	classes := make(map[string]TrendColors)
+	for _, r := range rules {
+		tc := TrendColors{Up: lipgloss.Color(r.Up), Down: lipgloss.Color(r.Down)}
+		switch {
+		case r.Symbol != "" && r.Class != "":
+			return t, fmt.Errorf("chart_colors: set symbol or class, not both (%s, %s)", r.Symbol, r.Class)
+		case r.Symbol != "":
+			symbols[strings.ToUpper(r.Symbol)] = tc
+		case slices.Contains(market.Classes, strings.ToLower(r.Class)):
+			classes[strings.ToLower(r.Class)] = tc
+		case r.Class != "":
+			return t, fmt.Errorf("chart_colors: unknown class %q (available: %s)", r.Class, strings.Join(market.Classes, ", "))
+		default:
+			return t, fmt.Errorf("chart_colors: a rule needs a symbol or a class")
+		}
+	}
+	t.SymbolTrends, t.ClassTrends = symbols, classes
+	return t, nil
+}
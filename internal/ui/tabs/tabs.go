@@ -0,0 +1,69 @@
+package tabs
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Model is a one-line tab bar over a pane that shows one view at a time.
+type Model struct {
+	titles []string
+	active int
+	width  int
+}
+
+func New(titles ...string) Model {
+	return Model{titles: titles}
+}
+
+func (m *Model) SetSize(w int) {
+	m.width = w
+}
+
+// Active returns the index of the shown tab.
+func (m Model) Active() int {
+	return m.active
+}
+
+// Set shows tab i. Indexes out of range wrap around, so Set(Active()+1)
+// steps forward from the last tab to the first.
+func (m *Model) Set(i int) {
+	n := len(m.titles)
+	m.active = ((i % n) + n) % n
+}
+
+func (m Model) Len() int {
+	return len(m.titles)
+}
+
+// At returns the tab under column x of the bar.
+func (m Model) At(x int) (int, bool) {
+	left := 1
+	for i, t := range m.titles {
+		right := left + lipgloss.Width(t) + 2
+		if x >= left && x < right {
+			return i, true
+		}
+		left = right + 1 // the separator
+	}
+	return 0, false
+}
+
+func (m Model) View() string {
+	activeS := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Background(styles.ColorHighlight)
+	dimS := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+
+	parts := make([]string, len(m.titles))
+	for i, t := range m.titles {
+		if i == m.active {
+			parts[i] = activeS.Render(" " + t + " ")
+		} else {
+			parts[i] = dimS.Render(" " + t + " ")
+		}
+	}
+	bar := ansi.Truncate(" "+strings.Join(parts, dimS.Render("│")), m.width, "…")
+	return lipgloss.NewStyle().Width(m.width).MaxWidth(m.width).Render(bar)
+}
@@ -0,0 +1,248 @@
+package ticker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// Fields that can be shown for each symbol, in display order.
+const (
+	FieldSymbol = "symbol"
+	FieldName   = "name"
+	FieldPrice  = "price"
+	FieldChange = "change"
+)
+
+// DefaultFields is used when no fields are configured.
+var DefaultFields = []string{FieldSymbol, FieldPrice, FieldChange}
+
+// DefaultSpeed is the time between one-column steps.
+const DefaultSpeed = 200 * time.Millisecond
+
+const separator = "   •   "
+
+// StepMsg advances the ticker by one column.
+type StepMsg struct{}
+
+// Model is the one-line scrolling strip of watchlist quotes shown above
+// the footer status bar.
+type Model struct {
+	fields  []string
+	speed   time.Duration
+	aliases models.Aliases
+	fx      market.Rates
+	quotes  []models.Quote
+	offset  int
+	width   int
+	static  bool // reduced motion: whole quotes a screenful at a time
+	first   int  // quote at the left edge while static
+}
+
+// New returns a ticker showing fields for each quote, stepping once per
+// speed. Unknown field names are an error.
+func New(fields []string, speed time.Duration) (Model, error) {
+	if len(fields) == 0 {
+		fields = DefaultFields
+	}
+	fields = slices.Clone(fields)
+	for i, f := range fields {
+		f = strings.ToLower(f)
+		switch f {
+		case FieldSymbol, FieldName, FieldPrice, FieldChange:
+		default:
+			return Model{}, fmt.Errorf("unknown ticker field %q (use symbol, name, price or change)", f)
+		}
+		fields[i] = f
+	}
+	if speed <= 0 {
+		speed = DefaultSpeed
+	}
+	return Model{fields: fields, speed: max(speed, 20*time.Millisecond)}, nil
+}
+
+func (m *Model) SetSize(w int) {
+	m.width = w
+}
+
+// SetAliases sets the display names used by the name field.
+func (m *Model) SetAliases(a models.Aliases) {
+	m.aliases = a
+}
+
+// SetRates shows prices in the rates' display currency.
+func (m *Model) SetRates(r market.Rates) {
+	m.fx = r
+}
+
+// SetQuotes replaces the quotes shown. The scroll position carries over so
+// refreshes don't restart the strip; a static strip moves on to the quotes
+// that did not fit instead.
+func (m *Model) SetQuotes(quotes []models.Quote) {
+	if m.static {
+		_, shown := m.page()
+		m.first = (m.first + shown) % max(len(quotes), 1)
+	}
+	m.quotes = quotes
+}
+
+// SetStatic stops the strip from scrolling. It then shows as many whole
+// quotes as fit and turns to the next ones with each refresh, for reduced
+// motion.
+func (m *Model) SetStatic(on bool) {
+	m.static = on
+}
+
+// Tick schedules the next step.
+func (m Model) Tick() tea.Cmd {
+	return tea.Tick(m.speed, func(time.Time) tea.Msg { return StepMsg{} })
+}
+
+// Step scrolls the strip one column to the left.
+func (m *Model) Step() {
+	m.offset++
+}
+
+// cell is one rune of the strip with its style.
+type cell struct {
+	r     rune
+	style lipgloss.Style
+}
+
+// strip lays out every quote's fields once, followed by a separator, and
+// returns where each quote starts.
+func (m Model) strip() ([]cell, []int) {
+	base := lipgloss.NewStyle().Foreground(styles.ColorText).Background(styles.ColorSurface)
+	dim := base.Foreground(styles.ColorSubtext)
+	var cells []cell
+	add := func(s string, style lipgloss.Style) {
+		for _, r := range s {
+			cells = append(cells, cell{r, style})
+		}
+	}
+	starts := make([]int, 0, len(m.quotes))
+	for _, q := range m.quotes {
+		starts = append(starts, len(cells))
+		written := false
+		for _, f := range m.fields {
+			// Without an alias the name is just the symbol again
+			name := m.aliases.Name(q.Symbol)
+			if f == FieldName && name == q.Symbol && slices.Contains(m.fields, FieldSymbol) {
+				continue
+			}
+			if written {
+				add(" ", base)
+			}
+			written = true
+			switch f {
+			case FieldSymbol:
+				add(q.Symbol, base.Bold(true))
+			case FieldName:
+				add(name, dim)
+			case FieldPrice:
+				if market.IsYield(q.Symbol) {
+					add(market.FormatYield(q.Price), base)
+				} else if v, ok := m.fx.Convert(q.Price, q.Currency); ok {
+					add(market.FormatPrice(v, m.fx.To, "%.2f"), base)
+				} else {
+					add(market.FormatPrice(q.Price, q.Currency, "%.2f"), base)
+				}
+			case FieldChange:
+				style := base.Foreground(styles.ColorSuccess)
+				if q.ChangePct < 0 {
+					style = base.Foreground(styles.ColorError)
+				}
+				if market.IsYield(q.Symbol) {
+					add(market.FormatBP(market.YieldChangeBP(q.Price, q.ChangePct)), style)
+				} else {
+					add(fmt.Sprintf("%+.2f%%", q.ChangePct), style)
+				}
+			}
+		}
+		add(separator, dim)
+	}
+	return cells, starts
+}
+
+// page returns the cells of the whole quotes that fit from the static
+// strip's first quote, and how many quotes that is. The first always
+// shows, cut short if it must.
+func (m Model) page() ([]cell, int) {
+	cells, starts := m.strip()
+	n := len(starts)
+	if n == 0 || m.width == 0 {
+		return nil, 0
+	}
+	sep := len([]rune(separator))
+	var out []cell
+	shown := 0
+	for shown < n {
+		i := (m.first + shown) % n
+		end := len(cells)
+		if i+1 < n {
+			end = starts[i+1]
+		}
+		quote := cells[starts[i]:end]
+		if shown > 0 && len(out)+len(quote)-sep > m.width {
+			break
+		}
+		out = append(out, quote...)
+		shown++
+	}
+	return out, shown
+}
+
+func (m Model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	bar := lipgloss.NewStyle().Background(styles.ColorSurface).Width(m.width).MaxWidth(m.width)
+	if m.static {
+		cells, _ := m.page()
+		// No separator after the last quote shown
+		cells = cells[:max(0, len(cells)-len([]rune(separator)))]
+		return bar.Render(renderCells(cells[:min(len(cells), m.width)]))
+	}
+	cells, _ := m.strip()
+	if len(cells) == 0 {
+		return bar.Render("")
+	}
+
+	// Wrap around the strip
+	shown := make([]cell, m.width)
+	for i := range shown {
+		shown[i] = cells[(m.offset+i)%len(cells)]
+	}
+	return bar.Render(renderCells(shown))
+}
+
+// renderCells joins runs of the same style so each run is rendered once.
+func renderCells(cells []cell) string {
+	if len(cells) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	var run []rune
+	style := cells[0].style
+	for _, c := range cells {
+		if !sameStyle(c.style, style) {
+			b.WriteString(style.Render(string(run)))
+			run = run[:0]
+			style = c.style
+		}
+		run = append(run, c.r)
+	}
+	b.WriteString(style.Render(string(run)))
+	return b.String()
+}
+
+func sameStyle(a, b lipgloss.Style) bool {
+	return a.GetForeground() == b.GetForeground() && a.GetBold() == b.GetBold()
+}
@@ -0,0 +1,311 @@
+package tour
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/keys"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// DoneMsg is emitted when the tour is finished or skipped.
+type DoneMsg struct{}
+
+// pane is the part of the screen a step is about. The card is placed
+// beside it, leaving the pane itself in view.
+type pane int
+
+const (
+	paneNone pane = iota
+	paneWatchlist
+	paneTabs
+	paneChart
+	paneFooter
+)
+
+// row is one key and what it does.
+type row struct {
+	keys string
+	desc string
+}
+
+type step struct {
+	title string
+	pane  pane
+	text  string
+	rows  []row
+}
+
+// Model is a card walking through the panes and their main keys, one
+// step at a time.
+type Model struct {
+	steps     []step
+	step      int
+	visible   bool
+	width     int
+	height    int
+	watchlist int // watchlist pane width
+	main      int // height above the footer
+}
+
+// New builds the tour from the active key bindings, so rebound keys show
+// as bound.
+func New(km keys.KeyMap) Model {
+	return Model{steps: []step{
+		{
+			title: "Welcome to stock-tui",
+			text: "Quotes are on the left and a chart of the selected symbol on the " +
+				"right. This tour points out each part and its main keys.",
+			rows: rows(
+				bound("Take the tour again", km.Tour),
+				bound("Or the command palette, then type tour", km.Palette),
+			),
+		},
+		{
+			title: "◀ Watchlist",
+			pane:  paneWatchlist,
+			text:  "Live quotes for the symbols you follow. The selected one is charted.",
+			rows: rows(
+				bound("Move between symbols", km.Up, km.Down),
+				bound("Search the list", km.Search),
+				bound("Look up and add a symbol", km.AddSymbol),
+				bound("Remove the selected symbol", km.RemoveSymbol),
+				bound("Cycle the sort order", km.Sort),
+				bound("Switch watchlists", km.PrevWatchlist, km.NextWatchlist, km.PickWatchlist),
+			),
+		},
+		{
+			title: "▲ Tabs",
+			pane:  paneTabs,
+			text:  "The right-hand pane shows the chart, news, details or option chain of the selected symbol.",
+			rows: rows(
+				bound("Previous / next tab", km.PrevTab, km.NextTab),
+				bound("Details: market cap, P/E, 52W", km.Fundamentals),
+				bound("News headlines", km.News),
+				bound("Open a headline in the browser", km.OpenNews),
+			),
+		},
+		{
+			title: "Chart ▶",
+			pane:  paneChart,
+			text:  "Price history over the chosen range.",
+			rows: rows(
+				bound("1H, 24H, 7D, 30D, YTD, 90D, 1Y, 5Y", km.Range1H, km.Range24H, km.Range7D, km.Range30D, km.RangeYTD, km.Range90D, km.Range1Y, km.Range5Y),
+				bound("Cycle the range", km.NextRange),
+				bound("Cycle the chart type", km.ChartType),
+				bound("Cycle indicators", km.Indicators),
+				bound("RSI and MACD panels", km.RSIPanel, km.MACDPanel),
+				bound("Crosshair", km.Crosshair),
+			),
+		},
+		{
+			title: "Views and alerts",
+			text:  "Other ways to look at the watchlist, and alerts on price moves.",
+			rows: rows(
+				bound("Heatmap of the watchlist", km.Heatmap),
+				bound("Narrow / widen the watchlist", km.Narrower, km.Wider),
+				bound("Switch / save a layout", km.PickLayout, km.SaveLayout),
+				bound("Set a price alert", km.Alert),
+				bound("Jump to the last alert", km.JumpAlert),
+			),
+		},
+		{
+			title: "▼ Footer",
+			pane:  paneFooter,
+			text:  "The provider, range and market hours, the time of the last update, and short messages.",
+			rows: rows(
+				bound("Refresh now", km.Refresh),
+			),
+		},
+		{
+			title: "Finding more",
+			text:  "Every action is in the command palette, found by typing part of its name.",
+			rows: rows(
+				bound("Command palette", km.Palette),
+				bound("All keyboard shortcuts", km.Help),
+				bound("Quit", km.Quit),
+			),
+		},
+	}}
+}
+
+// bound pairs desc with the keys of bindings, leaving out unbound ones.
+// It returns no row when none of them is bound.
+func bound(desc string, bindings ...key.Binding) []row {
+	var ks []string
+	for _, b := range bindings {
+		if b.Enabled() {
+			ks = append(ks, b.Help().Key)
+		}
+	}
+	if len(ks) == 0 {
+		return nil
+	}
+	return []row{{keys: strings.Join(ks, " "), desc: desc}}
+}
+
+func rows(groups ...[]row) []row {
+	var out []row
+	for _, g := range groups {
+		out = append(out, g...)
+	}
+	return out
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+// Open starts the tour at its first step.
+func (m *Model) Open() {
+	m.step = 0
+	m.visible = true
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+	km, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch km.String() {
+	case "right", "l", "enter", " ":
+		if m.step < len(m.steps)-1 {
+			m.step++
+			return m, nil
+		}
+		return m.close()
+	case "left", "h", "backspace":
+		if m.step > 0 {
+			m.step--
+		}
+	case "esc", "q":
+		return m.close()
+	}
+	return m, nil
+}
+
+func (m Model) close() (Model, tea.Cmd) {
+	m.visible = false
+	return m, func() tea.Msg { return DoneMsg{} }
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// SetPanes tells the tour where the panes are: the watchlist width and
+// the height above the footer.
+func (m *Model) SetPanes(watchlistW, mainH int) {
+	m.watchlist = watchlistW
+	m.main = mainH
+}
+
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// cardWidth fits the card beside the watchlist where there is room.
+func (m Model) cardWidth() int {
+	return max(30, min(56, m.width-m.watchlist-4, m.width-4))
+}
+
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+	s := m.steps[m.step]
+	width := m.cardWidth()
+	inner := width - 6 // border and padding
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorText).
+		Width(inner)
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorPrimary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.ColorSubtext).
+		Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(s.title))
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("  %d/%d", m.step+1, len(m.steps))))
+	sb.WriteString("\n\n")
+	sb.WriteString(textStyle.Render(s.text))
+	sb.WriteString("\n")
+	if len(s.rows) > 0 {
+		sb.WriteString("\n")
+	}
+
+	keyW := 0
+	for _, r := range s.rows {
+		keyW = max(keyW, lipgloss.Width(r.keys))
+	}
+	keyW = min(keyW, inner/2)
+	for _, r := range s.rows {
+		k := truncate(r.keys, keyW)
+		sb.WriteString(keyStyle.Render(fmt.Sprintf("%-*s", keyW, k)))
+		sb.WriteString("  ")
+		sb.WriteString(truncate(r.desc, inner-keyW-2))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	hint := "→/Enter next • ← back • Esc skip"
+	if m.step == len(m.steps)-1 {
+		hint = "Enter done • ← back"
+	}
+	sb.WriteString(hintStyle.Render(hint))
+
+	cardStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(1, 2).
+		Width(width - 2).
+		Background(styles.ColorSurface)
+
+	return cardStyle.Render(sb.String())
+}
+
+// Position returns where the top-left corner of card goes: beside the
+// pane of the step, or centered for steps about no one pane.
+func (m Model) Position(card string) (x, y int) {
+	w, h := lipgloss.Width(card), lipgloss.Height(card)
+	center := func() (int, int) { return max(0, (m.width-w)/2), max(0, (m.height-h)/2) }
+	switch m.steps[m.step].pane {
+	case paneWatchlist:
+		if m.watchlist+w <= m.width {
+			return m.watchlist + 1, max(0, (m.main-h)/2)
+		}
+	case paneTabs:
+		if m.watchlist+w <= m.width {
+			return m.watchlist + 1, 1
+		}
+	case paneChart:
+		if w < m.watchlist+(m.width-m.watchlist)/2 {
+			return 1, max(0, (m.main-h)/2)
+		}
+	case paneFooter:
+		return max(0, (m.width-w)/2), max(0, m.main-h)
+	}
+	return center()
+}
+
+func truncate(s string, w int) string {
+	r := []rune(s)
+	if w <= 0 || len(r) <= w {
+		return s
+	}
+	return string(r[:w-1]) + "…"
+}
@@ -0,0 +1,142 @@
+package watchlist
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// column is an optional watchlist column after the change column.
+type column struct {
+	name  string // config name
+	title string // shown over the column in the indicator line
+	width int
+	value func(it item) string // "" when unknown
+}
+
+// columns lists the optional columns by config name.
+var columns = []column{
+	{"volume", "Vol", 7, func(it item) string { return compact(it.volume) }},
+	{"open", "Open", 9, func(it item) string { return it.priceCell(it.open) }},
+	{"high", "High", 9, func(it item) string { return it.priceCell(it.high) }},
+	{"low", "Low", 9, func(it item) string { return it.priceCell(it.low) }},
+	{"market_cap", "MCap", 7, func(it item) string { return compact(it.marketCap) }},
+	{"52w", "52W", 5, func(it item) string {
+		high, low, ok := it.yearRange()
+		if !ok {
+			return ""
+		}
+		return fmt.Sprintf("%.0f%%", (it.price-low)/(high-low)*100)
+	}},
+}
+
+// CheckColumns reports column names the watchlist does not know.
+func CheckColumns(names []string) error {
+	var unknown []string
+	for _, n := range names {
+		if !slices.ContainsFunc(columns, func(c column) bool { return c.name == strings.ToLower(n) }) {
+			unknown = append(unknown, n)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown watchlist_columns %s (use volume, open, high, low, market_cap or 52w)", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// SetColumns shows the named columns after the change column, in order.
+// Unknown names are skipped; CheckColumns reports them.
+func (m *Model) SetColumns(names []string) {
+	m.columns = nil
+	for _, n := range names {
+		if i := slices.IndexFunc(columns, func(c column) bool { return c.name == strings.ToLower(n) }); i >= 0 {
+			m.columns = append(m.columns, columns[i])
+		}
+	}
+	m.list.SetDelegate(delegate{columns: m.columns})
+}
+
+// minSymbolWidth is the narrowest the symbol column gets to make room for
+// the optional columns.
+const minSymbolWidth = 12
+
+// fitColumns returns the leading columns of cols that fit in a list of
+// width totalW beside the fixed ones. Those that don't fit are dropped
+// from the end, so the first configured column is the last to go.
+func fitColumns(cols []column, totalW int) []column {
+	_, priceW, pctW := columnWidths(totalW, 0)
+	room := totalW - minSymbolWidth - priceW - pctW - 2
+	for i, c := range cols {
+		room -= c.width + 1
+		if room < 0 {
+			return cols[:i]
+		}
+	}
+	return cols
+}
+
+// extrasWidth is the width the columns take, with their separators.
+func extrasWidth(cols []column) int {
+	w := 0
+	for _, c := range cols {
+		w += c.width + 1
+	}
+	return w
+}
+
+// renderExtras formats the columns' cells for it, each right-aligned.
+func renderExtras(it item, cols []column) string {
+	var sb strings.Builder
+	for _, c := range cols {
+		v := c.value(it)
+		if v == "" {
+			v = "—"
+		}
+		if r := []rune(v); len(r) > c.width {
+			v = string(r[:c.width])
+		}
+		fmt.Fprintf(&sb, " %*s", c.width, v)
+	}
+	return sb.String()
+}
+
+// columnTitles are the titles over the columns, starting at the change
+// column's right edge.
+func columnTitles(cols []column) string {
+	var sb strings.Builder
+	for _, c := range cols {
+		fmt.Fprintf(&sb, " %*s", c.width, c.title)
+	}
+	return sb.String()
+}
+
+// priceCell formats a price in the row's display currency, without the
+// currency symbol to save room.
+func (i item) priceCell(v float64) string {
+	if v == 0 || i.header {
+		return ""
+	}
+	v, _, _ = i.converted(v)
+	if v >= 1000 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+// compact abbreviates large figures, e.g. 2.4T or 53.2M; "" for zero.
+func compact(v float64) string {
+	switch {
+	case v == 0:
+		return ""
+	case v >= 1e12:
+		return fmt.Sprintf("%.1fT", v/1e12)
+	case v >= 1e9:
+		return fmt.Sprintf("%.1fB", v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case v >= 1e3:
+		return fmt.Sprintf("%.1fK", v/1e3)
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
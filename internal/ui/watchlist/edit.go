@@ -0,0 +1,134 @@
+package watchlist
+
+import (
+	"slices"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// maxUndo bounds the undo stack; older edits are forgotten.
+const maxUndo = 50
+
+// edit is the list as it was before a change, for undo.
+type edit struct {
+	what  string
+	items []item
+	mode  SortMode
+	asc   bool
+}
+
+// remember records the list before a change described by what.
+func (m *Model) remember(what string) {
+	m.undo = append(m.undo, edit{what: what, items: slices.Clone(m.allItems), mode: m.sortMode, asc: m.sortAsc})
+	if len(m.undo) > maxUndo {
+		m.undo = slices.Delete(m.undo, 0, len(m.undo)-maxUndo)
+	}
+}
+
+// Undo restores the list as it was before the last edit and returns that
+// edit's description. It reports false when there is nothing to undo.
+func (m *Model) Undo() (string, bool) {
+	if len(m.undo) == 0 {
+		return "", false
+	}
+	e := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+	m.allItems = e.items
+	m.sortMode, m.sortAsc = e.mode, e.asc
+	m.applyFilter(m.filterQuery)
+	return e.what, true
+}
+
+// RemoveSymbol drops symbol from the list, keeping the cursor at the same
+// position. It reports false when the symbol is not listed.
+func (m *Model) RemoveSymbol(symbol string) bool {
+	i := slices.IndexFunc(m.allItems, func(it item) bool { return it.symbol == symbol })
+	if i < 0 {
+		return false
+	}
+	m.remember("remove " + symbol)
+	row := m.indexOf(symbol)
+	m.allItems = slices.Delete(slices.Clone(m.allItems), i, i+1)
+	m.applyFilter(m.filterQuery)
+	if n := len(m.list.Items()); row >= 0 && n > 0 {
+		m.list.Select(min(row, n-1))
+	}
+	return true
+}
+
+// Resync appends the symbols in add and drops those in remove after the
+// config file changes, keeping the selection, sort and undo history. It
+// reports whether any row changed.
+func (m *Model) Resync(add, remove []string, a models.Aliases) bool {
+	sel := m.SelectedSymbol()
+	items := slices.Clone(m.allItems)
+	n := len(items)
+	items = slices.DeleteFunc(items, func(it item) bool { return slices.Contains(remove, it.symbol) })
+	changed := len(items) != n
+	for _, sym := range add {
+		if !slices.ContainsFunc(items, func(it item) bool { return it.symbol == sym }) {
+			items = append(items, m.newItem(sym, a.Name(sym)))
+			changed = true
+		}
+	}
+	if !changed {
+		return false
+	}
+	m.allItems = items
+	m.applyFilter(m.filterQuery)
+	if i := m.indexOf(sel); i >= 0 {
+		m.list.Select(i)
+	}
+	return true
+}
+
+// MoveSelected moves the selected row delta places up (negative) or down
+// the visible list. A sorted list switches to custom order first, starting
+// from the order on screen. It reports false at either end.
+func (m *Model) MoveSelected(delta int) bool {
+	sel := m.SelectedSymbol()
+	visible := m.list.Items()
+	row := m.indexOf(sel)
+	if row < 0 || row+delta < 0 || row+delta >= len(visible) {
+		return false
+	}
+	next := visible[row+delta].(item)
+	if next.header {
+		// Rows stay within their section
+		return false
+	}
+	other := next.symbol
+
+	m.remember("move " + sel)
+	items := slices.Clone(m.allItems)
+	if m.sortMode != SortCustom || !m.sortAsc {
+		m.sortItems(items)
+		m.sortMode, m.sortAsc = SortCustom, true
+	}
+	a := slices.IndexFunc(items, func(it item) bool { return it.symbol == sel })
+	b := slices.IndexFunc(items, func(it item) bool { return it.symbol == other })
+	items[a], items[b] = items[b], items[a]
+	m.allItems = items
+	m.applyFilter(m.filterQuery)
+	return true
+}
+
+// Clear empties the list. It reports false when it is already empty.
+func (m *Model) Clear() bool {
+	if len(m.allItems) == 0 {
+		return false
+	}
+	m.remember("clear")
+	m.allItems = nil
+	m.applyFilter(m.filterQuery)
+	return true
+}
+
+// Symbols returns every listed symbol in list order, ignoring any filter.
+func (m Model) Symbols() []string {
+	out := make([]string, len(m.allItems))
+	for i, it := range m.allItems {
+		out[i] = it.symbol
+	}
+	return out
+}
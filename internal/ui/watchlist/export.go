@@ -0,0 +1,69 @@
+package watchlist
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/ni5arga/stock-tui/internal/market"
+)
+
+// tableHeader names the columns of an exported table.
+var tableHeader = []string{"Symbol", "Name", "Price", "Change", "Currency"}
+
+// tableRows returns the rows on screen, pinned row first, in display
+// order with the current filter applied. Change follows the change column:
+// daily or since start, and basis points for yields.
+func (m Model) tableRows() [][]string {
+	items := m.getFilteredItems()
+	if m.pinned != nil {
+		items = append([]item{*m.pinned}, items...)
+	}
+	rows := make([][]string, 0, len(items))
+	for _, it := range items {
+		price, change := "", ""
+		if it.price != 0 {
+			if market.IsYield(it.symbol) {
+				price = market.FormatYield(it.price)
+				change = market.FormatBP(it.yieldChangeBP())
+			} else {
+				format := "%.2f"
+				if it.price < 1 {
+					format = "%.4f"
+				}
+				price = fmt.Sprintf(format, it.price)
+				change = fmt.Sprintf("%+.2f%%", it.shownChange())
+			}
+		}
+		rows = append(rows, []string{it.symbol, it.name, price, change, it.currency})
+	}
+	return rows
+}
+
+// Markdown renders the visible rows as a Markdown table.
+func (m Model) Markdown() string {
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for _, c := range cells {
+			sb.WriteString(" " + strings.ReplaceAll(c, "|", `\|`) + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(tableHeader)
+	sb.WriteString("|---|---|--:|--:|---|\n")
+	for _, row := range m.tableRows() {
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+// CSV renders the visible rows as CSV with a header line.
+func (m Model) CSV() string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(tableHeader)
+	_ = w.WriteAll(m.tableRows())
+	return buf.String()
+}
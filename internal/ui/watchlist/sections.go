@@ -0,0 +1,156 @@
+package watchlist
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// SetSections groups the rows under the given section headers, in config
+// order. Symbols outside every section stay at the top without a header.
+func (m *Model) SetSections(sections []models.Section) {
+	m.sections = sections
+	m.collapsed = make(map[string]bool)
+	for _, s := range sections {
+		if s.Collapsed {
+			m.collapsed[s.Name] = true
+		}
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+// sectionOf returns the name of the first section listing symbol, or "".
+func (m Model) sectionOf(symbol string) string {
+	for _, s := range m.sections {
+		if slices.ContainsFunc(s.Symbols, func(x string) bool { return strings.EqualFold(x, symbol) }) {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// arrange puts sorted rows under their section headers. Sorting applies
+// within each section. Collapsed sections show only their header, except
+// while a filter is active so that matches are never hidden.
+func (m Model) arrange(items []item, filtering bool) []item {
+	if len(m.sections) == 0 {
+		return items
+	}
+	bySection := make(map[string][]item)
+	for _, it := range items {
+		s := m.sectionOf(it.symbol)
+		bySection[s] = append(bySection[s], it)
+	}
+	out := slices.Clone(bySection[""])
+	for _, s := range m.sections {
+		members := bySection[s.Name]
+		if filtering && len(members) == 0 {
+			continue
+		}
+		out = append(out, m.sectionHeader(s.Name))
+		if !m.collapsed[s.Name] || filtering {
+			out = append(out, members...)
+		}
+	}
+	return out
+}
+
+// sectionHeader builds the header row of a section, carrying the equal-
+// weight average of its members' shown change. Yields and unquoted
+// members are left out of the average.
+func (m Model) sectionHeader(name string) item {
+	h := item{header: true, name: name, collapsed: m.collapsed[name], session: m.session}
+	var sum float64
+	for _, it := range m.allItems {
+		if m.sectionOf(it.symbol) != name {
+			continue
+		}
+		h.count++
+		if it.price == 0 || market.IsYield(it.symbol) {
+			continue
+		}
+		sum += it.shownChange()
+		h.quoted++
+	}
+	if h.quoted > 0 {
+		h.changePct = sum / float64(h.quoted)
+	}
+	return h
+}
+
+// ToggleSection collapses or expands the section whose header is selected.
+// It reports false when the cursor is not on a header.
+func (m *Model) ToggleSection() bool {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok || !it.header {
+		return false
+	}
+	m.collapsed[it.name] = !m.collapsed[it.name]
+	m.applyFilter(m.filterQuery)
+	return true
+}
+
+// Collapsed returns the names of the collapsed sections, in config order.
+func (m Model) Collapsed() []string {
+	var out []string
+	for _, s := range m.sections {
+		if m.collapsed[s.Name] {
+			out = append(out, s.Name)
+		}
+	}
+	return out
+}
+
+// SetCollapsed collapses exactly the named sections. Names of sections
+// that no longer exist are ignored.
+func (m *Model) SetCollapsed(names []string) {
+	for _, s := range m.sections {
+		m.collapsed[s.Name] = slices.Contains(names, s.Name)
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+// expandFor opens the collapsed section holding symbol, if any.
+func (m *Model) expandFor(symbol string) {
+	if s := m.sectionOf(symbol); s != "" && m.collapsed[s] {
+		m.collapsed[s] = false
+		m.applyFilter(m.filterQuery)
+	}
+}
+
+// renderHeader formats a section header row: a fold marker, the name and
+// member count, and the section's average change in the change column.
+func renderHeader(it item, totalW, extraW int, selected bool) string {
+	symW, priceW, pctW := columnWidths(totalW, extraW)
+	marker := "▾"
+	if it.collapsed {
+		marker = "▸"
+	}
+	label := []rune(fmt.Sprintf("%s %s (%d)", marker, it.name, it.count))
+	if labelW := symW + 1 + priceW; len(label) > labelW {
+		label = append(label[:labelW-1], '…')
+	}
+	labelStr := fmt.Sprintf("%-*s", symW+1+priceW, string(label))
+	pctStr := fmt.Sprintf("%*s", pctW, "—")
+	if it.quoted > 0 {
+		pctStr = fmt.Sprintf("%+*.2f%%", pctW-1, it.changePct)
+	}
+
+	if selected {
+		return styles.SelectedItem.Render(labelStr + " " + pctStr)
+	}
+	pctStyle := styles.PositiveChange
+	if it.changePct < 0 {
+		pctStyle = styles.NegativeChange
+	}
+	if it.quoted == 0 {
+		pctStyle = lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	}
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	return fmt.Sprintf(" %s %s", labelStyle.Render(labelStr), pctStyle.Render(pctStr))
+}
@@ -0,0 +1,171 @@
+package watchlist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/market"
+	"github.com/ni5arga/stock-tui/internal/models"
+	"github.com/ni5arga/stock-tui/internal/ui/styles"
+)
+
+// listTop is the pane row of the first list item: below the border, the
+// pinned row and its rule, and the search box or sort indicator.
+func (m Model) listTop() int {
+	top := 1
+	if m.pinned != nil {
+		top += 2
+	}
+	if m.searchMode {
+		top += 4
+	} else {
+		top++
+	}
+	return top
+}
+
+// rowAt maps a pane position to the index of the list item drawn there.
+func (m Model) rowAt(x, y int) (int, bool) {
+	if x < 0 || x >= m.width {
+		return 0, false
+	}
+	local := y - m.listTop()
+	if local < 0 || local >= m.list.Paginator.PerPage {
+		return 0, false
+	}
+	index := local + m.list.Paginator.Page*m.list.Paginator.PerPage
+	if index >= len(m.list.Items()) {
+		return 0, false
+	}
+	return index, true
+}
+
+// tooltip renders the quote details box for the hovered row.
+func (m Model) tooltip(it item) string {
+	labelS := lipgloss.NewStyle().Foreground(styles.ColorSubtext).Width(10)
+	valueS := lipgloss.NewStyle().Foreground(styles.ColorText)
+	line := func(label, value string) string {
+		return labelS.Render(label) + valueS.Render(value)
+	}
+
+	title := it.symbol
+	if it.name != "" {
+		title += "  " + it.name
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(title)}
+
+	if it.price == 0 {
+		lines = append(lines, line("Price", "—"))
+	} else if market.IsYield(it.symbol) {
+		lines = append(lines,
+			line("Yield", market.FormatYield(it.price)),
+			line("Change", market.FormatBP(market.YieldChangeBP(it.price, it.changePct))))
+	} else {
+		prev := it.price / (1 + it.changePct/100)
+		pctStyle := styles.PositiveChange
+		if it.changePct < 0 {
+			pctStyle = styles.NegativeChange
+		}
+		lines = append(lines, line("Price", market.FormatPrice(it.price, it.currency, "%.2f")+" "+it.currency))
+		if v, code, ok := it.converted(it.price); ok {
+			lines = append(lines, line("In "+code, market.FormatPrice(v, code, "%.2f")))
+		}
+		lines = append(lines, labelS.Render("Change")+pctStyle.Render(fmt.Sprintf("%+.2f (%+.2f%%)", it.price-prev, it.changePct)))
+		if it.sessionBase > 0 {
+			since := (it.price/it.sessionBase - 1) * 100
+			sinceStyle := styles.PositiveChange
+			if since < 0 {
+				sinceStyle = styles.NegativeChange
+			}
+			lines = append(lines, labelS.Render("Session")+sinceStyle.Render(fmt.Sprintf("%+.2f (%+.2f%%)", it.price-it.sessionBase, since)))
+		}
+		if it.extSession != "" && it.extPrice > 0 {
+			label := "Pre-mkt"
+			if it.extSession == models.SessionPost {
+				label = "After hrs"
+			}
+			extStyle := styles.PositiveChange
+			if it.extChangePct < 0 {
+				extStyle = styles.NegativeChange
+			}
+			lines = append(lines, labelS.Render(label)+styles.ExtendedHours.Render(market.FormatPrice(it.extPrice, it.currency, "%.2f"))+
+				" "+extStyle.Italic(true).Render(fmt.Sprintf("(%+.2f%%)", it.extChangePct)))
+		}
+	}
+
+	if high, low, ok := it.yearRange(); ok && !market.IsYield(it.symbol) {
+		lines = append(lines, line("52W", fmt.Sprintf("%s – %s, %.0f%% of high",
+			market.FormatPrice(low, it.currency, "%.2f"), market.FormatPrice(high, it.currency, "%.2f"), it.price/high*100)))
+	}
+	if ex := market.ExchangeFor(it.symbol); ex.Name != "" {
+		lines = append(lines, line("Exchange", ex.Name))
+	}
+	updated := "—"
+	if !it.updated.IsZero() {
+		updated = it.updated.Local().Format("Jan 02 15:04:05")
+	}
+	if it.stale {
+		updated += lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(" ⚠")
+	}
+	lines = append(lines, line("Updated", updated))
+	if e := it.earnings; !e.Date.IsZero() && e.DaysUntil(time.Now()) >= 0 {
+		when := e.Date.Local().Format("Jan 02")
+		switch e.Timing {
+		case models.EarningsBeforeOpen:
+			when += ", before open"
+		case models.EarningsAfterClose:
+			when += ", after close"
+		}
+		lines = append(lines, line("Earnings", when))
+	}
+	if d := it.earnings.ExDividend; !d.IsZero() && it.earnings.ExDividendTomorrow(time.Now()) {
+		lines = append(lines, line("Ex-div", d.Local().Format("Jan 02")+", buy today to qualify"))
+	} else if !d.IsZero() && !d.Before(time.Now().Truncate(24*time.Hour)) {
+		lines = append(lines, line("Ex-div", d.Local().Format("Jan 02")))
+	}
+	if it.note != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtext).Italic(true).Render(it.note))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Background(styles.ColorSurface).
+		Padding(0, 1).
+		Width(m.width - 6).
+		Render(strings.Join(lines, "\n"))
+}
+
+// withTooltip draws the hovered row's tooltip over the pane content, below
+// the row when it fits and above it otherwise. The selection is untouched.
+func (m Model) withTooltip(content string) string {
+	items := m.list.Items()
+	if m.hoverIndex >= len(items) {
+		return content
+	}
+	it, ok := items[m.hoverIndex].(item)
+	if !ok || it.header {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	for len(lines) < m.height {
+		lines = append(lines, "")
+	}
+	row := m.listTop() - 1 + m.hoverIndex - m.list.Paginator.Page*m.list.Paginator.PerPage
+	tip := strings.Split(m.tooltip(it), "\n")
+
+	start := row + 1
+	if start+len(tip) > m.height {
+		start = row - len(tip)
+	}
+	if start < 0 {
+		return content
+	}
+	for i, l := range tip {
+		lines[start+i] = l
+	}
+	return strings.Join(lines, "\n")
+}
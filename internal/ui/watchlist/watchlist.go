@@ -3,13 +3,19 @@ package watchlist
 import (
 	"fmt"
 	"io"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ni5arga/stock-tui/internal/keys"
+	"github.com/ni5arga/stock-tui/internal/market"
 	"github.com/ni5arga/stock-tui/internal/models"
 	"github.com/ni5arga/stock-tui/internal/ui/styles"
 )
@@ -20,6 +26,7 @@ const (
 	SortByName SortMode = iota
 	SortByPrice
 	SortByChange
+	SortCustom // list order, as configured or rearranged
 )
 
 func (s SortMode) String() string {
@@ -30,6 +37,8 @@ func (s SortMode) String() string {
 		return "Price"
 	case SortByChange:
 		return "Change%"
+	case SortCustom:
+		return "Custom"
 	default:
 		return "Name"
 	}
@@ -41,26 +50,131 @@ type Model struct {
 	width       int
 	height      int
 	searchMode  bool
+	focused     bool
 	searchInput textinput.Model
 	filterQuery string // Current active filter (persists after search closes)
 	sortMode    SortMode
 	sortAsc     bool // true = ascending, false = descending
+	keys        keys.KeyMap
+	undo        []edit // list states before each edit, newest last
+	fx          market.Rates
+	pinned      *item // summary row kept above the list, if any
+	hovering    bool
+	hoverIndex  int  // list index under the mouse while hovering
+	session     bool // show change since the app started instead of daily
+	sections    []models.Section
+	collapsed   map[string]bool // section name -> folded under its header
+	static      bool            // reduced motion: no blinking search cursor
+	notes       map[string]string
+	earnings    map[string]models.Earnings
+	yearRanges  map[string]models.Fundamentals
+	columns     []column // optional columns, as configured
 }
 
 type item struct {
 	symbol    string
+	name      string // display alias, empty if none
 	price     float64
 	changePct float64
+	currency  string
+	flash     bool // alert recently fired
+	marked    bool // included in the compare chart
+	updated   time.Time
+	stale     bool // quote older than its refresh interval allows
+	note      string
+	earnings  models.Earnings // next report and ex-dividend date; zero when none
+
+	// Session figures, zero when the source doesn't report them
+	open, high, low float64
+	volume          float64
+	marketCap       float64
+	high52, low52   float64
+
+	yearHigh, yearLow float64 // 52-week range from the fundamentals
+
+	sessionBase float64 // first price seen this session
+	session     bool    // show change since sessionBase
+
+	extSession   models.ExtSession // pre-market or after hours, if trading
+	extPrice     float64
+	extChangePct float64
+
+	fx market.Rates // conversion into the display currency, if set
+
+	// Section header rows only
+	header    bool
+	collapsed bool
+	count     int // members listed
+	quoted    int // members in the average change
 }
 
-func (i item) Title() string       { return i.symbol }
+// key identifies a row across refreshes: its symbol, or its section for a
+// header.
+func (i item) key() string {
+	if i.header {
+		return "\x00" + i.name
+	}
+	return i.symbol
+}
+
+func (i item) Title() string       { return i.label() }
 func (i item) Description() string { return "" }
-func (i item) FilterValue() string { return i.symbol }
+func (i item) FilterValue() string { return i.symbol + " " + i.name }
+
+// shownChange is the % change displayed and sorted on: daily, or since
+// the session started.
+func (i item) shownChange() float64 {
+	if !i.session {
+		return i.changePct
+	}
+	if i.sessionBase <= 0 {
+		return 0
+	}
+	return (i.price/i.sessionBase - 1) * 100
+}
+
+// yieldChangeBP is the shown change of a yield in basis points.
+func (i item) yieldChangeBP() float64 {
+	if !i.session {
+		return market.YieldChangeBP(i.price, i.changePct)
+	}
+	if i.sessionBase <= 0 {
+		return 0
+	}
+	return (i.price - i.sessionBase) * 100
+}
+
+// extended reports whether the row shows an extended-hours quote instead
+// of the regular session. The since-start view always uses regular prices.
+func (i item) extended() bool {
+	return i.extSession != "" && i.extPrice > 0 && !i.session && !market.IsYield(i.symbol)
+}
+
+// converted returns v in the display currency and that currency's code,
+// or v and the native currency when no conversion applies. Yields are
+// never converted.
+func (i item) converted(v float64) (float64, string, bool) {
+	if market.IsYield(i.symbol) {
+		return v, i.currency, false
+	}
+	if c, ok := i.fx.Convert(v, i.currency); ok {
+		return c, i.fx.To, true
+	}
+	return v, i.currency, false
+}
+
+// label is the text shown in the symbol column.
+func (i item) label() string {
+	if i.name != "" {
+		return i.name
+	}
+	return i.symbol
+}
 
 func New(symbols []string) Model {
 	items := make([]item, len(symbols))
 	for i, s := range symbols {
-		items[i] = item{symbol: s}
+		items[i] = item{symbol: s, currency: market.ExchangeFor(s).Currency}
 	}
 
 	l := list.New(toListItems(items), newDelegate(), 0, 0)
@@ -73,9 +187,9 @@ func New(symbols []string) Model {
 
 	ti := textinput.New()
 	ti.Placeholder = "type to filter..."
-	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
-	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
-	ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(styles.ColorText).Bold(true)
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
 	ti.CharLimit = 30
 	ti.Width = 25
 
@@ -85,9 +199,18 @@ func New(symbols []string) Model {
 		searchInput: ti,
 		sortMode:    SortByName,
 		sortAsc:     true,
+		keys:        keys.Default(),
 	}
 }
 
+// SetKeyMap applies the configured bindings for navigation, search and
+// sorting.
+func (m *Model) SetKeyMap(km keys.KeyMap) {
+	m.keys = km
+	m.list.KeyMap.CursorUp = km.Up
+	m.list.KeyMap.CursorDown = km.Down
+}
+
 func toListItems(items []item) []list.Item {
 	result := make([]list.Item, len(items))
 	for i, it := range items {
@@ -96,7 +219,9 @@ func toListItems(items []item) []list.Item {
 	return result
 }
 
-type delegate struct{}
+type delegate struct {
+	columns []column
+}
 
 func newDelegate() delegate { return delegate{} }
 
@@ -109,60 +234,168 @@ func (d delegate) Render(w io.Writer, m list.Model, index int, listItem list.Ite
 	if !ok {
 		return
 	}
+	cols := fitColumns(d.columns, m.Width())
+	if it.header {
+		fmt.Fprint(w, renderHeader(it, m.Width(), extrasWidth(cols), index == m.Index()))
+		return
+	}
+	fmt.Fprint(w, renderRow(it, m.Width(), cols, index == m.Index()))
+}
 
-	// Dynamic widths based on list width
-	totalW := m.Width()
-	symW := 14
-	priceW := 12
-	pctW := 9
+// columnWidths sizes the symbol, price and change columns for a list of
+// width totalW, extraW of which goes to the optional columns.
+func columnWidths(totalW, extraW int) (symW, priceW, pctW int) {
+	symW, priceW, pctW = 14, 12, 9
+	if totalW > 40 || extraW > 0 {
+		symW = min(20, totalW-priceW-pctW-2-extraW)
+	}
+	return symW, priceW, pctW
+}
 
-	if totalW > 40 {
-		symW = min(20, totalW-priceW-pctW-2)
+// renderRow formats one watchlist row at the given width, followed by
+// the optional columns that fit.
+func renderRow(it item, totalW int, cols []column, selected bool) string {
+	symW, priceW, pctW := columnWidths(totalW, extrasWidth(cols))
+
+	// Extended-hours rows give up a little of the symbol column to a badge,
+	// and converted prices to the native currency code after the price
+	ext := it.extended()
+	badge := ""
+	labelW := symW
+	if ext {
+		badge = fmt.Sprintf(" %-3s", it.extSession)
+		labelW -= len(badge)
+	}
+	dot := ""
+	if it.note != "" {
+		dot = " •"
+		labelW -= 2
+	}
+	earn := ""
+	if it.earnings.Soon(time.Now()) {
+		earn = " ◆"
+		labelW -= 2
+	}
+	if it.earnings.ExDividendTomorrow(time.Now()) {
+		earn += " $"
+		labelW -= 2
+	}
+	year, nearHigh := it.yearBadge()
+	labelW -= len([]rune(year))
+	warn := ""
+	if it.stale {
+		warn = " ⚠"
+		labelW -= 2
+	}
+	_, _, conv := it.converted(it.price)
+	if conv {
+		labelW -= 4
+		priceW += 4
 	}
 
 	// Symbol - truncate if needed
-	sym := it.symbol
-	if len(sym) > symW {
-		sym = sym[:symW-1] + "…"
+	label := it.label()
+	if it.marked {
+		label = "● " + label
 	}
-	symStr := fmt.Sprintf("%-*s", symW, sym)
+	sym := []rune(label)
+	if len(sym) > labelW {
+		sym = append(sym[:labelW-1], '…')
+	}
+	symStr := fmt.Sprintf("%-*s", labelW+len([]rune(dot+earn+year+warn)), string(sym)+dot+earn+year+warn)
 
-	// Price
+	// Price, with the native currency symbol for non-USD listings
+	isYield := market.IsYield(it.symbol)
 	var priceStr string
 	if it.price == 0 {
 		priceStr = fmt.Sprintf("%*s", priceW, "—")
-	} else if it.price >= 1000 {
-		priceStr = fmt.Sprintf("%*.0f", priceW, it.price)
+	} else if isYield {
+		priceStr = fmt.Sprintf("%*s", priceW, market.FormatYield(it.price))
 	} else {
-		priceStr = fmt.Sprintf("%*.2f", priceW, it.price)
+		price := it.price
+		if ext {
+			price = it.extPrice
+		}
+		price, code, _ := it.converted(price)
+		format := "%.2f"
+		if price >= 1000 {
+			format = "%.0f"
+		}
+		p := fmt.Sprintf(format, price)
+		if code != "" && code != "USD" {
+			p = market.FormatPrice(price, code, format)
+		}
+		if conv {
+			p += fmt.Sprintf(" %-3s", it.currency)
+		}
+		priceStr = fmt.Sprintf("%*s", priceW, p)
 	}
 
 	// Percent change
+	// Yields change in basis points rather than relative percent
 	var pctStr string
 	if it.price == 0 {
 		pctStr = fmt.Sprintf("%*s", pctW, "—")
+	} else if isYield {
+		pctStr = fmt.Sprintf("%*s", pctW, market.FormatBP(it.yieldChangeBP()))
 	} else {
-		pctStr = fmt.Sprintf("%+*.2f%%", pctW-1, it.changePct)
+		change := it.shownChange()
+		if ext {
+			change = it.extChangePct
+		}
+		pctStr = fmt.Sprintf("%+*.2f%%", pctW-1, change)
 	}
 
-	// Style based on selection and trend
-	selected := index == m.Index()
-
-	if selected {
-		row := fmt.Sprintf("%s %s %s", symStr, priceStr, pctStr)
-		fmt.Fprint(w, styles.SelectedItem.Render(row))
-	} else {
-		symStyled := lipgloss.NewStyle().Foreground(styles.ColorText).Render(symStr)
-		priceStyled := lipgloss.NewStyle().Foreground(styles.ColorText).Render(priceStr)
+	extras := renderExtras(it, cols)
 
-		pctStyle := styles.PositiveChange
-		if it.changePct < 0 {
-			pctStyle = styles.NegativeChange
+	// Style based on selection and trend
+	if it.flash {
+		row := fmt.Sprintf("%s%s %s %s%s", symStr, badge, priceStr, pctStr, extras)
+		return styles.FlashItem.Render(row)
+	} else if selected {
+		row := fmt.Sprintf("%s%s %s %s%s", symStr, badge, priceStr, pctStr, extras)
+		return styles.SelectedItem.Render(row)
+	}
+	if it.stale {
+		// The last price may be well out of date, so the whole row goes dim
+		dim := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+		name, pad, _ := strings.Cut(symStr, warn)
+		symStyled := dim.Render(name) + lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(warn) + dim.Render(pad)
+		if ext {
+			symStyled += dim.Render(badge)
+		}
+		return fmt.Sprintf(" %s %s %s%s", symStyled, dim.Render(priceStr), dim.Render(pctStr), dim.Render(extras))
+	}
+	symStyled := lipgloss.NewStyle().Foreground(styles.ColorText).Render(symStr)
+	if dot+earn+year != "" {
+		yearStyle := styles.NegativeChange
+		if nearHigh {
+			yearStyle = styles.PositiveChange
 		}
-		pctStyled := pctStyle.Render(pctStr)
+		name, pad, _ := strings.Cut(symStr, dot+earn+year)
+		symStyled = lipgloss.NewStyle().Foreground(styles.ColorText).Render(name) +
+			lipgloss.NewStyle().Foreground(styles.ColorPrimary).Render(dot) +
+			lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(earn) +
+			yearStyle.Render(year) +
+			lipgloss.NewStyle().Foreground(styles.ColorText).Render(pad)
+	}
+	priceStyle := lipgloss.NewStyle().Foreground(styles.ColorText)
 
-		fmt.Fprint(w, fmt.Sprintf(" %s %s %s", symStyled, priceStyled, pctStyled))
+	pctStyle := styles.PositiveChange
+	if it.shownChange() < 0 {
+		pctStyle = styles.NegativeChange
+	}
+	if ext {
+		priceStyle = styles.ExtendedHours
+		pctStyle = styles.PositiveChange.Italic(true)
+		if it.extChangePct < 0 {
+			pctStyle = styles.NegativeChange.Italic(true)
+		}
+		symStyled += styles.ExtendedBadge.Render(badge)
 	}
+
+	extraStyle := lipgloss.NewStyle().Foreground(styles.ColorSubtext)
+	return fmt.Sprintf(" %s %s %s%s", symStyled, priceStyle.Render(priceStr), pctStyle.Render(pctStr), extraStyle.Render(extras))
 }
 
 func (m Model) Init() tea.Cmd {
@@ -199,42 +432,36 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "/":
+		switch {
+		case key.Matches(msg, m.keys.Search):
 			m.searchMode = true
 			m.searchInput.Focus()
+			if m.static {
+				return m, nil
+			}
 			return m, textinput.Blink
-		case "s":
+		case key.Matches(msg, m.keys.Sort):
 			m.cycleSort()
 			return m, nil
-		case "S":
+		case key.Matches(msg, m.keys.SortDirection):
 			m.sortAsc = !m.sortAsc
 			m.applySorting()
 			return m, nil
+		case key.Matches(msg, m.keys.ToggleSection):
+			if m.ToggleSection() {
+				return m, nil
+			}
 		}
 	case tea.MouseMsg:
-		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
-			// Check if click is within bounds of the pane
-			if msg.X >= 0 && msg.X < m.width && msg.Y >= 0 && msg.Y < m.height {
-				listHeight := m.list.Height()
-				if listHeight > 0 && listHeight <= m.height {
-					topOffset := (m.height - listHeight) / 2
-					if topOffset < 0 {
-						topOffset = 0
-					}
-					// Account for search bar if visible
-					if m.searchMode {
-						topOffset += 2
-					}
-					if msg.Y >= topOffset && msg.Y < topOffset+listHeight {
-						localIndex := msg.Y - topOffset
-						index := localIndex + m.list.Paginator.Page*m.list.Paginator.PerPage
-						if index >= 0 && index < len(m.list.Items()) {
-							m.list.Select(index)
-						}
-					}
-				}
+		index, onRow := m.rowAt(msg.X, msg.Y)
+		switch {
+		case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+			if onRow {
+				m.list.Select(index)
 			}
+		case msg.Action == tea.MouseActionMotion:
+			m.hovering = onRow
+			m.hoverIndex = index
 		}
 	}
 
@@ -243,38 +470,91 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// sortCycle is the order the sort key steps through. The direction key
+// still flips whichever mode is active.
+var sortCycle = []struct {
+	mode SortMode
+	asc  bool
+}{
+	{SortByName, true},
+	{SortByPrice, false},
+	{SortByChange, false},
+	{SortByChange, true},
+	{SortCustom, true},
+}
+
 func (m *Model) cycleSort() {
-	m.sortMode = (m.sortMode + 1) % 3
+	next := 0
+	for i, s := range sortCycle {
+		if s.mode == m.sortMode && s.asc == m.sortAsc {
+			next = (i + 1) % len(sortCycle)
+			break
+		}
+	}
+	m.sortMode, m.sortAsc = sortCycle[next].mode, sortCycle[next].asc
 	m.applySorting()
 }
 
+// applySorting re-sorts from the full list, so custom order comes back
+// as it was after other sorts.
 func (m *Model) applySorting() {
-	items := m.getFilteredItems()
+	m.applyFilter(m.filterQuery)
+}
+
+// setItems replaces the visible rows, keeping the cursor on the same
+// row when it is still shown. A symbol folded away leaves the cursor on
+// its section header.
+func (m *Model) setItems(items []item) {
+	prev, ok := m.list.SelectedItem().(item)
+	m.list.SetItems(toListItems(items))
+	if !ok {
+		return
+	}
+	keys := []string{prev.key()}
+	if s := m.sectionOf(prev.symbol); !prev.header && s != "" {
+		keys = append(keys, item{header: true, name: s}.key())
+	}
+	for _, k := range keys {
+		if i := slices.IndexFunc(items, func(it item) bool { return it.key() == k }); i >= 0 {
+			m.list.Select(i)
+			return
+		}
+	}
+}
 
+func (m *Model) sortItems(items []item) {
+	if m.sortMode == SortCustom {
+		if !m.sortAsc {
+			slices.Reverse(items)
+		}
+		return
+	}
 	sort.SliceStable(items, func(i, j int) bool {
 		var less bool
 		switch m.sortMode {
 		case SortByName:
-			less = strings.ToLower(items[i].symbol) < strings.ToLower(items[j].symbol)
+			less = strings.ToLower(items[i].label()) < strings.ToLower(items[j].label())
 		case SortByPrice:
-			less = items[i].price < items[j].price
+			pi, _, _ := items[i].converted(items[i].price)
+			pj, _, _ := items[j].converted(items[j].price)
+			less = pi < pj
 		case SortByChange:
-			less = items[i].changePct < items[j].changePct
+			less = items[i].shownChange() < items[j].shownChange()
 		}
 		if !m.sortAsc {
 			return !less
 		}
 		return less
 	})
-
-	m.list.SetItems(toListItems(items))
 }
 
 func (m *Model) getFilteredItems() []item {
 	listItems := m.list.Items()
-	items := make([]item, len(listItems))
-	for i, li := range listItems {
-		items[i] = li.(item)
+	items := make([]item, 0, len(listItems))
+	for _, li := range listItems {
+		if it := li.(item); !it.header {
+			items = append(items, it)
+		}
 	}
 	return items
 }
@@ -282,20 +562,14 @@ func (m *Model) getFilteredItems() []item {
 func (m *Model) applyFilter(query string) {
 	query = strings.ToLower(strings.TrimSpace(query))
 
-	if query == "" {
-		m.list.SetItems(toListItems(m.allItems))
-		m.applySorting()
-		return
-	}
-
-	filtered := make([]item, 0)
+	filtered := make([]item, 0, len(m.allItems))
 	for _, it := range m.allItems {
-		if strings.Contains(strings.ToLower(it.symbol), query) {
+		if query == "" || strings.Contains(strings.ToLower(it.FilterValue()), query) {
 			filtered = append(filtered, it)
 		}
 	}
-	m.list.SetItems(toListItems(filtered))
-	m.applySorting()
+	m.sortItems(filtered)
+	m.setItems(m.arrange(filtered, query != ""))
 }
 
 func (m Model) View() string {
@@ -306,7 +580,7 @@ func (m Model) View() string {
 		searchBoxStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(styles.ColorPrimary).
-			Background(lipgloss.Color("#1a1a2e")).
+			Background(styles.ColorSurface).
 			Padding(0, 1).
 			Width(m.width - 6)
 
@@ -315,13 +589,13 @@ func (m Model) View() string {
 			Bold(true)
 
 		typedTextStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
+			Foreground(styles.ColorText).
 			Bold(true)
 
 		cursorStyle := lipgloss.NewStyle().
 			Foreground(styles.ColorPrimary).
 			Bold(true).
-			Blink(true)
+			Blink(!m.static)
 
 		hintStyle := lipgloss.NewStyle().
 			Foreground(styles.ColorSubtext).
@@ -334,7 +608,7 @@ func (m Model) View() string {
 		searchLabel := labelStyle.Render("🔍 Search: ")
 		typedText := m.searchInput.Value()
 		if typedText == "" {
-			typedText = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render("type to filter...")
+			typedText = lipgloss.NewStyle().Foreground(styles.ColorSecondary).Render("type to filter...")
 		} else {
 			typedText = typedTextStyle.Render(typedText)
 		}
@@ -342,7 +616,7 @@ func (m Model) View() string {
 		searchField := typedText + cursor
 
 		// Show result count
-		resultCount := len(m.list.Items())
+		resultCount := len(m.getFilteredItems())
 		totalCount := len(m.allItems)
 		countStr := resultCountStyle.Render(fmt.Sprintf(" (%d/%d)", resultCount, totalCount))
 
@@ -354,56 +628,187 @@ func (m Model) View() string {
 
 		content = searchBox + "\n" + m.list.View()
 	} else {
-		// Show sort indicator in header
-		sortIndicator := ""
-		if m.sortMode != SortByName || !m.sortAsc {
-			arrow := "↑"
-			if !m.sortAsc {
-				arrow = "↓"
-			}
-			sortIndicator = lipgloss.NewStyle().
-				Foreground(styles.ColorSubtext).
-				Render(fmt.Sprintf(" [%s %s]", m.sortMode.String(), arrow))
-		}
+		content = lipgloss.NewStyle().Foreground(styles.ColorSubtext).Render(m.header()) + "\n" + m.list.View()
+	}
 
-		if sortIndicator != "" {
-			content = sortIndicator + "\n" + m.list.View()
-		} else {
-			content = m.list.View()
-		}
+	if m.pinned != nil {
+		content = m.pinnedView() + content
+	}
+	if m.hovering && !m.searchMode {
+		content = m.withTooltip(content)
 	}
 
-	return styles.Pane.
+	return styles.PaneFor(m.focused).
 		Width(m.width).
 		Height(m.height).
 		Render(content)
 }
 
+// SetFocused highlights the pane's border while it has the keyboard focus.
+func (m *Model) SetFocused(on bool) {
+	m.focused = on
+}
+
 func (m *Model) SetSize(w, h int) {
 	m.width = w
 	m.height = h
-	m.list.SetSize(w-4, h-4)
+	listH := h - 4
+	if m.pinned != nil {
+		listH -= 2 // pinned row and its rule
+	}
+	m.list.SetSize(w-4, listH)
 	m.searchInput.Width = w - 8
 }
 
+// header is the indicator line above the list: the active sort and the
+// session change mode, then the titles of the optional columns shown.
+func (m Model) header() string {
+	arrow := "↑"
+	if !m.sortAsc {
+		arrow = "↓"
+	}
+	parts := []string{fmt.Sprintf("[%s %s]", m.sortMode.String(), arrow)}
+	if m.session {
+		parts = append(parts, "[Since start]")
+	}
+	line := " " + strings.Join(parts, " ")
+	cols := fitColumns(m.columns, m.width-4)
+	if len(cols) == 0 {
+		return line
+	}
+	symW, priceW, pctW := columnWidths(m.width-4, extrasWidth(cols))
+	return fmt.Sprintf("%-*s", 1+symW+1+priceW+1+pctW, line) + columnTitles(cols)
+}
+
+// SetSessionChange switches the change column between the daily change
+// and the change since the first quote of this session.
+func (m *Model) SetSessionChange(on bool) {
+	m.session = on
+	for i := range m.allItems {
+		m.allItems[i].session = on
+	}
+	if m.pinned != nil {
+		m.pinned.session = on
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+// SetReducedMotion stops the search cursor from blinking.
+func (m *Model) SetReducedMotion(on bool) {
+	m.static = on
+	if on {
+		m.searchInput.Cursor.SetMode(cursor.CursorStatic)
+	}
+}
+
+// SetRates converts prices into the rates' display currency, with the
+// native currency noted after each converted price.
+func (m *Model) SetRates(r market.Rates) {
+	m.fx = r
+	for i := range m.allItems {
+		m.allItems[i].fx = r
+	}
+	if m.pinned != nil {
+		m.pinned.fx = r
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+// SessionChange reports whether the change since session start is shown.
+func (m Model) SessionChange() bool {
+	return m.session
+}
+
+// SetPinned keeps symbol's row at the top of the pane regardless of
+// scrolling, sorting and filtering. An empty symbol removes the pin.
+func (m *Model) SetPinned(symbol string) {
+	if symbol == "" {
+		m.pinned = nil
+	} else {
+		m.pinned = &item{symbol: symbol, currency: market.ExchangeFor(symbol).Currency, session: m.session, fx: m.fx,
+			note: m.notes[symbol], earnings: m.earnings[symbol]}
+		m.pinned.setYearRange(m.yearRanges)
+	}
+	m.SetSize(m.width, m.height)
+}
+
+// Pinned returns the pinned symbol, or "" when none is set.
+func (m Model) Pinned() string {
+	if m.pinned == nil {
+		return ""
+	}
+	return m.pinned.symbol
+}
+
+func (m Model) pinnedView() string {
+	row := renderRow(*m.pinned, m.width-4, fitColumns(m.columns, m.width-4), false)
+	rule := lipgloss.NewStyle().Foreground(styles.ColorSecondary).Render(strings.Repeat("─", lipgloss.Width(row)))
+	return row + "\n" + rule + "\n"
+}
+
+func (m Model) Size() (int, int) {
+	return m.width, m.height
+}
+
 func (m *Model) UpdateQuotes(quotes []models.Quote) {
 	qmap := make(map[string]models.Quote, len(quotes))
 	for _, q := range quotes {
 		qmap[q.Symbol] = q
 	}
 
-	// Update allItems with new data
-	for i, it := range m.allItems {
+	apply := func(it *item) {
 		if q, ok := qmap[it.symbol]; ok {
-			m.allItems[i].price = q.Price
-			m.allItems[i].changePct = q.ChangePct
+			it.price = q.Price
+			it.changePct = q.ChangePct
+			it.updated = q.LastUpdated
+			it.extSession, it.extPrice, it.extChangePct = q.ExtSession, q.ExtPrice, q.ExtChangePct
+			it.open, it.high, it.low = q.Open, q.DayHigh, q.DayLow
+			it.volume, it.marketCap = q.Volume, q.MarketCap
+			it.high52, it.low52 = q.High52, q.Low52
+			if it.sessionBase == 0 && q.Price > 0 {
+				it.sessionBase = q.Price
+			}
+			if q.Currency != "" {
+				it.currency = q.Currency
+			}
 		}
+		it.fx = m.fx
+	}
+
+	// Update allItems with new data
+	for i := range m.allItems {
+		apply(&m.allItems[i])
+	}
+	if m.pinned != nil {
+		apply(m.pinned)
 	}
 
 	// Re-apply filter and sort to update the visible list
 	m.applyFilter(m.filterQuery)
 }
 
+// MarkStale flags the rows whose last quote is older than maxAge allows
+// for their symbol, or unflags them; a maxAge of 0 never goes stale. It
+// returns the stale symbols in list order.
+func (m *Model) MarkStale(now time.Time, maxAge func(symbol string) time.Duration) []string {
+	var stale []string
+	check := func(it *item) {
+		limit := maxAge(it.symbol)
+		it.stale = !it.header && !it.updated.IsZero() && limit > 0 && now.Sub(it.updated) > limit
+		if it.stale && !slices.Contains(stale, it.symbol) {
+			stale = append(stale, it.symbol)
+		}
+	}
+	for i := range m.allItems {
+		check(&m.allItems[i])
+	}
+	if m.pinned != nil {
+		check(m.pinned)
+	}
+	m.applyFilter(m.filterQuery)
+	return stale
+}
+
 // UpdatePriceChange updates change % for a symbol based on historical data
 func (m *Model) UpdatePriceChange(symbol string, currentPrice, startPrice float64) {
 	// Update in allItems
@@ -421,6 +826,164 @@ func (m *Model) UpdatePriceChange(symbol string, currentPrice, startPrice float6
 	m.applyFilter(m.filterQuery)
 }
 
+// SetAliases applies display names to the rows.
+func (m *Model) SetAliases(a models.Aliases) {
+	for i, it := range m.allItems {
+		if name := a.Name(it.symbol); name != it.symbol {
+			m.allItems[i].name = name
+		} else {
+			m.allItems[i].name = ""
+		}
+	}
+	if m.pinned != nil {
+		if name := a.Name(m.pinned.symbol); name != m.pinned.symbol {
+			m.pinned.name = name
+		}
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+// SetNotes marks the rows of symbols with a note, which their tooltips
+// show in full.
+func (m *Model) SetNotes(notes map[string]string) {
+	m.notes = notes
+	for i := range m.allItems {
+		m.allItems[i].note = notes[m.allItems[i].symbol]
+	}
+	if m.pinned != nil {
+		m.pinned.note = notes[m.pinned.symbol]
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+// SetEarnings gives rows their symbol's next earnings report, flagging
+// those due within models.EarningsSoonDays and those going ex-dividend
+// tomorrow.
+func (m *Model) SetEarnings(earnings map[string]models.Earnings) {
+	m.earnings = earnings
+	for i := range m.allItems {
+		m.allItems[i].earnings = earnings[m.allItems[i].symbol]
+	}
+	if m.pinned != nil {
+		m.pinned.earnings = earnings[m.pinned.symbol]
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+// AddSymbol appends symbol to the list with an optional display name and
+// selects it. It reports false when the symbol is already listed.
+func (m *Model) AddSymbol(symbol, name string) bool {
+	for _, it := range m.allItems {
+		if it.symbol == symbol {
+			return false
+		}
+	}
+	m.remember("add " + symbol)
+	m.allItems = append(m.allItems, m.newItem(symbol, name))
+	m.applyFilter(m.filterQuery)
+	m.expandFor(symbol)
+	if i := m.indexOf(symbol); i >= 0 {
+		m.list.Select(i)
+	}
+	return true
+}
+
+// newItem returns a row for symbol with an optional display name, yet to
+// be quoted.
+func (m Model) newItem(symbol, name string) item {
+	if name == symbol {
+		name = ""
+	}
+	return item{
+		symbol:   symbol,
+		name:     name,
+		currency: market.ExchangeFor(symbol).Currency,
+		session:  m.session,
+		fx:       m.fx,
+		note:     m.notes[symbol],
+		earnings: m.earnings[symbol],
+		yearHigh: m.yearRanges[symbol].High52,
+		yearLow:  m.yearRanges[symbol].Low52,
+	}
+}
+
+// Scroll moves the cursor delta rows, as the mouse wheel does over the
+// list. It stops at either end.
+func (m *Model) Scroll(delta int) {
+	for ; delta > 0; delta-- {
+		m.list.CursorDown()
+	}
+	for ; delta < 0; delta++ {
+		m.list.CursorUp()
+	}
+}
+
+// Select moves the cursor to symbol, clearing a filter that hides it. It
+// reports false when the symbol is not in the list.
+func (m *Model) Select(symbol string) bool {
+	if !slices.ContainsFunc(m.allItems, func(it item) bool { return it.symbol == symbol }) {
+		return false
+	}
+	m.expandFor(symbol)
+	i := m.indexOf(symbol)
+	if i < 0 {
+		m.searchInput.SetValue("")
+		m.filterQuery = ""
+		m.applyFilter("")
+		i = m.indexOf(symbol)
+	}
+	m.list.Select(i)
+	return true
+}
+
+// indexOf returns the position of symbol among the visible rows, or -1.
+func (m Model) indexOf(symbol string) int {
+	return slices.IndexFunc(m.list.Items(), func(li list.Item) bool {
+		it := li.(item)
+		return !it.header && it.symbol == symbol
+	})
+}
+
+// SetFlash highlights or un-highlights a symbol's row.
+func (m *Model) SetFlash(symbol string, on bool) {
+	for i, it := range m.allItems {
+		if it.symbol == symbol {
+			m.allItems[i].flash = on
+			break
+		}
+	}
+	if m.pinned != nil && m.pinned.symbol == symbol {
+		m.pinned.flash = on
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+// ToggleMark adds or removes symbol from the compare set and reports
+// whether it is now marked.
+func (m *Model) ToggleMark(symbol string) bool {
+	on := false
+	for i, it := range m.allItems {
+		if it.symbol == symbol {
+			m.allItems[i].marked = !it.marked
+			on = m.allItems[i].marked
+			break
+		}
+	}
+	m.applyFilter(m.filterQuery)
+	return on
+}
+
+// Marked returns the symbols marked for comparison, in list order.
+func (m Model) Marked() []string {
+	var out []string
+	for _, it := range m.allItems {
+		if it.marked {
+			out = append(out, it.symbol)
+		}
+	}
+	return out
+}
+
 func (m Model) SelectedSymbol() string {
 	if it, ok := m.list.SelectedItem().(item); ok {
 		return it.symbol
@@ -436,3 +999,19 @@ func (m Model) IsSearching() bool {
 func (m Model) SortInfo() (SortMode, bool) {
 	return m.sortMode, m.sortAsc
 }
+
+// SetSort sorts the list by mode in the given direction.
+func (m *Model) SetSort(mode SortMode, asc bool) {
+	m.sortMode, m.sortAsc = mode, asc
+	m.applySorting()
+}
+
+// ParseSortMode parses a sort mode name as String gives it.
+func ParseSortMode(s string) (SortMode, bool) {
+	for _, mode := range []SortMode{SortByName, SortByPrice, SortByChange, SortCustom} {
+		if strings.EqualFold(mode.String(), s) {
+			return mode, true
+		}
+	}
+	return SortByName, false
+}
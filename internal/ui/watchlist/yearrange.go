@@ -0,0 +1,58 @@
+package watchlist
+
+import (
+	"fmt"
+
+	"github.com/ni5arga/stock-tui/internal/models"
+)
+
+// nearYearEdge is how close to its 52-week high or low, as a fraction of
+// it, a price gets a badge after its symbol.
+const nearYearEdge = 0.05
+
+// SetYearRanges gives rows the 52-week range from their symbol's
+// fundamentals, for sources whose quotes do not carry one.
+func (m *Model) SetYearRanges(fundamentals map[string]models.Fundamentals) {
+	m.yearRanges = fundamentals
+	for i := range m.allItems {
+		m.allItems[i].setYearRange(fundamentals)
+	}
+	if m.pinned != nil {
+		m.pinned.setYearRange(fundamentals)
+	}
+	m.applyFilter(m.filterQuery)
+}
+
+func (i *item) setYearRange(fundamentals map[string]models.Fundamentals) {
+	f := fundamentals[i.symbol]
+	i.yearHigh, i.yearLow = f.High52, f.Low52
+}
+
+// yearRange returns the 52-week high and low, from the quote when it has
+// them and the fundamentals otherwise, stretched to a price beyond them.
+func (i item) yearRange() (high, low float64, ok bool) {
+	high, low = i.high52, i.low52
+	if high <= low {
+		high, low = i.yearHigh, i.yearLow
+	}
+	if high <= low || i.price == 0 {
+		return 0, 0, false
+	}
+	return max(high, i.price), min(low, i.price), true
+}
+
+// yearBadge marks a price within nearYearEdge of its 52-week high, as a
+// percentage of it, or of its low; up tells which. It is "" otherwise.
+func (i item) yearBadge() (badge string, up bool) {
+	high, low, ok := i.yearRange()
+	if !ok || i.header {
+		return "", false
+	}
+	switch {
+	case i.price >= high*(1-nearYearEdge):
+		return fmt.Sprintf(" ▲%.0f%%", i.price/high*100), true
+	case i.price <= low*(1+nearYearEdge):
+		return fmt.Sprintf(" ▼%.0f%%", i.price/low*100), false
+	}
+	return "", false
+}